@@ -4,6 +4,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -24,6 +26,12 @@ type BytesCache interface {
 	Set(k string, v []byte, expire int32)
 }
 
+// Purger is implemented by caches that can remove entries at runtime
+type Purger interface {
+	// Purge removes entries with keys starting with prefix (all entries if prefix is empty) and returns their count
+	Purge(prefix string) int
+}
+
 type NullCache struct{}
 
 func (NullCache) Get(string) ([]byte, error) { return nil, ErrNotFound }
@@ -32,14 +40,40 @@ func (NullCache) Set(string, []byte, int32)  {}
 func NewExpireCache(maxsize uint64) BytesCache {
 	ec := expirecache.New(maxsize)
 	go ec.ApproximateCleaner(10 * time.Second)
-	return &ExpireCache{ec: ec}
+	c := &ExpireCache{ec: ec}
+	for i := range c.keys {
+		c.keys[i].keys = make(map[string]struct{})
+	}
+	go c.keysCleaner(10 * time.Second)
+	return c
+}
+
+// keyShards is a number of independently locked parts of keys index
+const keyShards = 64
+
+type keyShard struct {
+	sync.Mutex
+	keys map[string]struct{}
 }
 
 type ExpireCache struct {
 	ec *expirecache.Cache
+
+	// keys are tracked to purge entries by prefix, expirecache doesn't expose them
+	keys [keyShards]keyShard
+}
+
+// shard returns part of keys index for key, FNV-1a hash is used
+func (ec *ExpireCache) shard(k string) *keyShard {
+	h := uint32(2166136261)
+	for i := 0; i < len(k); i++ {
+		h ^= uint32(k[i])
+		h *= 16777619
+	}
+	return &ec.keys[h%keyShards]
 }
 
-func (ec ExpireCache) Get(k string) ([]byte, error) {
+func (ec *ExpireCache) Get(k string) ([]byte, error) {
 	v, ok := ec.ec.Get(k)
 
 	if !ok {
@@ -49,13 +83,55 @@ func (ec ExpireCache) Get(k string) ([]byte, error) {
 	return v.([]byte), nil
 }
 
-func (ec ExpireCache) Set(k string, v []byte, expire int32) {
+// Set stores entry under lock of its key shard, so concurrent Purge either expires it or doesn't see its key yet
+func (ec *ExpireCache) Set(k string, v []byte, expire int32) {
+	s := ec.shard(k)
+	s.Lock()
 	ec.ec.Set(k, v, uint64(len(v)), expire)
+	s.keys[k] = struct{}{}
+	s.Unlock()
 }
 
-func (ec ExpireCache) Items() int { return ec.ec.Items() }
+func (ec *ExpireCache) Items() int { return ec.ec.Items() }
+
+func (ec *ExpireCache) Size() uint64 { return ec.ec.Size() }
+
+// Purge expires entries with keys starting with prefix, they are removed from memory by the cleaner
+func (ec *ExpireCache) Purge(prefix string) int {
+	n := 0
+	for i := range ec.keys {
+		s := &ec.keys[i]
+		s.Lock()
+		for k := range s.keys {
+			if !strings.HasPrefix(k, prefix) {
+				continue
+			}
+			if _, ok := ec.ec.Get(k); ok {
+				ec.ec.Set(k, nil, 0, -1)
+				n++
+			}
+			delete(s.keys, k)
+		}
+		s.Unlock()
+	}
+	return n
+}
 
-func (ec ExpireCache) Size() uint64 { return ec.ec.Size() }
+// keysCleaner forgets keys that were expired or evicted, shards are locked one by one
+func (ec *ExpireCache) keysCleaner(d time.Duration) {
+	for range time.Tick(d) {
+		for i := range ec.keys {
+			s := &ec.keys[i]
+			s.Lock()
+			for k := range s.keys {
+				if _, ok := ec.ec.Get(k); !ok {
+					delete(s.keys, k)
+				}
+			}
+			s.Unlock()
+		}
+	}
+}
 
 func NewMemcached(prefix string, servers ...string) BytesCache {
 	return &MemcachedCache{prefix: prefix, client: memcache.New(servers...)}
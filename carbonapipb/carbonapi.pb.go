@@ -32,5 +32,8 @@ type AccessLogDetails struct {
 	UsedBackendCache              bool              `json:"used_backend_cache"`
 	ZipperRequests                uint64            `json:"zipper_requests,omitempty"`
 	TotalMetricsCount             uint64            `json:"total_metrics_count,omitempty"`
+	Truncated                     bool              `json:"truncated,omitempty"`
+	PushedDown                    []string          `json:"pushed_down,omitempty"`
+	MemoryPeakBytes               int64             `json:"memory_peak_bytes,omitempty"`
 	RequestHeaders                map[string]string `json:"request_headers"`
 }
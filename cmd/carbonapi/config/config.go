@@ -8,6 +8,7 @@ import (
 	"github.com/go-graphite/carbonapi/expr"
 	"github.com/go-graphite/carbonapi/expr/interfaces"
 	"github.com/go-graphite/carbonapi/limiter"
+	"github.com/go-graphite/carbonapi/pkg/auth"
 	"github.com/go-graphite/carbonapi/pkg/tlsconfig"
 	zipperCfg "github.com/go-graphite/carbonapi/zipper/config"
 	zipper "github.com/go-graphite/carbonapi/zipper/interfaces"
@@ -33,6 +34,7 @@ type CacheConfig struct {
 	ShortTimeoutSec     int32         `mapstructure:"shortTimeoutSec"`
 	ShortDuration       time.Duration `mapstructure:"shortDuration"`
 	ShortUntilOffsetSec int64         `mapstructure:"shortUntilOffsetSec"`
+	NegativeTimeoutSec  int32         `mapstructure:"negativeTimeoutSec"` // findCache only: timeout for empty (not found) responses
 }
 
 type GraphiteConfig struct {
@@ -54,6 +56,58 @@ type ExpvarConfig struct {
 	PProfEnabled bool   `mapstructure:"pprofEnabled"`
 }
 
+// TagDBConfig describes where tag writes (/tags/tagSeries, /tags/tagMultiSeries and /tags/delSeries) are forwarded to
+// and how long tag read responses are cached
+type TagDBConfig struct {
+	URL              string        `mapstructure:"url"`
+	User             string        `mapstructure:"user"`
+	Password         string        `mapstructure:"password" json:"-"`
+	Timeout          time.Duration `mapstructure:"timeout"`
+	CacheDurationSec int32         `mapstructure:"cacheDurationSec"`
+	MaxDetailsSeries int64         `mapstructure:"maxDetailsSeries"`
+}
+
+// AuditLogConfig describes which render requests are written to "audit" logger and how many of the slowest recent
+// requests are exported via expvar
+type AuditLogConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	SlowThreshold    time.Duration `mapstructure:"slowThreshold"`
+	SeriesThreshold  uint64        `mapstructure:"seriesThreshold"`
+	TopN             int           `mapstructure:"topN"`
+	TopWindow        time.Duration `mapstructure:"topWindow"`
+	File             string        `mapstructure:"file"`
+	SampleTick       string        `mapstructure:"sampleTick"`
+	SampleInitial    int           `mapstructure:"sampleInitial"`
+	SampleThereafter int           `mapstructure:"sampleThereafter"`
+}
+
+// CaptureConfig describes which render and find requests are written to Dir as mockbackend test cases, either when
+// Header is set to a truthy value by admin user or with SampleRate probability. At most MaxFiles cases of up to
+// MaxFileSize bytes are written, zero disables the limit.
+type CaptureConfig struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	Dir         string  `mapstructure:"dir"`
+	Header      string  `mapstructure:"header"`
+	SampleRate  float64 `mapstructure:"sampleRate"`
+	Listener    string  `mapstructure:"listener"`
+	MaxFileSize int64   `mapstructure:"maxFileSize"`
+	MaxFiles    int     `mapstructure:"maxFiles"`
+}
+
+// AdminConfig enables /_internal/admin API for users and groups authenticated by auth
+type AdminConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Users   []string `mapstructure:"users"`
+	Groups  []string `mapstructure:"groups"`
+}
+
+// ShutdownConfig describes graceful shutdown: readiness checks fail first, then after Delay servers stop accepting new
+// connections and in-flight requests have DrainTimeout to finish before they are cancelled
+type ShutdownConfig struct {
+	Delay        time.Duration `mapstructure:"delay"`
+	DrainTimeout time.Duration `mapstructure:"drainTimeout"`
+}
+
 type Listener struct {
 	Address string `mapstructure:"address"`
 
@@ -78,6 +132,7 @@ type ConfigType struct {
 	Concurency                 int                `mapstructure:"concurency"`
 	ResponseCacheConfig        CacheConfig        `mapstructure:"cache"`
 	BackendCacheConfig         CacheConfig        `mapstructure:"backendCache"`
+	FindCacheConfig            CacheConfig        `mapstructure:"findCache"`
 	Cpus                       int                `mapstructure:"cpus"`
 	TimezoneString             string             `mapstructure:"tz"`
 	UnicodeRangeTables         []string           `mapstructure:"unicodeRangeTables"`
@@ -88,6 +143,7 @@ type ConfigType struct {
 	AlwaysSendGlobsAsIs        *bool              `mapstructure:"alwaysSendGlobsAsIs"`
 	ExtractTagsFromArgs        bool               `mapstructure:"extractTagsFromArgs"`
 	PassFunctionsToBackend     bool               `mapstructure:"passFunctionsToBackend"`
+	ServeFilteringFunctions    bool               `mapstructure:"serveFilteringFunctions"`
 	MaxBatchSize               int                `mapstructure:"maxBatchSize"`
 	Zipper                     string             `mapstructure:"zipper"`
 	Upstreams                  zipperCfg.Config   `mapstructure:"upstreams"`
@@ -106,25 +162,38 @@ type ConfigType struct {
 	HTTPResponseStackTrace     bool               `mapstructure:"httpResponseStackTrace"`
 	UseCachingDNSResolver      bool               `mapstructure:"useCachingDNSResolver"`
 	CachingDNSRefreshTime      time.Duration      `mapstructure:"cachingDNSRefreshTime"`
+	TagDB                      TagDBConfig        `mapstructure:"tagdb"`
+	AuditLog                   AuditLogConfig     `mapstructure:"auditLog"`
+	Capture                    CaptureConfig      `mapstructure:"capture"`
+	Auth                       auth.Config        `mapstructure:"auth"`
+	Admin                      AdminConfig        `mapstructure:"admin"`
+	Shutdown                   ShutdownConfig     `mapstructure:"shutdown"`
 
 	TruncateTimeMap map[time.Duration]time.Duration `mapstructure:"truncateTime"`
 	TruncateTime    []DurationTruncate              `mapstructure:"-" json:"-"` // produce from TruncateTimeMap and sort in reverse order
 
 	MaxQueryLength              uint64 `mapstructure:"maxQueryLength"`
+	MaxMemoryPerRequestMB       uint64 `mapstructure:"maxMemoryPerRequestMB"`
 	CombineMultipleTargetsInOne bool   `mapstructure:"combineMultipleTargetsInOne"`
 
 	ResponseCache cache.BytesCache `mapstructure:"-" json:"-"`
 	BackendCache  cache.BytesCache `mapstructure:"-" json:"-"`
+	FindCache     cache.BytesCache `mapstructure:"-" json:"-"`
 
 	DefaultTimeZone *time.Location `mapstructure:"-" json:"-"`
 
 	// ZipperInstance is API entry to carbonzipper
 	ZipperInstance zipper.CarbonZipper `mapstructure:"-" json:"-"`
+	// ZipperAdmin allows to inspect backends, nil if zipper doesn't support it
+	ZipperAdmin zipper.Admin `mapstructure:"-" json:"-"`
 
 	// Limiter limits concurrent zipper requests
 	Limiter limiter.SimpleLimiter `mapstructure:"-" json:"-"`
 
 	Evaluator interfaces.Evaluator `mapstructure:"-" json:"-"`
+
+	// Authenticator authenticates requests, nil if authentication isn't configured
+	Authenticator *auth.Auth `mapstructure:"-" json:"-"`
 }
 
 // skipcq: CRT-P0003
@@ -137,8 +206,9 @@ func (c ConfigType) String() string {
 	}
 }
 
-func (c *ConfigType) SetZipper(zipper zipper.CarbonZipper) (err error) {
-	c.ZipperInstance = zipper
+func (c *ConfigType) SetZipper(z zipper.CarbonZipper) (err error) {
+	c.ZipperAdmin, _ = z.(zipper.Admin)
+	c.ZipperInstance = auth.NewZipper(z)
 	c.Evaluator, err = expr.NewEvaluator(c.Limiter, c.ZipperInstance, c.PassFunctionsToBackend)
 	return
 }
@@ -159,6 +229,12 @@ var Config = ConfigType{
 		DefaultTimeoutSec: 0,
 		ShortTimeoutSec:   0,
 	},
+	FindCacheConfig: CacheConfig{
+		Type:               "mem",
+		Size:               64,
+		DefaultTimeoutSec:  60,
+		NegativeTimeoutSec: 10,
+	},
 	TimezoneString: "",
 	Graphite: GraphiteConfig{
 		Pattern:  "{prefix}.{fqdn}",
@@ -172,6 +248,7 @@ var Config = ConfigType{
 
 	ResponseCache: cache.NullCache{},
 	BackendCache:  cache.NullCache{},
+	FindCache:     cache.NullCache{},
 
 	DefaultTimeZone: time.Local,
 	Logger:          []zapwriter.Config{DefaultLoggerConfig},
@@ -200,4 +277,24 @@ var Config = ConfigType{
 	HTTPResponseStackTrace: true,
 	UseCachingDNSResolver:  false,
 	CachingDNSRefreshTime:  1 * time.Minute,
+	TagDB: TagDBConfig{
+		Timeout:          5 * time.Second,
+		CacheDurationSec: 60,
+		MaxDetailsSeries: 100000,
+	},
+	AuditLog: AuditLogConfig{
+		SlowThreshold: 5 * time.Second,
+		TopN:          20,
+		TopWindow:     time.Hour,
+		File:          "stderr",
+	},
+	Capture: CaptureConfig{
+		Dir:         "/var/lib/carbonapi/capture",
+		Listener:    ":9070",
+		MaxFileSize: 10 * 1024 * 1024,
+		MaxFiles:    1000,
+	},
+	Shutdown: ShutdownConfig{
+		DrainTimeout: 10 * time.Second,
+	},
 }
@@ -27,6 +27,7 @@ import (
 	"github.com/go-graphite/carbonapi/expr/helper"
 	"github.com/go-graphite/carbonapi/expr/rewrite"
 	"github.com/go-graphite/carbonapi/limiter"
+	"github.com/go-graphite/carbonapi/pkg/auth"
 	"github.com/go-graphite/carbonapi/pkg/parser"
 	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
 )
@@ -74,6 +75,9 @@ func SetUpConfig(logger *zap.Logger, BuildVersion string) {
 	if n := viper.GetString("logger.encodingduration"); n != "" {
 		Config.Logger[0].EncodingDuration = n
 	}
+	if Config.AuditLog.Enabled {
+		Config.Logger = appendAuditLogger(Config.Logger, Config.AuditLog)
+	}
 	err := zapwriter.ApplyConfig(Config.Logger)
 	if err != nil {
 		logger.Fatal("failed to initialize logger with requested configuration",
@@ -152,6 +156,16 @@ func SetUpConfig(logger *zap.Logger, BuildVersion string) {
 		}
 	}
 
+	Config.Authenticator, err = auth.New(Config.Auth)
+	if err != nil {
+		logger.Fatal("failed to initialize authentication",
+			zap.Error(err),
+		)
+	}
+	if Config.Admin.Enabled && (Config.Authenticator == nil || len(Config.Admin.Users)+len(Config.Admin.Groups) == 0) {
+		logger.Fatal("admin API requires auth to be configured and admin users or groups to be set")
+	}
+
 	if Config.DefaultColors != nil {
 		for name, color := range Config.DefaultColors {
 			err = png.SetColor(name, color)
@@ -183,6 +197,7 @@ func SetUpConfig(logger *zap.Logger, BuildVersion string) {
 
 	Config.ResponseCache = createCache(logger, "cache", &Config.ResponseCacheConfig)
 	Config.BackendCache = createCache(logger, "backendCache", &Config.BackendCacheConfig)
+	Config.FindCache = createCache(logger, "findCache", &Config.FindCacheConfig)
 
 	if Config.TimezoneString != "" {
 		fields := strings.Split(Config.TimezoneString, ",")
@@ -288,7 +303,7 @@ func SetUpConfig(logger *zap.Logger, BuildVersion string) {
 }
 
 func createCache(logger *zap.Logger, cacheName string, cacheConfig *CacheConfig) cache.BytesCache {
-	if cacheConfig.DefaultTimeoutSec <= 0 && cacheConfig.ShortTimeoutSec <= 0 {
+	if cacheConfig.DefaultTimeoutSec <= 0 && cacheConfig.ShortTimeoutSec <= 0 && cacheConfig.NegativeTimeoutSec <= 0 {
 		return cache.NullCache{}
 	}
 	if cacheConfig.ShortTimeoutSec < 0 || cacheConfig.DefaultTimeoutSec == cacheConfig.ShortTimeoutSec {
@@ -330,6 +345,26 @@ func createCache(logger *zap.Logger, cacheName string, cacheConfig *CacheConfig)
 	}
 }
 
+// appendAuditLogger adds "audit" logger that writes to its own output with sampling, unless it's already configured
+func appendAuditLogger(loggers []zapwriter.Config, cfg AuditLogConfig) []zapwriter.Config {
+	for _, l := range loggers {
+		if l.Logger == "audit" {
+			return loggers
+		}
+	}
+	return append(loggers, zapwriter.Config{
+		Logger:           "audit",
+		File:             cfg.File,
+		Level:            "info",
+		Encoding:         "json",
+		EncodingTime:     "iso8601",
+		EncodingDuration: "seconds",
+		SampleTick:       cfg.SampleTick,
+		SampleInitial:    cfg.SampleInitial,
+		SampleThereafter: cfg.SampleThereafter,
+	})
+}
+
 func SetUpViper(logger *zap.Logger, configPath *string, exactConfig bool, viperPrefix string) {
 	if *configPath != "" {
 		b, err := os.ReadFile(*configPath)
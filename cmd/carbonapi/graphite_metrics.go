@@ -89,7 +89,12 @@ func setupGraphiteMetrics(logger *zap.Logger) {
 		metrics.Register("requests", http.ApiMetrics.RequestsH)
 
 		metrics.Register("find_requests", http.ApiMetrics.FindRequests)
+		metrics.Register("find_cache_hits", http.ApiMetrics.FindCacheHits)
+		metrics.Register("find_cache_misses", http.ApiMetrics.FindCacheMisses)
 		metrics.Register("render_requests", http.ApiMetrics.RenderRequests)
+		metrics.Register("tags_requests", http.ApiMetrics.TagsRequests)
+		metrics.Register("tags_cache_hits", http.ApiMetrics.TagsCacheHits)
+		metrics.Register("tags_cache_misses", http.ApiMetrics.TagsCacheMisses)
 
 		if http.ApiMetrics.MemcacheTimeouts != nil {
 			metrics.Register("memcache_timeouts", http.ApiMetrics.MemcacheTimeouts)
@@ -0,0 +1,215 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/lomik/zapwriter"
+	"go.uber.org/zap"
+
+	"github.com/go-graphite/carbonapi/cache"
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	"github.com/go-graphite/carbonapi/pkg/auth"
+	zipperCfg "github.com/go-graphite/carbonapi/zipper/config"
+	"github.com/go-graphite/carbonapi/zipper/httpHeaders"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
+)
+
+const adminPrefix = "/_internal/admin/"
+
+// adminAuthorized checks that user is listed in admin users or groups
+func adminAuthorized(id *auth.Identity) bool {
+	for _, u := range config.Config.Admin.Users {
+		if u == id.User {
+			return true
+		}
+	}
+	for _, g := range config.Config.Admin.Groups {
+		for _, userGroup := range id.Groups {
+			if g == userGroup {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// adminHandler serves /_internal/admin API, it's available only to admin users and every request is logged
+func adminHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	logger := zapwriter.Logger("access").With(
+		zap.String("handler", "admin"),
+		zap.String("url", r.URL.RequestURI()),
+		zap.String("peer", r.RemoteAddr),
+		zap.String("method", r.Method),
+	)
+
+	a := config.Config.Authenticator
+	if !config.Config.Admin.Enabled || a == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	id, err := a.Authenticate(r)
+	if err != nil {
+		if a.BasicAuthEnabled() {
+			w.Header().Set("WWW-Authenticate", `Basic realm="carbonapi"`)
+		} else {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+		}
+		logger.Warn("admin request failed",
+			zap.Int("http_code", http.StatusUnauthorized),
+			zap.Duration("runtime", time.Since(t0)),
+		)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	logger = logger.With(zap.String("username", id.User))
+	if !adminAuthorized(id) {
+		logger.Warn("admin request failed",
+			zap.Int("http_code", http.StatusForbidden),
+			zap.Duration("runtime", time.Since(t0)),
+		)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	res, code, err := serveAdmin(r.Context(), r)
+	if err != nil {
+		logger.Warn("admin request failed",
+			zap.Int("http_code", code),
+			zap.Duration("runtime", time.Since(t0)),
+			zap.Error(err),
+		)
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	body, e := json.Marshal(res)
+	if e != nil {
+		http.Error(w, e.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", httpHeaders.ContentTypeJSON)
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+
+	logger.Info("admin request served",
+		zap.Int("http_code", code),
+		zap.Duration("runtime", time.Since(t0)),
+	)
+}
+
+func serveAdmin(ctx context.Context, r *http.Request) (interface{}, int, error) {
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, config.Config.Prefix+adminPrefix), "/")
+	mutating := path == "tlds/refresh" || path == "cache/purge"
+	if mutating && r.Method != http.MethodPost {
+		return nil, http.StatusMethodNotAllowed, merry.New("use POST for " + path)
+	}
+	if !mutating && r.Method != http.MethodGet {
+		return nil, http.StatusMethodNotAllowed, merry.New("use GET for " + path)
+	}
+
+	admin := config.Config.ZipperAdmin
+	switch path {
+	case "backends":
+		if admin == nil {
+			return nil, http.StatusNotImplemented, merry.New("zipper doesn't support inspection")
+		}
+		return admin.Status(), http.StatusOK, nil
+	case "tlds":
+		if admin == nil {
+			return nil, http.StatusNotImplemented, merry.New("zipper doesn't support inspection")
+		}
+		return admin.TLDs(), http.StatusOK, nil
+	case "tlds/refresh":
+		if admin == nil {
+			return nil, http.StatusNotImplemented, merry.New("zipper doesn't support inspection")
+		}
+		if config.Config.Upstreams.TLDCacheDisabled {
+			return nil, http.StatusBadRequest, merry.New("tld cache is disabled")
+		}
+		tlds, err := admin.RefreshTLDs(ctx)
+		res := map[string]interface{}{"tlds": tlds}
+		if err != nil {
+			res["error"] = err.Error()
+		}
+		return res, http.StatusOK, nil
+	case "cache/purge":
+		return purgeCaches(r.FormValue("cache"), r.FormValue("prefix"))
+	case "config":
+		return sanitizedUpstreams(config.Config.Upstreams), http.StatusOK, nil
+	}
+	return nil, http.StatusNotFound, merry.New("unknown admin endpoint " + path)
+}
+
+// purgeCaches removes entries with keys starting with prefix from the named cache or from all caches if name is empty
+func purgeCaches(name, prefix string) (interface{}, int, error) {
+	caches := map[string]cache.BytesCache{
+		"response": config.Config.ResponseCache,
+		"backend":  config.Config.BackendCache,
+		"find":     config.Config.FindCache,
+	}
+	if name != "" {
+		c, ok := caches[name]
+		if !ok {
+			return nil, http.StatusBadRequest, merry.New("unknown cache " + name + ", use response, backend or find")
+		}
+		if _, ok := c.(cache.Purger); !ok {
+			return nil, http.StatusBadRequest, merry.New(name + " cache doesn't support purge")
+		}
+		caches = map[string]cache.BytesCache{name: c}
+	}
+
+	purged := make(map[string]int, len(caches))
+	for n, c := range caches {
+		if p, ok := c.(cache.Purger); ok {
+			purged[n] = p.Purge(prefix)
+		}
+	}
+	return map[string]interface{}{"purged": purged}, http.StatusOK, nil
+}
+
+func redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	return u.Redacted()
+}
+
+func redactURLs(servers []string) []string {
+	res := make([]string, 0, len(servers))
+	for _, s := range servers {
+		res = append(res, redactURL(s))
+	}
+	return res
+}
+
+// sanitizedUpstreams returns effective zipper config without credentials
+func sanitizedUpstreams(cfg zipperCfg.Config) zipperCfg.Config {
+	cfg.Backends = redactURLs(cfg.Backends)
+	backends := make([]zipperTypes.BackendV2, 0, len(cfg.BackendsV2.Backends))
+	for _, b := range cfg.BackendsV2.Backends {
+		b.Servers = redactURLs(b.Servers)
+		if b.BackendOptions != nil {
+			options := make(map[string]interface{}, len(b.BackendOptions))
+			for k, v := range b.BackendOptions {
+				lk := strings.ToLower(k)
+				if strings.Contains(lk, "password") || strings.Contains(lk, "secret") || strings.Contains(lk, "token") {
+					v = "xxxxx"
+				}
+				options[k] = v
+			}
+			b.BackendOptions = options
+		}
+		backends = append(backends, b)
+	}
+	cfg.BackendsV2.Backends = backends
+	return cfg
+}
@@ -0,0 +1,191 @@
+package http
+
+import (
+	"expvar"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lomik/zapwriter"
+	"go.uber.org/zap"
+
+	"github.com/go-graphite/carbonapi/carbonapipb"
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	"github.com/go-graphite/carbonapi/pkg/parser"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+)
+
+// slowQuery is a render request written to audit log
+type slowQuery struct {
+	Time           time.Time          `json:"time"`
+	CarbonapiUUID  string             `json:"carbonapi_uuid"`
+	Username       string             `json:"username,omitempty"`
+	Targets        []string           `json:"targets"`
+	From           int64              `json:"from"`
+	Until          int64              `json:"until"`
+	Runtime        float64            `json:"runtime"`
+	HTTPCode       int32              `json:"http_code"`
+	MetricsCount   uint64             `json:"metrics_count"`
+	ResponseSize   int64              `json:"response_size_bytes"`
+	FromCache      bool               `json:"from_cache"`
+	BackendCache   bool               `json:"used_backend_cache"`
+	BackendTimings map[string]float64 `json:"backend_timings,omitempty"`
+	RequestHeaders map[string]string  `json:"request_headers,omitempty"`
+}
+
+// slowQueries keeps the slowest render requests for the last window
+type slowQueries struct {
+	mu      sync.Mutex
+	size    int
+	window  time.Duration
+	queries []slowQuery
+}
+
+var topSlowQueries = &slowQueries{}
+
+func init() {
+	expvar.Publish("slow_queries", expvar.Func(func() interface{} {
+		return topSlowQueries.list(time.Now())
+	}))
+}
+
+func (s *slowQueries) expire(now time.Time) {
+	if s.window <= 0 {
+		return
+	}
+	queries := s.queries[:0]
+	for _, q := range s.queries {
+		if now.Sub(q.Time) <= s.window {
+			queries = append(queries, q)
+		}
+	}
+	s.queries = queries
+}
+
+func (s *slowQueries) add(q slowQuery) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.size = config.Config.AuditLog.TopN
+	s.window = config.Config.AuditLog.TopWindow
+	s.expire(q.Time)
+	if s.size <= 0 {
+		s.queries = s.queries[:0]
+		return
+	}
+
+	i := sort.Search(len(s.queries), func(i int) bool { return s.queries[i].Runtime < q.Runtime })
+	if i >= s.size {
+		return
+	}
+	s.queries = append(s.queries, slowQuery{})
+	copy(s.queries[i+1:], s.queries[i:])
+	s.queries[i] = q
+	if len(s.queries) > s.size {
+		s.queries = s.queries[:s.size]
+	}
+}
+
+// list returns the slowest recent queries, the slowest first
+func (s *slowQueries) list(now time.Time) []slowQuery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire(now)
+	return append([]slowQuery(nil), s.queries...)
+}
+
+// normalizeTargets returns targets as carbonapi parsed them, so the same queries look the same in audit log
+func normalizeTargets(targets []string) []string {
+	normalized := make([]string, 0, len(targets))
+	for _, target := range targets {
+		exp, e, err := parser.ParseExpr(target)
+		if err != nil || e != "" {
+			normalized = append(normalized, target)
+			continue
+		}
+		normalized = append(normalized, normalizeExpr(exp))
+	}
+	return normalized
+}
+
+// normalizeExpr prints expression without extra whitespace and with named arguments sorted
+func normalizeExpr(e parser.Expr) string {
+	if !e.IsFunc() {
+		return e.ToString()
+	}
+	args := make([]string, 0, e.ArgsLen()+len(e.NamedArgs()))
+	for _, arg := range e.Args() {
+		args = append(args, normalizeExpr(arg))
+	}
+	named := e.NamedArgs()
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, name+"="+normalizeExpr(named[name]))
+	}
+	return e.Target() + "(" + strings.Join(args, ",") + ")"
+}
+
+// auditRender writes render request to "audit" logger if it was slower than slowThreshold or fetched more than
+// seriesThreshold series
+func auditRender(accessLogDetails *carbonapipb.AccessLogDetails, timings *utilctx.BackendTimings, t0 time.Time) {
+	cfg := config.Config.AuditLog
+	if !cfg.Enabled {
+		return
+	}
+	runtime := time.Since(t0)
+	slow := cfg.SlowThreshold > 0 && runtime >= cfg.SlowThreshold
+	heavy := cfg.SeriesThreshold > 0 && accessLogDetails.TotalMetricsCount >= cfg.SeriesThreshold
+	if !slow && !heavy {
+		return
+	}
+
+	q := slowQuery{
+		Time:           t0,
+		CarbonapiUUID:  accessLogDetails.CarbonapiUUID,
+		Username:       accessLogDetails.Username,
+		Targets:        normalizeTargets(accessLogDetails.Targets),
+		From:           accessLogDetails.From,
+		Until:          accessLogDetails.Until,
+		Runtime:        runtime.Seconds(),
+		HTTPCode:       accessLogDetails.HTTPCode,
+		MetricsCount:   accessLogDetails.TotalMetricsCount,
+		ResponseSize:   accessLogDetails.CarbonapiResponseSizeBytes,
+		FromCache:      accessLogDetails.FromCache,
+		BackendCache:   accessLogDetails.UsedBackendCache,
+		RequestHeaders: accessLogDetails.RequestHeaders,
+	}
+	if timings != nil {
+		times := timings.Map()
+		q.BackendTimings = make(map[string]float64, len(times))
+		for backend, d := range times {
+			q.BackendTimings[backend] = d.Seconds()
+		}
+	}
+
+	zapwriter.Logger("audit").Info("audit",
+		zap.Time("time", q.Time),
+		zap.String("carbonapi_uuid", q.CarbonapiUUID),
+		zap.String("username", q.Username),
+		zap.Strings("targets", q.Targets),
+		zap.Int64("from", q.From),
+		zap.Int64("until", q.Until),
+		zap.Duration("runtime", runtime),
+		zap.Bool("slow", slow),
+		zap.Bool("heavy", heavy),
+		zap.Int32("http_code", q.HTTPCode),
+		zap.Uint64("metrics_count", q.MetricsCount),
+		zap.Int64("response_size_bytes", q.ResponseSize),
+		zap.Bool("from_cache", q.FromCache),
+		zap.Bool("used_backend_cache", q.BackendCache),
+		zap.Any("backend_timings", q.BackendTimings),
+		zap.Any("request_headers", q.RequestHeaders),
+	)
+
+	if slow {
+		topSlowQueries.add(q)
+	}
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-graphite/carbonapi/carbonapipb"
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+)
+
+func runtimes(queries []slowQuery) []float64 {
+	r := make([]float64, 0, len(queries))
+	for _, q := range queries {
+		r = append(r, q.Runtime)
+	}
+	return r
+}
+
+func TestSlowQueriesTopN(t *testing.T) {
+	prev := config.Config.AuditLog
+	defer func() { config.Config.AuditLog = prev }()
+	config.Config.AuditLog.TopN = 3
+	config.Config.AuditLog.TopWindow = time.Minute
+
+	now := time.Now()
+	s := &slowQueries{}
+	s.add(slowQuery{Time: now.Add(-2 * time.Minute), Runtime: 100})
+	for _, runtime := range []float64{2, 5, 1, 4, 3} {
+		s.add(slowQuery{Time: now, Runtime: runtime})
+	}
+	assert.Equal(t, []float64{5, 4, 3}, runtimes(s.list(now)))
+	assert.Empty(t, s.list(now.Add(2*time.Minute)))
+}
+
+func TestAuditRender(t *testing.T) {
+	prev := config.Config.AuditLog
+	defer func() { config.Config.AuditLog = prev }()
+	prevTop := topSlowQueries
+	defer func() { topSlowQueries = prevTop }()
+
+	config.Config.AuditLog = config.AuditLogConfig{
+		Enabled:         true,
+		SlowThreshold:   time.Second,
+		SeriesThreshold: 100,
+		TopN:            10,
+		TopWindow:       time.Hour,
+	}
+	topSlowQueries = &slowQueries{}
+
+	timings := &utilctx.BackendTimings{}
+	timings.Add("backend1", 2*time.Second)
+	timings.Add("backend1", time.Second)
+
+	// fast request with a few series isn't audited
+	auditRender(&carbonapipb.AccessLogDetails{Targets: []string{"a.b"}, TotalMetricsCount: 1}, timings, time.Now())
+	assert.Empty(t, topSlowQueries.list(time.Now()))
+
+	// heavy request is audited, but isn't one of the slowest
+	auditRender(&carbonapipb.AccessLogDetails{Targets: []string{"a.*"}, TotalMetricsCount: 100}, timings, time.Now())
+	assert.Empty(t, topSlowQueries.list(time.Now()))
+
+	auditRender(&carbonapipb.AccessLogDetails{
+		Targets:           []string{"sumSeries( a.* )", "aliasByNode(a.*, 1)"},
+		TotalMetricsCount: 2,
+		Username:          "user",
+	}, timings, time.Now().Add(-2*time.Second))
+	queries := topSlowQueries.list(time.Now())
+	if assert.Len(t, queries, 1) {
+		assert.Equal(t, []string{"sumSeries(a.*)", "aliasByNode(a.*,1)"}, queries[0].Targets)
+		assert.Equal(t, "user", queries[0].Username)
+		assert.Equal(t, map[string]float64{"backend1": 3}, queries[0].BackendTimings)
+	}
+}
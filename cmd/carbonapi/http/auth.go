@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/lomik/zapwriter"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/go-graphite/carbonapi/carbonapipb"
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	"github.com/go-graphite/carbonapi/pkg/auth"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+)
+
+// authenticate checks credentials of the request and stores user and its ACL in the context, ACL is enforced by
+// zipper (see auth.Zipper)
+func authenticate(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a := config.Config.Authenticator
+		if a == nil {
+			fn(w, r)
+			return
+		}
+
+		id, err := a.Authenticate(r)
+		var acl *auth.ACL
+		if err == nil {
+			acl, err = a.ACL(id)
+		}
+		if err != nil {
+			t0 := time.Now()
+			carbonapiUUID := uuid.NewV4().String()
+			srcIP, srcPort := splitRemoteAddr(r.RemoteAddr)
+			accessLogDetails := &carbonapipb.AccessLogDetails{
+				Handler:        "auth",
+				CarbonapiUUID:  carbonapiUUID,
+				URL:            r.URL.RequestURI(),
+				PeerIP:         srcIP,
+				PeerPort:       srcPort,
+				Host:           r.Host,
+				Referer:        r.Referer(),
+				URI:            r.RequestURI,
+				RequestHeaders: utilctx.GetLogHeaders(r.Context()),
+			}
+			if id != nil {
+				accessLogDetails.Username = id.User
+			}
+			code := merry.HTTPCode(err)
+			if code == http.StatusUnauthorized {
+				if a.BasicAuthEnabled() {
+					w.Header().Set("WWW-Authenticate", `Basic realm="carbonapi"`)
+				} else {
+					w.Header().Set("WWW-Authenticate", "Bearer")
+				}
+			}
+			setError(w, accessLogDetails, merry.Message(err), code, carbonapiUUID)
+			deferredAccessLogging(zapwriter.Logger("access"), accessLogDetails, t0, true)
+			return
+		}
+
+		ctx := auth.SetIdentity(r.Context(), id)
+		if acl != nil {
+			ctx = auth.SetACL(ctx, acl)
+		}
+		fn(w, r.WithContext(ctx))
+	}
+}
+
+// requestUsername returns authenticated user or user from basic auth if authentication isn't configured
+func requestUsername(r *http.Request) string {
+	if id := auth.GetIdentity(r.Context()); id != nil {
+		return id.User
+	}
+	username, _, _ := r.BasicAuth()
+	return username
+}
+
+// aclCacheKey makes cache key unique for the set of metrics user is allowed to read. ACL key is appended, so entries
+// of all users could be purged by the prefix of the original key
+func aclCacheKey(ctx context.Context, key string) string {
+	if acl := auth.GetACL(ctx); acl != nil {
+		return key + " " + acl.Key()
+	}
+	return key
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/lomik/zapwriter"
 	"go.uber.org/zap"
 
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
 	"github.com/go-graphite/carbonapi/zipper/httpHeaders"
 )
 
@@ -66,10 +67,10 @@ func capabilityHandler(wr http.ResponseWriter, req *http.Request) {
 		pvResponse := pb.CapabilityResponse{
 			SupportedProtocols:        []string{"carbonapi_v3_pb", "carbonapi_v2_pb", "graphite-web-pickle", "graphite-web-pickle-1.1", "carbonapi_v2_json"},
 			Name:                      hostname,
-			HighPrecisionTimestamps:   false,
-			SupportFilteringFunctions: false,
+			HighPrecisionTimestamps:   true,
+			SupportFilteringFunctions: config.Config.ServeFilteringFunctions,
 			LikeSplittedRequests:      false,
-			SupportStreaming:          false,
+			SupportStreaming:          true,
 		}
 
 		var data []byte
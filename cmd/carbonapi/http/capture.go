@@ -0,0 +1,299 @@
+package http
+
+import (
+	"bytes"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ansel1/merry"
+	"github.com/lomik/zapwriter"
+	uuid "github.com/satori/go.uuid"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	"github.com/go-graphite/carbonapi/pkg/auth"
+	"github.com/go-graphite/carbonapi/pkg/parser"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+)
+
+// captureCase is a mockbackend test case, see cmd/mockbackend
+type captureCase struct {
+	Version   string            `yaml:"version"`
+	Test      captureTest       `yaml:"test"`
+	Listeners []captureListener `yaml:"listeners"`
+}
+
+type captureTest struct {
+	Queries []captureQuery `yaml:"queries"`
+}
+
+type captureQuery struct {
+	Endpoint         string                  `yaml:"endpoint"`
+	Type             string                  `yaml:"type"`
+	URL              string                  `yaml:"URL"`
+	ExpectedResponse captureExpectedResponse `yaml:"expectedResponse"`
+}
+
+type captureExpectedResponse struct {
+	HTTPCode    int    `yaml:"httpCode"`
+	ContentType string `yaml:"contentType"`
+	Body        string `yaml:"body,omitempty"`
+}
+
+type captureListener struct {
+	Address     string                       `yaml:"address"`
+	Expressions map[string]captureExpression `yaml:"expressions"`
+}
+
+type captureExpression struct {
+	Code           int             `yaml:"code,omitempty"`
+	PathExpression string          `yaml:"pathExpression"`
+	Data           []captureMetric `yaml:"data"`
+}
+
+type captureMetric struct {
+	MetricName string    `yaml:"metricName"`
+	Step       int64     `yaml:"step,omitempty"`
+	StartTime  int64     `yaml:"startTime,omitempty"`
+	Values     []float64 `yaml:"values,omitempty"`
+	Branch     bool      `yaml:"branch,omitempty"`
+}
+
+var (
+	errCaptureTooLarge = merry.New("captured request exceeds maxFileSize")
+	errCaptureMaxFiles = merry.New("maxFiles captured requests are already written")
+)
+
+// captureMu serializes writes of captured requests, so maxFiles isn't exceeded
+var captureMu sync.Mutex
+
+// captureResponseWriter keeps a copy of the response sent to the client, up to limit bytes
+type captureResponseWriter struct {
+	http.ResponseWriter
+	code      int
+	body      bytes.Buffer
+	limit     int64
+	truncated bool
+}
+
+func (w *captureResponseWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *captureResponseWriter) Write(b []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	if !w.truncated {
+		if w.limit > 0 && int64(w.body.Len()+len(b)) > w.limit {
+			w.truncated = true
+			w.body = bytes.Buffer{}
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// shouldCapture checks if request is asked to be captured with a header or is sampled. Responses of backends are
+// recorded before ACL is applied, so requests of users with restricted access are never captured.
+func shouldCapture(r *http.Request) bool {
+	cfg := config.Config.Capture
+	if !cfg.Enabled || auth.GetACL(r.Context()) != nil {
+		return false
+	}
+	if cfg.Header != "" && parser.TruthyBool(r.Header.Get(cfg.Header)) {
+		// header could be set by anyone, so it's honored only for admin users
+		if id := auth.GetIdentity(r.Context()); id != nil && adminAuthorized(id) {
+			return true
+		}
+	}
+	return cfg.SampleRate > 0 && rand.Float64() < cfg.SampleRate
+}
+
+// capture writes requests to backends and the response of the handler as mockbackend test case, so it could be
+// replayed with `mockbackend replay`. Requests from other carbonapi with protobuf body can't be replayed from URL and
+// aren't captured.
+func capture(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !shouldCapture(r) {
+			h(w, r)
+			return
+		}
+		_ = r.ParseForm()
+		if format, _, _ := getFormat(r, jsonFormat); format == protoV3Format || format == protoV3StreamFormat {
+			h(w, r)
+			return
+		}
+
+		// handlers remove some of parameters from the form, e.g. jsonp
+		form := cloneForm(r.Form)
+		c := &utilctx.Capture{}
+		cw := &captureResponseWriter{ResponseWriter: w, limit: config.Config.Capture.MaxFileSize}
+		h(cw, r.WithContext(utilctx.SetCapture(r.Context(), c)))
+
+		logger := zapwriter.Logger("capture").With(zap.String("url", r.URL.RequestURI()))
+		path, err := writeCapture(r, form, c, cw)
+		if err != nil {
+			logger.Error("failed to write captured request", zap.Error(err))
+			return
+		}
+		logger.Info("request captured", zap.String("file", path))
+	}
+}
+
+func writeCapture(r *http.Request, form url.Values, c *utilctx.Capture, cw *captureResponseWriter) (string, error) {
+	cfg := config.Config.Capture
+	if cw.truncated {
+		return "", errCaptureTooLarge
+	}
+	tc := captureCase{
+		Version: "v1",
+		Test: captureTest{
+			Queries: []captureQuery{captureRequest(r, form, c, cw)},
+		},
+		Listeners: []captureListener{{
+			Address:     cfg.Listener,
+			Expressions: captureExpressions(c),
+		}},
+	}
+
+	b, err := yaml.Marshal(&tc)
+	if err != nil {
+		return "", err
+	}
+	if cfg.MaxFileSize > 0 && int64(len(b)) > cfg.MaxFileSize {
+		return "", errCaptureTooLarge
+	}
+
+	captureMu.Lock()
+	defer captureMu.Unlock()
+	if err := os.MkdirAll(cfg.Dir, 0755); err != nil {
+		return "", err
+	}
+	if cfg.MaxFiles > 0 {
+		files, err := filepath.Glob(filepath.Join(cfg.Dir, "*.yaml"))
+		if err != nil {
+			return "", err
+		}
+		if len(files) >= cfg.MaxFiles {
+			return "", errCaptureMaxFiles
+		}
+	}
+	name := cw.Header().Get(ctxHeaderUUID)
+	if name == "" {
+		name = uuid.NewV4().String()
+	}
+	path := filepath.Join(cfg.Dir, name+".yaml")
+	return path, os.WriteFile(path, b, 0644)
+}
+
+// captureRequest returns query with relative time range replaced with the resolved one and the response of carbonapi
+func captureRequest(r *http.Request, form url.Values, c *utilctx.Capture, cw *captureResponseWriter) captureQuery {
+	if from, until := c.TimeRange(); from != 0 || until != 0 {
+		form.Set("from", strconv.FormatInt(from, 10))
+		form.Set("until", strconv.FormatInt(until, 10))
+	}
+	q := captureQuery{
+		Endpoint: "http://" + r.Host,
+		Type:     http.MethodGet,
+		URL:      r.URL.Path + "?" + form.Encode(),
+		ExpectedResponse: captureExpectedResponse{
+			HTTPCode:    cw.code,
+			ContentType: cw.Header().Get("Content-Type"),
+		},
+	}
+	// png can't be compared on replay, other formats are checked as is
+	if cw.code < 300 && !strings.HasPrefix(q.ExpectedResponse.ContentType, contentTypePNG) && utf8.Valid(cw.body.Bytes()) {
+		q.ExpectedResponse.Body = cw.body.String()
+	}
+	return q
+}
+
+func cloneForm(form url.Values) url.Values {
+	res := make(url.Values, len(form))
+	for k, v := range form {
+		res[k] = append([]string(nil), v...)
+	}
+	return res
+}
+
+// captureExpressions converts recorded responses of backends to mockbackend expressions. If the same expression was
+// requested several times, the first response is kept.
+func captureExpressions(c *utilctx.Capture) map[string]captureExpression {
+	expressions := make(map[string]captureExpression)
+
+	for _, fetch := range c.Fetches() {
+		byExpression := make(map[string][]captureMetric)
+		if fetch.Response != nil {
+			for _, m := range fetch.Response.Metrics {
+				pathExpression := m.PathExpression
+				if pathExpression == "" && len(fetch.Request.Metrics) == 1 {
+					pathExpression = fetch.Request.Metrics[0].PathExpression
+				}
+				if pathExpression == "" {
+					pathExpression = m.Name
+				}
+				byExpression[pathExpression] = append(byExpression[pathExpression], captureMetric{
+					MetricName: m.Name,
+					Step:       m.StepTime,
+					StartTime:  m.StartTime,
+					Values:     m.Values,
+				})
+			}
+		}
+		for _, req := range fetch.Request.Metrics {
+			if _, ok := expressions[req.PathExpression]; ok {
+				continue
+			}
+			e := captureExpression{
+				PathExpression: req.PathExpression,
+				Data:           byExpression[req.PathExpression],
+			}
+			if len(e.Data) == 0 && fetch.Err != nil {
+				e.Code = merry.HTTPCode(fetch.Err)
+			}
+			expressions[req.PathExpression] = e
+		}
+	}
+
+	for _, find := range c.Finds() {
+		byQuery := make(map[string][]captureMetric)
+		if find.Response != nil {
+			for _, glob := range find.Response.Metrics {
+				for _, m := range glob.Matches {
+					byQuery[glob.Name] = append(byQuery[glob.Name], captureMetric{
+						MetricName: m.Path,
+						Branch:     !m.IsLeaf,
+					})
+				}
+			}
+		}
+		for _, query := range find.Request.Metrics {
+			if _, ok := expressions[query]; ok {
+				continue
+			}
+			e := captureExpression{
+				PathExpression: query,
+				Data:           byQuery[query],
+			}
+			if len(e.Data) == 0 && find.Err != nil {
+				e.Code = merry.HTTPCode(find.Err)
+			}
+			expressions[query] = e
+		}
+	}
+
+	return expressions
+}
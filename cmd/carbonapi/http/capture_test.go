@@ -0,0 +1,138 @@
+package http
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ansel1/merry"
+	pb "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	"github.com/go-graphite/carbonapi/pkg/auth"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+)
+
+func TestCaptureRender(t *testing.T) {
+	htpasswd := filepath.Join(t.TempDir(), "htpasswd")
+	// both passwords are "secret"
+	err := os.WriteFile(htpasswd, []byte("alice:$apr1$saltsalt$LrttParrLPdxvgutaSXWJ0\nbob:{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticator, err := auth.New(auth.Config{
+		HtpasswdFile: htpasswd,
+		Rules: []auth.Rule{
+			{Users: []string{"alice"}},
+			{Users: []string{"bob"}, Prefixes: []string{"foo"}},
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	saved := config.Config
+	defer func() { config.Config = saved }()
+	config.Config.Capture.Enabled = true
+	config.Config.Capture.Dir = t.TempDir()
+	config.Config.Capture.Header = "X-Carbonapi-Capture"
+	config.Config.Admin = config.AdminConfig{Users: []string{"alice", "bob"}}
+
+	captured := func() []string {
+		files, _ := filepath.Glob(filepath.Join(config.Config.Capture.Dir, "*.yaml"))
+		return files
+	}
+	request := func(user string, header bool) *httptest.ResponseRecorder {
+		req, rr := setUpRequest(t, "/render/?target=foo.bar&from=-10minutes&format=json")
+		if user != "" {
+			req.SetBasicAuth(user, "secret")
+		}
+		if header {
+			req.Header.Set(config.Config.Capture.Header, "1")
+		}
+		authenticate(capture(renderHandler))(rr, req)
+		return rr
+	}
+
+	request("", true)
+	assert.Empty(t, captured(), "header shouldn't be honored without authentication")
+
+	config.Config.Authenticator = authenticator
+	request("alice", false)
+	assert.Empty(t, captured(), "request without header shouldn't be captured")
+
+	request("bob", true)
+	assert.Empty(t, captured(), "request of user with restricted access shouldn't be captured")
+
+	config.Config.Admin.Users = []string{"bob"}
+	request("alice", true)
+	assert.Empty(t, captured(), "header should be honored only for admin users")
+
+	config.Config.Admin.Users = []string{"alice"}
+	config.Config.Capture.MaxFileSize = 100
+	request("alice", true)
+	assert.Empty(t, captured(), "request larger than maxFileSize shouldn't be captured")
+
+	config.Config.Capture.MaxFileSize = saved.Capture.MaxFileSize
+	rr := request("alice", true)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	files := captured()
+	if !assert.Len(t, files, 1) {
+		return
+	}
+	b, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	var tc captureCase
+	assert.NoError(t, yaml.Unmarshal(b, &tc))
+	if assert.Len(t, tc.Test.Queries, 1) {
+		q := tc.Test.Queries[0]
+		// relative time range is replaced with timestamps
+		u, err := url.Parse(q.URL)
+		assert.NoError(t, err)
+		from, _ := strconv.ParseInt(u.Query().Get("from"), 10, 64)
+		until, _ := strconv.ParseInt(u.Query().Get("until"), 10, 64)
+		assert.Equal(t, int64(600), until-from)
+		assert.Equal(t, "foo.bar", u.Query().Get("target"))
+		assert.Equal(t, http.StatusOK, q.ExpectedResponse.HTTPCode)
+		assert.Equal(t, rr.Body.String(), q.ExpectedResponse.Body)
+	}
+
+	config.Config.Capture.MaxFiles = 1
+	request("alice", true)
+	assert.Len(t, captured(), 1, "no more than maxFiles requests should be captured")
+}
+
+func TestCaptureExpressions(t *testing.T) {
+	c := &utilctx.Capture{}
+	c.AddFetch(pb.MultiFetchRequest{Metrics: []pb.FetchRequest{{PathExpression: "a.*"}, {PathExpression: "b"}}},
+		&pb.MultiFetchResponse{Metrics: []pb.FetchResponse{
+			{Name: "a.a", PathExpression: "a.*", StartTime: 60, StepTime: 60, Values: []float64{1, math.NaN()}},
+			{Name: "a.b", PathExpression: "a.*", StartTime: 60, StepTime: 60, Values: []float64{2, 3}},
+		}},
+		merry.New("b is not found").WithHTTPCode(http.StatusNotFound),
+	)
+	// the first response is kept
+	c.AddFetch(pb.MultiFetchRequest{Metrics: []pb.FetchRequest{{PathExpression: "a.*"}}}, &pb.MultiFetchResponse{}, nil)
+	c.AddFind(pb.MultiGlobRequest{Metrics: []string{"c.*"}}, &pb.MultiGlobResponse{Metrics: []pb.GlobResponse{
+		{Name: "c.*", Matches: []pb.GlobMatch{{Path: "c.d", IsLeaf: false}, {Path: "c.e", IsLeaf: true}}},
+	}}, nil)
+
+	got := captureExpressions(c)
+	assert.Len(t, got, 3)
+	if assert.Len(t, got["a.*"].Data, 2) {
+		assert.Equal(t, "a.a", got["a.*"].Data[0].MetricName)
+		assert.Equal(t, int64(60), got["a.*"].Data[0].Step)
+		assert.True(t, math.IsNaN(got["a.*"].Data[0].Values[1]))
+	}
+	assert.Equal(t, 0, got["a.*"].Code)
+	assert.Equal(t, captureExpression{PathExpression: "b", Code: http.StatusNotFound}, got["b"])
+	assert.Equal(t, []captureMetric{{MetricName: "c.d", Branch: true}, {MetricName: "c.e"}}, got["c.*"].Data)
+}
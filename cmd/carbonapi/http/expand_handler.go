@@ -24,7 +24,7 @@ func expandHandler(w http.ResponseWriter, r *http.Request) {
 	// TODO: Migrate to context.WithTimeout
 	// ctx, _ := context.WithTimeout(context.TODO(), config.Config.ZipperTimeout)
 	ctx := utilctx.SetUUID(r.Context(), uid.String())
-	username, _, _ := r.BasicAuth()
+	username := requestUsername(r)
 	requestHeaders := utilctx.GetLogHeaders(ctx)
 
 	format, ok, formatRaw := getFormat(r, treejsonFormat)
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/msaf1980/go-metrics"
+
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+	zipperCache "github.com/go-graphite/carbonapi/zipper/cache"
+)
+
+var (
+	findQueriesOnce sync.Once
+	findQueries     *zipperCache.QueryCache
+)
+
+// getFindQueries returns cache of in-flight find and tags requests. It only coalesces identical requests made while one
+// of them is in progress, caching is done by config.Config.FindCache
+func getFindQueries() *zipperCache.QueryCache {
+	findQueriesOnce.Do(func() {
+		expire := int32(config.Config.Upstreams.Timeouts.Find / time.Second)
+		if expire < 1 {
+			expire = 1
+		}
+		findQueries = zipperCache.NewQueryCache(uint64(config.Config.FindCacheConfig.Size*1024*1024), expire)
+		go findQueries.ApproximateCleaner(10 * time.Second)
+	})
+	return findQueries
+}
+
+// findCacheFetch returns response for the key from find cache. If it's not cached, it's fetched by fetch, identical
+// requests are coalesced and wait for the first one. fetch returns ok = false if response must not be shared or cached,
+// empty responses are cached for FindCacheConfig.NegativeTimeoutSec instead of timeout.
+// Returns fromCache = true if response was got from the cache or from another request.
+func findCacheFetch(ctx context.Context, key string, useCache bool, timeout int32, hits, misses metrics.Counter, fetch func() (b []byte, empty, ok bool)) (b []byte, fromCache bool) {
+	key = aclCacheKey(ctx, passHeadersCacheKey(ctx, key))
+	if useCache {
+		tc := time.Now()
+		b, err := config.Config.FindCache.Get(key)
+		td := time.Since(tc).Nanoseconds()
+		ApiMetrics.RequestsCacheOverheadNS.Add(uint64(td))
+		if err == nil {
+			hits.Add(1)
+			return b, true
+		}
+		misses.Add(1)
+
+		queries := getFindQueries()
+		item := queries.GetQueryItem(key)
+		res, shared := item.FetchOrLock(ctx)
+		if !shared {
+			b, empty, ok := fetch()
+			if !ok {
+				item.StoreAbort()
+				return b, false
+			}
+			if b == nil {
+				b = []byte{}
+			}
+			item.StoreAndUnlock(b, uint64(len(b)))
+			findCacheSet(key, b, empty, timeout)
+			// response is shared only with requests made while it was fetched, the rest should use the cache
+			queries.Expire(key)
+			return b, false
+		}
+		if res != nil {
+			return res.([]byte), true
+		}
+		// request we waited for failed or we are out of time, so just try to do it on our own
+	}
+
+	b, empty, ok := fetch()
+	if ok {
+		findCacheSet(key, b, empty, timeout)
+	}
+	return b, false
+}
+
+// passHeadersCacheKey adds headers passed to the backends (headersToPass) to the key, backends could answer differently
+// depending on them, e.x. if they check credentials of the user
+func passHeadersCacheKey(ctx context.Context, key string) string {
+	hdrs := utilctx.GetPassHeaders(ctx)
+	if len(hdrs) == 0 {
+		return key
+	}
+	v := make(url.Values, len(hdrs))
+	for name, value := range hdrs {
+		v.Set(name, value)
+	}
+	return key + " " + v.Encode()
+}
+
+func findCacheSet(key string, b []byte, empty bool, timeout int32) {
+	if empty {
+		timeout = config.Config.FindCacheConfig.NegativeTimeoutSec
+	}
+	if timeout <= 0 {
+		return
+	}
+	tc := time.Now()
+	config.Config.FindCache.Set(key, b, timeout)
+	td := time.Since(tc).Nanoseconds()
+	ApiMetrics.RequestsCacheOverheadNS.Add(uint64(td))
+}
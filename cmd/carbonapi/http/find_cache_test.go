@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/msaf1980/go-metrics"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+)
+
+func TestFindCacheFetchCoalesce(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	fetch := func() ([]byte, bool, bool) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return []byte("response"), false, true
+	}
+
+	hits, misses := metrics.NewCounter(), metrics.NewCounter()
+	var wg sync.WaitGroup
+	responses := make([][]byte, 5)
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i], _ = findCacheFetch(context.Background(), "test:coalesce", true, 60, hits, misses, fetch)
+		}(i)
+	}
+	// wait for all requests to miss the cache, so they are coalesced with the first one
+	for misses.Count() < uint64(len(responses)) {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, b := range responses {
+		assert.Equal(t, "response", string(b))
+	}
+
+	b, fromCache := findCacheFetch(context.Background(), "test:coalesce", true, 60, hits, misses, fetch)
+	assert.True(t, fromCache)
+	assert.Equal(t, "response", string(b))
+	assert.Equal(t, uint64(1), hits.Count())
+}
+
+func TestFindCacheFetchNegative(t *testing.T) {
+	defer func(timeout int32) { config.Config.FindCacheConfig.NegativeTimeoutSec = timeout }(config.Config.FindCacheConfig.NegativeTimeoutSec)
+	hits, misses := metrics.NewCounter(), metrics.NewCounter()
+	var calls int
+	fetch := func() ([]byte, bool, bool) {
+		calls++
+		return []byte{}, true, true
+	}
+
+	config.Config.FindCacheConfig.NegativeTimeoutSec = 0
+	findCacheFetch(context.Background(), "test:negative-disabled", true, 60, hits, misses, fetch)
+	findCacheFetch(context.Background(), "test:negative-disabled", true, 60, hits, misses, fetch)
+	assert.Equal(t, 2, calls)
+
+	config.Config.FindCacheConfig.NegativeTimeoutSec = 10
+	findCacheFetch(context.Background(), "test:negative", true, 60, hits, misses, fetch)
+	b, fromCache := findCacheFetch(context.Background(), "test:negative", true, 60, hits, misses, fetch)
+	assert.Equal(t, 3, calls)
+	assert.True(t, fromCache)
+	assert.Empty(t, b)
+
+	// failed requests are not cached
+	findCacheFetch(context.Background(), "test:failed", true, 60, hits, misses, func() ([]byte, bool, bool) {
+		calls++
+		return nil, false, false
+	})
+	_, fromCache = findCacheFetch(context.Background(), "test:failed", true, 60, hits, misses, fetch)
+	assert.False(t, fromCache)
+	assert.Equal(t, 5, calls)
+}
+
+func TestFindHandlerCache(t *testing.T) {
+	hits := ApiMetrics.FindCacheHits.Count()
+
+	for i := 0; i < 2; i++ {
+		req, rr := setUpRequest(t, "/metrics/find/?query=foo.cached&format=json&from=1000&until=2000")
+		findHandler(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `[{"allowChildren":0,"expandable":0,"leaf":1,"id":"foo.bar","text":"bar","context":{}}]`+"\n", rr.Body.String())
+	}
+	assert.Equal(t, hits+1, ApiMetrics.FindCacheHits.Count())
+
+	req, rr := setUpRequest(t, "/metrics/find/?query=foo.cached&format=json&from=1000&until=2000&noCache=1")
+	findHandler(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, hits+1, ApiMetrics.FindCacheHits.Count())
+}
+
+func TestFindCacheFetchPassHeaders(t *testing.T) {
+	fetch := func(user string) func() ([]byte, bool, bool) {
+		return func() ([]byte, bool, bool) { return []byte(user), false, true }
+	}
+
+	hits, misses := metrics.NewCounter(), metrics.NewCounter()
+	alice := utilctx.SetPassHeaders(context.Background(), map[string]string{"X-User": "alice"})
+	bob := utilctx.SetPassHeaders(context.Background(), map[string]string{"X-User": "bob"})
+
+	b, fromCache := findCacheFetch(alice, "test:headers", true, 60, hits, misses, fetch("alice"))
+	assert.False(t, fromCache)
+	assert.Equal(t, "alice", string(b))
+
+	b, fromCache = findCacheFetch(bob, "test:headers", true, 60, hits, misses, fetch("bob"))
+	assert.False(t, fromCache)
+	assert.Equal(t, "bob", string(b))
+
+	b, fromCache = findCacheFetch(alice, "test:headers", true, 60, hits, misses, fetch("alice"))
+	assert.True(t, fromCache)
+	assert.Equal(t, "alice", string(b))
+}
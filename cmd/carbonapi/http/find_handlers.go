@@ -2,11 +2,13 @@ package http
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,8 +24,10 @@ import (
 	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
 	"github.com/go-graphite/carbonapi/date"
 	"github.com/go-graphite/carbonapi/intervalset"
+	"github.com/go-graphite/carbonapi/pkg/parser"
 	utilctx "github.com/go-graphite/carbonapi/util/ctx"
 	"github.com/go-graphite/carbonapi/zipper/helper"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
 )
 
 // Find handler and it's helper functions
@@ -38,14 +42,57 @@ type treejson struct {
 
 var treejsonContext = make(map[string]int)
 
-func findTreejson(multiGlobs *pbv3.MultiGlobResponse) ([]byte, error) {
+const (
+	findHeaderTruncated  = "X-CarbonAPI-Truncated"
+	findHeaderNextCursor = "X-CarbonAPI-Next-Cursor"
+)
+
+// findPaginate leaves in multiGlobs only matches that go after cursor (in order of their paths), no more than limit of them
+// (limit <= 0 means no limit). Returns cursor for the next page, empty if nothing is left.
+func findPaginate(multiGlobs *pbv3.MultiGlobResponse, cursor string, limit int) string {
+	type globMatch struct {
+		glob  int
+		match pbv3.GlobMatch
+	}
+
+	var matches []globMatch
+	seen := make(map[string]struct{})
+	for i := range multiGlobs.Metrics {
+		for _, m := range multiGlobs.Metrics[i].Matches {
+			if strings.HasPrefix(m.Path, "_tag") || m.Path <= cursor {
+				continue
+			}
+			if _, ok := seen[m.Path]; ok {
+				continue
+			}
+			seen[m.Path] = struct{}{}
+			matches = append(matches, globMatch{glob: i, match: m})
+		}
+		multiGlobs.Metrics[i].Matches = nil
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].match.Path < matches[j].match.Path })
+
+	var next string
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+		next = base64.RawURLEncoding.EncodeToString([]byte(matches[limit-1].match.Path))
+	}
+	for _, m := range matches {
+		multiGlobs.Metrics[m.glob].Matches = append(multiGlobs.Metrics[m.glob].Matches, m.match)
+	}
+
+	return next
+}
+
+func findTreejson(multiGlobs *pbv3.MultiGlobResponse, wildcards bool) ([]byte, error) {
 	var b bytes.Buffer
 
 	var tree = make([]treejson, 0)
 
 	seen := make(map[string]struct{})
 
-	for _, globs := range multiGlobs.Metrics {
+	var wildcardBasepath string
+	for n, globs := range multiGlobs.Metrics {
 		basepath := globs.Name
 
 		if i := strings.LastIndex(basepath, "."); i != -1 {
@@ -53,6 +100,9 @@ func findTreejson(multiGlobs *pbv3.MultiGlobResponse) ([]byte, error) {
 		} else {
 			basepath = ""
 		}
+		if n == 0 {
+			wildcardBasepath = basepath
+		}
 
 		for _, g := range globs.Matches {
 			if strings.HasPrefix(g.Path, "_tag") {
@@ -97,17 +147,36 @@ func findTreejson(multiGlobs *pbv3.MultiGlobResponse) ([]byte, error) {
 		return natural.Less(tree[i].Text, tree[j].Text)
 	})
 
+	// graphite-web adds '*' node before the others if there is something to match it
+	if wildcards && len(tree) > 1 {
+		t := treejson{
+			ID:      wildcardBasepath + "*",
+			Context: treejsonContext,
+			Text:    "*",
+			Leaf:    1,
+		}
+		for i := range tree {
+			if tree[i].Leaf == 0 {
+				t.AllowChildren = 1
+				t.Expandable = 1
+				t.Leaf = 0
+				break
+			}
+		}
+		tree = append([]treejson{t}, tree...)
+	}
+
 	err := json.NewEncoder(&b).Encode(tree)
 	return b.Bytes(), err
 }
 
 type completer struct {
-	Path   string `json:"path"`
+	Path   string `json:"path,omitempty"`
 	Name   string `json:"name"`
-	IsLeaf string `json:"is_leaf"`
+	IsLeaf string `json:"is_leaf,omitempty"`
 }
 
-func findCompleter(multiGlobs *pbv3.MultiGlobResponse) ([]byte, error) {
+func findCompleter(multiGlobs *pbv3.MultiGlobResponse, wildcards bool, nextCursor string) ([]byte, error) {
 	var b bytes.Buffer
 
 	var complete = make([]completer, 0)
@@ -143,11 +212,20 @@ func findCompleter(multiGlobs *pbv3.MultiGlobResponse) ([]byte, error) {
 		}
 	}
 
+	// graphite-web adds '*' after the other metrics if there is something to match it
+	if wildcards && len(complete) > 1 {
+		complete = append(complete, completer{Name: "*"})
+	}
+
 	err := json.NewEncoder(&b).Encode(struct {
-		Metrics []completer `json:"metrics"`
+		Metrics   []completer `json:"metrics"`
+		Truncated bool        `json:"truncated,omitempty"`
+		Cursor    string      `json:"cursor,omitempty"`
 	}{
-		Metrics: complete},
-	)
+		Metrics:   complete,
+		Truncated: nextCursor != "",
+		Cursor:    nextCursor,
+	})
 	return b.Bytes(), err
 }
 
@@ -173,13 +251,34 @@ func findList(multiGlobs *pbv3.MultiGlobResponse) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+// findCacheKey returns key for the find cache. Backends (if at all) use from and until with far less precision than
+// they are requested with, so they are truncated to cache timeout in order to share responses between requests
+func findCacheKey(request *pbv3.MultiGlobRequest, timeout int32) string {
+	start, stop := request.StartTime, request.StopTime
+	if timeout > 0 {
+		start -= start % int64(timeout)
+		stop -= stop % int64(timeout)
+	}
+	return "find:" + strings.Join(request.Metrics, ",") + ":" + strconv.FormatInt(start, 10) + ":" + strconv.FormatInt(stop, 10)
+}
+
+// findIsEmpty returns true if there are no matches in the response
+func findIsEmpty(multiGlobs *pbv3.MultiGlobResponse) bool {
+	for i := range multiGlobs.Metrics {
+		if len(multiGlobs.Metrics[i].Matches) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func findHandler(w http.ResponseWriter, r *http.Request) {
 	t0 := time.Now()
 	uid := uuid.NewV4()
 	// TODO: Migrate to context.WithTimeout
 	// ctx, _ := context.WithTimeout(context.TODO(), config.Config.ZipperTimeout)
 	ctx := utilctx.SetUUID(r.Context(), uid.String())
-	username, _, _ := r.BasicAuth()
+	username := requestUsername(r)
 	requestHeaders := utilctx.GetLogHeaders(ctx)
 
 	format, ok, formatRaw := getFormat(r, treejsonFormat)
@@ -226,6 +325,30 @@ func findHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var limit int
+	if limitStr := r.FormValue("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			setError(w, &accessLogDetails, "invalid limit: "+limitStr, http.StatusBadRequest, uid.String())
+			logAsError = true
+			return
+		}
+	}
+
+	var cursor string
+	if cursorStr := r.FormValue("cursor"); cursorStr != "" {
+		c, err := base64.RawURLEncoding.DecodeString(cursorStr)
+		if err != nil {
+			setError(w, &accessLogDetails, "invalid cursor: "+cursorStr, http.StatusBadRequest, uid.String())
+			logAsError = true
+			return
+		}
+		cursor = string(c)
+	}
+
+	wildcards := parser.TruthyBool(r.FormValue("wildcards"))
+
 	if format == completerFormat {
 		var replacer = strings.NewReplacer("/", ".")
 		for i := range query {
@@ -268,7 +391,41 @@ func findHandler(w http.ResponseWriter, r *http.Request) {
 
 	accessLogDetails.Metrics = pv3Request.Metrics
 
-	multiGlobs, stats, err := config.Config.ZipperInstance.Find(ctx, pv3Request)
+	useCache := !parser.TruthyBool(r.FormValue("noCache")) && utilctx.GetCapture(ctx) == nil
+	accessLogDetails.UseCache = useCache
+
+	ApiMetrics.FindRequests.Add(1)
+	var multiGlobs *pbv3.MultiGlobResponse
+	var stats *zipperTypes.Stats
+	var err merry.Error
+	cacheTimeout := config.Config.FindCacheConfig.DefaultTimeoutSec
+	cached, fromCache := findCacheFetch(ctx, findCacheKey(&pv3Request, cacheTimeout), useCache, cacheTimeout, ApiMetrics.FindCacheHits, ApiMetrics.FindCacheMisses, func() ([]byte, bool, bool) {
+		multiGlobs, stats, err = config.Config.ZipperInstance.Find(ctx, pv3Request)
+		if err != nil {
+			// not found is cached as empty response, partial results and other errors are not cached
+			return nil, true, merry.HTTPCode(err) == http.StatusNotFound
+		}
+		b, err := multiGlobs.Marshal()
+		if err != nil {
+			return nil, false, false
+		}
+		return b, findIsEmpty(multiGlobs), true
+	})
+	if fromCache {
+		accessLogDetails.FromCache = true
+		if len(cached) == 0 {
+			err = merry.WithHTTPCode(zipperTypes.ErrNotFound, http.StatusNotFound)
+		} else {
+			multiGlobs = &pbv3.MultiGlobResponse{}
+			if e := multiGlobs.Unmarshal(cached); e != nil {
+				setError(w, &accessLogDetails, e.Error(), http.StatusInternalServerError, uid.String())
+				logAsError = true
+				return
+			}
+		}
+	} else {
+		accessLogDetails.CacheTimeout = cacheTimeout
+	}
 	if stats != nil {
 		accessLogDetails.ZipperRequests = stats.ZipperRequests
 		accessLogDetails.TotalMetricsCount += stats.TotalMetricsCount
@@ -296,15 +453,27 @@ func findHandler(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
+
+	// MultiGlobRequest has no limit or cursor, so backends always return everything and pages are cut here
+	var nextCursor string
+	if limit > 0 || cursor != "" {
+		nextCursor = findPaginate(multiGlobs, cursor, limit)
+		if nextCursor != "" {
+			accessLogDetails.Truncated = true
+			w.Header().Set(findHeaderTruncated, "true")
+			w.Header().Set(findHeaderNextCursor, nextCursor)
+		}
+	}
+
 	var b []byte
 	var err2 error
 	switch format {
 	case treejsonFormat, jsonFormat:
-		b, err2 = findTreejson(multiGlobs)
+		b, err2 = findTreejson(multiGlobs, wildcards)
 		err = merry.Wrap(err2)
 		format = jsonFormat
 	case completerFormat:
-		b, err2 = findCompleter(multiGlobs)
+		b, err2 = findCompleter(multiGlobs, wildcards, nextCursor)
 		err = merry.Wrap(err2)
 		format = jsonFormat
 	case rawFormat:
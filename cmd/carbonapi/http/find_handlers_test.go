@@ -0,0 +1,91 @@
+package http
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	pbv3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func getFindPaginationResponse() *pbv3.MultiGlobResponse {
+	return &pbv3.MultiGlobResponse{
+		Metrics: []pbv3.GlobResponse{
+			{
+				Name: "a.*",
+				Matches: []pbv3.GlobMatch{
+					{Path: "a.d", IsLeaf: true},
+					{Path: "a.b", IsLeaf: false},
+					{Path: "_tag.a", IsLeaf: true},
+					{Path: "a.c", IsLeaf: true},
+				},
+			},
+			{
+				Name: "a.e*",
+				Matches: []pbv3.GlobMatch{
+					{Path: "a.e", IsLeaf: true},
+					{Path: "a.d", IsLeaf: true},
+				},
+			},
+		},
+	}
+}
+
+func TestFindPaginate(t *testing.T) {
+	globs := getFindPaginationResponse()
+	next := findPaginate(globs, "", 2)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString([]byte("a.c")), next)
+	assert.Equal(t, []pbv3.GlobMatch{{Path: "a.b", IsLeaf: false}, {Path: "a.c", IsLeaf: true}}, globs.Metrics[0].Matches)
+	assert.Empty(t, globs.Metrics[1].Matches)
+
+	globs = getFindPaginationResponse()
+	next = findPaginate(globs, "a.c", 2)
+	assert.Equal(t, "", next)
+	assert.Equal(t, []pbv3.GlobMatch{{Path: "a.d", IsLeaf: true}}, globs.Metrics[0].Matches)
+	assert.Equal(t, []pbv3.GlobMatch{{Path: "a.e", IsLeaf: true}}, globs.Metrics[1].Matches)
+}
+
+func TestFindWildcards(t *testing.T) {
+	globs := &pbv3.MultiGlobResponse{
+		Metrics: []pbv3.GlobResponse{
+			{
+				Name: "a.*",
+				Matches: []pbv3.GlobMatch{
+					{Path: "a.c", IsLeaf: true},
+					{Path: "a.b", IsLeaf: false},
+				},
+			},
+		},
+	}
+
+	b, err := findTreejson(globs, true)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"allowChildren":1,"expandable":1,"leaf":0,"id":"a.*","text":"*","context":{}},`+
+		`{"allowChildren":1,"expandable":1,"leaf":0,"id":"a.b","text":"b","context":{}},`+
+		`{"allowChildren":0,"expandable":0,"leaf":1,"id":"a.c","text":"c","context":{}}]`+"\n", string(b))
+
+	b, err = findCompleter(globs, true, "YS5j")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"metrics":[{"path":"a.c","name":"c","is_leaf":"1"},{"path":"a.b.","name":"","is_leaf":"0"},{"name":"*"}],"truncated":true,"cursor":"YS5j"}`+"\n", string(b))
+}
+
+func TestFindHandlerPagination(t *testing.T) {
+	req, rr := setUpRequest(t, "/metrics/find/?query=foo.*&format=json&limit=1")
+	findHandler(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "", rr.Header().Get(findHeaderTruncated))
+
+	req, rr = setUpRequest(t, "/metrics/find/?query=foo.*&format=json&limit=1&cursor=Zm9vLmJhcg")
+	findHandler(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "[]\n", rr.Body.String())
+
+	req, rr = setUpRequest(t, "/metrics/find/?query=foo.*&format=json&cursor=!!")
+	findHandler(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	req, rr = setUpRequest(t, "/metrics/find/?query=foo.*&format=json&limit=-1")
+	findHandler(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -18,7 +18,7 @@ import (
 func functionsHandler(w http.ResponseWriter, r *http.Request) {
 	// TODO: Implement helper for specific functions
 	t0 := time.Now()
-	username, _, _ := r.BasicAuth()
+	username := requestUsername(r)
 
 	srcIP, srcPort := splitRemoteAddr(r.RemoteAddr)
 
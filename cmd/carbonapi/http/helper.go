@@ -28,6 +28,7 @@ const (
 	svgFormat
 	protoV2Format
 	protoV3Format
+	protoV3StreamFormat
 	pickleFormat
 	completerFormat
 )
@@ -46,6 +47,8 @@ func (r responseFormat) String() string {
 		return "protobuf3"
 	case protoV3Format:
 		return "carbonapi_v3_pb"
+	case protoV3StreamFormat:
+		return "carbonapi_v3_pb_stream"
 	case treejsonFormat:
 		return "treejson"
 	case pngFormat:
@@ -105,6 +108,8 @@ func (r responseFormat) ValidRenderFormat() bool {
 		return true
 	case protoV3Format:
 		return true
+	case protoV3StreamFormat:
+		return true
 	case pngFormat:
 		return true
 	case svgFormat:
@@ -118,19 +123,30 @@ func (r responseFormat) ValidRenderFormat() bool {
 	}
 }
 
+// SupportsHighPrecisionTimestamps reports if format is able to carry millisecond timestamps
+func (r responseFormat) SupportsHighPrecisionTimestamps() bool {
+	switch r {
+	case jsonFormat, csvFormat, protoV3Format, protoV3StreamFormat:
+		return true
+	default:
+		return false
+	}
+}
+
 var knownFormats = map[string]responseFormat{
-	"json":            jsonFormat,
-	"pickle":          pickleFormat,
-	"treejson":        treejsonFormat,
-	"protobuf":        protoV2Format,
-	"protobuf3":       protoV2Format,
-	"carbonapi_v2_pb": protoV2Format,
-	"carbonapi_v3_pb": protoV3Format,
-	"png":             pngFormat,
-	"csv":             csvFormat,
-	"raw":             rawFormat,
-	"svg":             svgFormat,
-	"completer":       completerFormat,
+	"json":                   jsonFormat,
+	"pickle":                 pickleFormat,
+	"treejson":               treejsonFormat,
+	"protobuf":               protoV2Format,
+	"protobuf3":              protoV2Format,
+	"carbonapi_v2_pb":        protoV2Format,
+	"carbonapi_v3_pb":        protoV3Format,
+	"carbonapi_v3_pb_stream": protoV3StreamFormat,
+	"png":                    pngFormat,
+	"csv":                    csvFormat,
+	"raw":                    rawFormat,
+	"svg":                    svgFormat,
+	"completer":              completerFormat,
 }
 
 const (
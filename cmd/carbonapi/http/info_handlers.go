@@ -22,7 +22,7 @@ func infoHandler(w http.ResponseWriter, r *http.Request) {
 	// TODO: Migrate to context.WithTimeout
 	// ctx, _ := context.WithTimeout(context.TODO(), config.Config.ZipperTimeout)
 	ctx := utilctx.SetUUID(r.Context(), uuid.String())
-	username, _, _ := r.BasicAuth()
+	username := requestUsername(r)
 	srcIP, srcPort := splitRemoteAddr(r.RemoteAddr)
 	format, ok, formatRaw := getFormat(r, jsonFormat)
 
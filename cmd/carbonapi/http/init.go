@@ -12,19 +12,32 @@ import (
 
 func InitHandlers(headersToPass, headersToLog []string) *http.ServeMux {
 	r := http.NewServeMux()
-	r.HandleFunc(config.Config.Prefix+"/render/", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(renderHandler, ctx.HeaderUUIDAPI)), bucketRequestTimes)))
-	r.HandleFunc(config.Config.Prefix+"/render", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(renderHandler, ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/render/", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(capture(renderHandler)), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/render", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(capture(renderHandler)), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
 
-	r.HandleFunc(config.Config.Prefix+"/metrics/find/", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(findHandler, ctx.HeaderUUIDAPI)), bucketRequestTimes)))
-	r.HandleFunc(config.Config.Prefix+"/metrics/find", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(findHandler, ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/metrics/find/", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(capture(findHandler)), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/metrics/find", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(capture(findHandler)), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
 
-	r.HandleFunc(config.Config.Prefix+"/metrics/expand/", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(expandHandler, ctx.HeaderUUIDAPI)), bucketRequestTimes)))
-	r.HandleFunc(config.Config.Prefix+"/metrics/expand", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(expandHandler, ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/metrics/expand/", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(expandHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/metrics/expand", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(expandHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
 
-	r.HandleFunc(config.Config.Prefix+"/info/", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(infoHandler, ctx.HeaderUUIDAPI)), bucketRequestTimes)))
-	r.HandleFunc(config.Config.Prefix+"/info", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(infoHandler, ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/metrics/index.json", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(indexHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/metrics/list/", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(indexHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/metrics/details/", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(detailsHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/metrics/details", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(detailsHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+
+	r.HandleFunc(config.Config.Prefix+"/info/", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(infoHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/info", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(infoHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+
+	r.HandleFunc(config.Config.Prefix+"/api/v1/query_range", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(promQueryRangeHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/api/v1/query", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(promQueryHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/api/v1/series", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(promSeriesHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/api/v1/labels", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(promLabelsHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
+	r.HandleFunc(config.Config.Prefix+"/api/v1/label/", httputil.TrackConnections(httputil.TimeHandler(enrichContextWithHeaders(headersToPass, headersToLog, ctx.ParseCtx(authenticate(promLabelsHandler), ctx.HeaderUUIDAPI)), bucketRequestTimes)))
 
 	r.HandleFunc(config.Config.Prefix+"/lb_check", lbcheckHandler)
+	r.HandleFunc(config.Config.Prefix+"/health/live", livenessHandler)
+	r.HandleFunc(config.Config.Prefix+"/health/ready", readinessHandler)
 
 	r.HandleFunc(config.Config.Prefix+"/version", versionHandler)
 	r.HandleFunc(config.Config.Prefix+"/version/", versionHandler)
@@ -32,12 +45,16 @@ func InitHandlers(headersToPass, headersToLog []string) *http.ServeMux {
 	r.HandleFunc(config.Config.Prefix+"/functions", enrichContextWithHeaders(headersToPass, headersToLog, functionsHandler))
 	r.HandleFunc(config.Config.Prefix+"/functions/", enrichContextWithHeaders(headersToPass, headersToLog, functionsHandler))
 
-	r.HandleFunc(config.Config.Prefix+"/tags", enrichContextWithHeaders(headersToPass, headersToLog, tagHandler))
-	r.HandleFunc(config.Config.Prefix+"/tags/", enrichContextWithHeaders(headersToPass, headersToLog, tagHandler))
+	r.HandleFunc(config.Config.Prefix+"/tags", enrichContextWithHeaders(headersToPass, headersToLog, authenticate(tagHandler)))
+	r.HandleFunc(config.Config.Prefix+"/tags/", enrichContextWithHeaders(headersToPass, headersToLog, authenticate(tagHandler)))
 
 	r.HandleFunc(config.Config.Prefix+"/_internal/capabilities", enrichContextWithHeaders(headersToPass, headersToLog, capabilityHandler))
 	r.HandleFunc(config.Config.Prefix+"/_internal/capabilities/", enrichContextWithHeaders(headersToPass, headersToLog, capabilityHandler))
 
+	if config.Config.Admin.Enabled {
+		r.HandleFunc(config.Config.Prefix+adminPrefix, adminHandler)
+	}
+
 	r.HandleFunc(config.Config.Prefix+"/", enrichContextWithHeaders(headersToPass, headersToLog, usageHandler))
 
 	if config.Config.Expvar.Enabled {
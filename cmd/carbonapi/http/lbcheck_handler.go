@@ -2,31 +2,86 @@ package http
 
 import (
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-graphite/carbonapi/carbonapipb"
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
 	"github.com/lomik/zapwriter"
 	"go.uber.org/zap"
 )
 
-func lbcheckHandler(w http.ResponseWriter, r *http.Request) {
-	t0 := time.Now()
-	accessLogger := zapwriter.Logger("access")
+var shuttingDown int32
+
+// SetShuttingDown makes lb_check and readiness checks fail, so load balancers stop sending new requests
+func SetShuttingDown() {
+	atomic.StoreInt32(&shuttingDown, 1)
+}
 
-	_, _ = w.Write([]byte("Ok\n"))
+func isShuttingDown() bool {
+	return atomic.LoadInt32(&shuttingDown) == 1
+}
+
+// backendsHealthy checks that at least one backend group answered the last TLD probe. Health is unknown if TLD cache
+// is disabled, backends are considered healthy then.
+func backendsHealthy() bool {
+	admin := config.Config.ZipperAdmin
+	if admin == nil || config.Config.Upstreams.TLDCacheDisabled {
+		return true
+	}
+	for _, group := range admin.Status().Children {
+		if group.Health == zipperTypes.HealthOK {
+			return true
+		}
+	}
+	return false
+}
+
+func healthCheckResponse(w http.ResponseWriter, r *http.Request, handler string, t0 time.Time, code int, body string) {
+	w.WriteHeader(code)
+	_, _ = w.Write([]byte(body))
 
 	srcIP, srcPort := splitRemoteAddr(r.RemoteAddr)
 
 	var accessLogDetails = carbonapipb.AccessLogDetails{
-		Handler:  "lbcheck",
+		Handler:  handler,
 		URL:      r.URL.RequestURI(),
 		PeerIP:   srcIP,
 		PeerPort: srcPort,
 		Host:     r.Host,
 		Referer:  r.Referer(),
 		Runtime:  time.Since(t0).Seconds(),
-		HTTPCode: http.StatusOK,
+		HTTPCode: int32(code),
 		URI:      r.RequestURI,
 	}
-	accessLogger.Info("request served", zap.Any("data", accessLogDetails))
+	zapwriter.Logger("access").Info("request served", zap.Any("data", accessLogDetails))
+}
+
+func lbcheckHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	if isShuttingDown() {
+		healthCheckResponse(w, r, "lbcheck", t0, http.StatusServiceUnavailable, "Shutting down\n")
+		return
+	}
+	healthCheckResponse(w, r, "lbcheck", t0, http.StatusOK, "Ok\n")
+}
+
+// livenessHandler answers while process is able to serve requests, even during shutdown
+func livenessHandler(w http.ResponseWriter, r *http.Request) {
+	healthCheckResponse(w, r, "liveness", time.Now(), http.StatusOK, "Ok\n")
+}
+
+// readinessHandler answers Ok if carbonapi isn't shutting down and at least one backend group is healthy
+func readinessHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	if isShuttingDown() {
+		healthCheckResponse(w, r, "readiness", t0, http.StatusServiceUnavailable, "Shutting down\n")
+		return
+	}
+	if !backendsHealthy() {
+		healthCheckResponse(w, r, "readiness", t0, http.StatusServiceUnavailable, "No healthy backends\n")
+		return
+	}
+	healthCheckResponse(w, r, "readiness", t0, http.StatusOK, "Ok\n")
 }
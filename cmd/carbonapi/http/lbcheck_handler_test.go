@@ -0,0 +1,58 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
+)
+
+type mockHealthZipperAdmin struct {
+	mockZipperAdmin
+	groups []zipperTypes.BackendStatus
+}
+
+func (z *mockHealthZipperAdmin) Status() zipperTypes.BackendStatus {
+	return zipperTypes.BackendStatus{Name: "root", Children: z.groups}
+}
+
+func TestHealthChecks(t *testing.T) {
+	saved := config.Config
+	defer func() {
+		config.Config = saved
+		atomic.StoreInt32(&shuttingDown, 0)
+	}()
+	zipperAdmin := &mockHealthZipperAdmin{groups: []zipperTypes.BackendStatus{
+		{Name: "group1", Health: zipperTypes.HealthFailed},
+		{Name: "group2", Health: zipperTypes.HealthOK},
+	}}
+	config.Config.ZipperAdmin = zipperAdmin
+	config.Config.Upstreams.TLDCacheDisabled = false
+
+	check := func(handler http.HandlerFunc) int {
+		rr := httptest.NewRecorder()
+		handler(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusOK, check(lbcheckHandler))
+	assert.Equal(t, http.StatusOK, check(livenessHandler))
+	assert.Equal(t, http.StatusOK, check(readinessHandler))
+
+	zipperAdmin.groups[1].Health = zipperTypes.HealthFailed
+	assert.Equal(t, http.StatusServiceUnavailable, check(readinessHandler), "no healthy backend groups")
+	assert.Equal(t, http.StatusOK, check(lbcheckHandler))
+
+	config.Config.Upstreams.TLDCacheDisabled = true
+	assert.Equal(t, http.StatusOK, check(readinessHandler), "health is unknown without TLD probes")
+
+	SetShuttingDown()
+	assert.Equal(t, http.StatusServiceUnavailable, check(lbcheckHandler))
+	assert.Equal(t, http.StatusServiceUnavailable, check(readinessHandler))
+	assert.Equal(t, http.StatusOK, check(livenessHandler))
+}
@@ -0,0 +1,267 @@
+package http
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/lomik/zapwriter"
+	uuid "github.com/satori/go.uuid"
+
+	pbv2 "github.com/go-graphite/protocol/carbonapi_v2_pb"
+	pbv3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+
+	"github.com/go-graphite/carbonapi/carbonapipb"
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+	"github.com/go-graphite/carbonapi/zipper/helper"
+	zipper "github.com/go-graphite/carbonapi/zipper/interfaces"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
+)
+
+// listWriteBufferSize is used to stream long lists of metrics to the client
+const listWriteBufferSize = 64 * 1024
+
+func validListFormat(format responseFormat) bool {
+	switch format {
+	case jsonFormat, protoV2Format, protoV3Format:
+		return true
+	default:
+		return false
+	}
+}
+
+// listError checks error returned by List or Details. Partial results are served if some of the backends failed,
+// otherwise error is written to the client. Returns if request failed and if it should be logged as error
+func listError(w http.ResponseWriter, accessLogDetails *carbonapipb.AccessLogDetails, err merry.Error, haveData bool, carbonapiUUID string) (bool, bool) {
+	if err == nil {
+		return false, false
+	}
+	if haveData && merry.Is(err, zipperTypes.ErrNonFatalErrors) {
+		accessLogDetails.Reason = err.Error()
+		return false, false
+	}
+
+	code := merry.HTTPCode(err)
+	if merry.Is(err, zipperTypes.ErrNotSupportedByBackend) {
+		code = http.StatusNotImplemented
+	}
+	setError(w, accessLogDetails, helper.MerryRootError(err), code, carbonapiUUID)
+	return true, code >= 500
+}
+
+// indexHandler serves graphite-web compatible /metrics/index.json: sorted list of all metrics known to backends.
+// Response is streamed as it could contain millions of metrics, protobuf formats return ListMetricsResponse.
+func indexHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	uid := uuid.NewV4()
+	ctx := utilctx.SetUUID(r.Context(), uid.String())
+	username := requestUsername(r)
+	srcIP, srcPort := splitRemoteAddr(r.RemoteAddr)
+	format, ok, formatRaw := getFormat(r, jsonFormat)
+	jsonp := r.FormValue("jsonp")
+
+	accessLogger := zapwriter.Logger("access")
+	var accessLogDetails = carbonapipb.AccessLogDetails{
+		Handler:        "index",
+		Username:       username,
+		CarbonapiUUID:  uid.String(),
+		URL:            r.URL.RequestURI(),
+		PeerIP:         srcIP,
+		PeerPort:       srcPort,
+		Host:           r.Host,
+		Referer:        r.Referer(),
+		Format:         formatRaw,
+		URI:            r.RequestURI,
+		RequestHeaders: utilctx.GetLogHeaders(ctx),
+	}
+
+	logAsError := false
+	defer func() {
+		deferredAccessLogging(accessLogger, &accessLogDetails, t0, logAsError)
+	}()
+
+	if !ok || !validListFormat(format) {
+		setError(w, &accessLogDetails, "unsupported format: "+formatRaw, http.StatusBadRequest, uid.String())
+		logAsError = true
+		return
+	}
+
+	var res *pbv3.ListMetricsResponse
+	var stats *zipperTypes.Stats
+	var err merry.Error
+	if lister, ok := config.Config.ZipperInstance.(zipper.Lister); ok {
+		res, stats, err = lister.List(ctx)
+	} else {
+		err = zipperTypes.ErrNotSupportedByBackend
+	}
+	if stats != nil {
+		accessLogDetails.ZipperRequests = stats.ZipperRequests
+		accessLogDetails.TotalMetricsCount += stats.TotalMetricsCount
+	}
+	if failed, isError := listError(w, &accessLogDetails, err, res != nil && len(res.Metrics) > 0, uid.String()); failed {
+		logAsError = isError
+		return
+	}
+
+	var metrics []string
+	if res != nil {
+		metrics = res.Metrics
+	}
+	sort.Strings(metrics)
+
+	w.Header().Set(ctxHeaderUUID, uid.String())
+	var e error
+	switch format {
+	case protoV2Format, protoV3Format:
+		w.Header().Set("Content-Type", contentTypeProtobuf)
+		e = writeIndexProtobuf(w, metrics)
+	default:
+		if jsonp != "" {
+			w.Header().Set("Content-Type", contentTypeJavaScript)
+		} else {
+			w.Header().Set("Content-Type", contentTypeJSON)
+		}
+		e = writeIndexJSON(w, metrics, jsonp)
+	}
+	accessLogDetails.HTTPCode = http.StatusOK
+	if e != nil {
+		// headers are already sent, so only thing that could be done is to log the error
+		accessLogDetails.Reason = e.Error()
+		logAsError = true
+	}
+}
+
+func writeIndexJSON(w io.Writer, metrics []string, jsonp string) error {
+	bw := bufio.NewWriterSize(w, listWriteBufferSize)
+	if jsonp != "" {
+		_, _ = bw.WriteString(jsonp)
+		_ = bw.WriteByte('(')
+	}
+	_ = bw.WriteByte('[')
+	for i, m := range metrics {
+		if i > 0 {
+			_ = bw.WriteByte(',')
+		}
+		b, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		if _, err = bw.Write(b); err != nil {
+			return err
+		}
+	}
+	_ = bw.WriteByte(']')
+	if jsonp != "" {
+		_ = bw.WriteByte(')')
+	}
+	return bw.Flush()
+}
+
+// writeIndexProtobuf encodes ListMetricsResponse (repeated string, field 1) one metric at a time, so the whole message
+// doesn't have to be kept in memory
+func writeIndexProtobuf(w io.Writer, metrics []string) error {
+	bw := bufio.NewWriterSize(w, listWriteBufferSize)
+	var size [binary.MaxVarintLen64]byte
+	for _, m := range metrics {
+		_ = bw.WriteByte(1<<3 | 2)
+		n := binary.PutUvarint(size[:], uint64(len(m)))
+		_, _ = bw.Write(size[:n])
+		if _, err := bw.WriteString(m); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// detailsHandler serves /metrics/details: size, modification and access time of every metric known to backends
+func detailsHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	uid := uuid.NewV4()
+	ctx := utilctx.SetUUID(r.Context(), uid.String())
+	username := requestUsername(r)
+	srcIP, srcPort := splitRemoteAddr(r.RemoteAddr)
+	format, ok, formatRaw := getFormat(r, jsonFormat)
+	jsonp := r.FormValue("jsonp")
+
+	accessLogger := zapwriter.Logger("access")
+	var accessLogDetails = carbonapipb.AccessLogDetails{
+		Handler:        "details",
+		Username:       username,
+		CarbonapiUUID:  uid.String(),
+		URL:            r.URL.RequestURI(),
+		PeerIP:         srcIP,
+		PeerPort:       srcPort,
+		Host:           r.Host,
+		Referer:        r.Referer(),
+		Format:         formatRaw,
+		URI:            r.RequestURI,
+		RequestHeaders: utilctx.GetLogHeaders(ctx),
+	}
+
+	logAsError := false
+	defer func() {
+		deferredAccessLogging(accessLogger, &accessLogDetails, t0, logAsError)
+	}()
+
+	if !ok || !validListFormat(format) {
+		setError(w, &accessLogDetails, "unsupported format: "+formatRaw, http.StatusBadRequest, uid.String())
+		logAsError = true
+		return
+	}
+
+	var res *pbv3.MetricDetailsResponse
+	var stats *zipperTypes.Stats
+	var err merry.Error
+	if lister, ok := config.Config.ZipperInstance.(zipper.Lister); ok {
+		res, stats, err = lister.Details(ctx)
+	} else {
+		err = zipperTypes.ErrNotSupportedByBackend
+	}
+	if stats != nil {
+		accessLogDetails.ZipperRequests = stats.ZipperRequests
+		accessLogDetails.TotalMetricsCount += stats.TotalMetricsCount
+	}
+	if failed, isError := listError(w, &accessLogDetails, err, res != nil && len(res.Metrics) > 0, uid.String()); failed {
+		logAsError = isError
+		return
+	}
+	if res == nil {
+		res = &pbv3.MetricDetailsResponse{}
+	}
+	if res.Metrics == nil {
+		res.Metrics = make(map[string]*pbv3.MetricDetails)
+	}
+
+	var b []byte
+	var e error
+	switch format {
+	case protoV2Format:
+		v2 := pbv2.MetricDetailsResponse{
+			Metrics:    make(map[string]*pbv2.MetricDetails, len(res.Metrics)),
+			FreeSpace:  res.FreeSpace,
+			TotalSpace: res.TotalSpace,
+		}
+		for name, d := range res.Metrics {
+			v2.Metrics[name] = &pbv2.MetricDetails{Size_: d.Size_, ModTime: d.ModTime, ATime: d.ATime, RdTime: d.RdTime}
+		}
+		b, e = v2.Marshal()
+	case protoV3Format:
+		b, e = res.Marshal()
+	default:
+		b, e = json.Marshal(res)
+	}
+	if e != nil {
+		setError(w, &accessLogDetails, e.Error(), http.StatusInternalServerError, uid.String())
+		logAsError = true
+		return
+	}
+
+	writeResponse(w, http.StatusOK, b, format, jsonp, uid.String())
+	accessLogDetails.HTTPCode = http.StatusOK
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ansel1/merry"
+	pbv3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	"github.com/go-graphite/carbonapi/pkg/auth"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
+)
+
+type mockListerZipper struct {
+	mockCarbonZipper
+}
+
+func (z mockListerZipper) List(ctx context.Context) (*pbv3.ListMetricsResponse, *zipperTypes.Stats, merry.Error) {
+	return &pbv3.ListMetricsResponse{Metrics: []string{"foo.baz", "foo.bar", `foo."quoted"`}}, &zipperTypes.Stats{}, nil
+}
+
+func (z mockListerZipper) Details(ctx context.Context) (*pbv3.MetricDetailsResponse, *zipperTypes.Stats, merry.Error) {
+	return &pbv3.MetricDetailsResponse{
+		Metrics:    map[string]*pbv3.MetricDetails{"foo.bar": {Size_: 1024, ModTime: 1600000000, ATime: 1600000060}},
+		FreeSpace:  10,
+		TotalSpace: 20,
+	}, &zipperTypes.Stats{}, nil
+}
+
+func TestIndexHandler(t *testing.T) {
+	saved := config.Config.ZipperInstance
+	defer func() { config.Config.ZipperInstance = saved }()
+	config.Config.ZipperInstance = auth.NewZipper(mockListerZipper{})
+
+	req, rr := setUpRequest(t, "/metrics/index.json")
+	indexHandler(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `["foo.\"quoted\"","foo.bar","foo.baz"]`, rr.Body.String())
+
+	req, rr = setUpRequest(t, "/metrics/index.json?jsonp=cb")
+	indexHandler(rr, req)
+	assert.Equal(t, contentTypeJavaScript, rr.Header().Get("Content-Type"))
+	assert.Equal(t, `cb(["foo.\"quoted\"","foo.bar","foo.baz"])`, rr.Body.String())
+
+	req, rr = setUpRequest(t, "/metrics/index.json?format=carbonapi_v3_pb")
+	indexHandler(rr, req)
+	assert.Equal(t, contentTypeProtobuf, rr.Header().Get("Content-Type"))
+	var list pbv3.ListMetricsResponse
+	if assert.NoError(t, list.Unmarshal(rr.Body.Bytes())) {
+		assert.Equal(t, []string{`foo."quoted"`, "foo.bar", "foo.baz"}, list.Metrics)
+	}
+
+	req, rr = setUpRequest(t, "/metrics/index.json?format=png")
+	indexHandler(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	config.Config.ZipperInstance = auth.NewZipper(newMockCarbonZipper())
+	req, rr = setUpRequest(t, "/metrics/index.json")
+	indexHandler(rr, req)
+	assert.Equal(t, http.StatusNotImplemented, rr.Code)
+}
+
+func TestWriteIndex(t *testing.T) {
+	long := strings.Repeat("a.", 200) + "b"
+	tests := [][]string{
+		{},
+		{"foo.bar"},
+		{`foo."quoted"`, "foo.<html>&", "foo.\u00fc\u043c", "foo.\x01", long},
+	}
+	for _, metrics := range tests {
+		expected, err := json.Marshal(metrics)
+		assert.NoError(t, err)
+		var b bytes.Buffer
+		assert.NoError(t, writeIndexJSON(&b, metrics, ""))
+		assert.Equal(t, string(expected), b.String())
+
+		b.Reset()
+		assert.NoError(t, writeIndexJSON(&b, metrics, "cb"))
+		assert.Equal(t, "cb("+string(expected)+")", b.String())
+
+		expected, err = (&pbv3.ListMetricsResponse{Metrics: metrics}).Marshal()
+		assert.NoError(t, err)
+		b.Reset()
+		assert.NoError(t, writeIndexProtobuf(&b, metrics))
+		assert.Equal(t, expected, b.Bytes())
+	}
+}
+
+func TestDetailsHandler(t *testing.T) {
+	saved := config.Config.ZipperInstance
+	defer func() { config.Config.ZipperInstance = saved }()
+	config.Config.ZipperInstance = auth.NewZipper(mockListerZipper{})
+
+	req, rr := setUpRequest(t, "/metrics/details")
+	detailsHandler(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"metrics":{"foo.bar":{"Size":1024,"ModTime":1600000000,"ATime":1600000060}},"FreeSpace":10,"TotalSpace":20}`, rr.Body.String())
+
+	req, rr = setUpRequest(t, "/metrics/details?format=carbonapi_v3_pb")
+	detailsHandler(rr, req)
+	var details pbv3.MetricDetailsResponse
+	if assert.NoError(t, details.Unmarshal(rr.Body.Bytes())) {
+		assert.Equal(t, int64(1024), details.Metrics["foo.bar"].Size_)
+		assert.Equal(t, uint64(20), details.TotalSpace)
+	}
+}
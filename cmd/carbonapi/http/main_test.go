@@ -34,7 +34,14 @@ func (z mockCarbonZipper) Info(ctx context.Context, metrics []string) (*pb.Zippe
 }
 
 func (z mockCarbonZipper) Render(ctx context.Context, request pb.MultiFetchRequest) ([]*types.MetricData, *zipperTypes.Stats, merry.Error) {
-	return z.RenderCompat(ctx, []string{""}, 0, 0)
+	result, stats, err := z.RenderCompat(ctx, []string{""}, 0, 0)
+	// answer with the first requested path expression, so series are found for any target (e.g. seriesByTag)
+	if len(request.Metrics) > 0 {
+		for _, m := range result {
+			m.PathExpression = request.Metrics[0].PathExpression
+		}
+	}
+	return result, stats, err
 }
 
 func (z mockCarbonZipper) RenderCompat(ctx context.Context, metrics []string, from, until int64) ([]*types.MetricData, *zipperTypes.Stats, merry.Error) {
@@ -52,6 +59,10 @@ func (z mockCarbonZipper) TagValues(ctx context.Context, query string, limit int
 	return []string{}, nil
 }
 
+func (z mockCarbonZipper) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	return []string{"foo;dc=a;env=prod", "bar;dc=b;env=prod", "baz;dc=a;env=dev"}, nil
+}
+
 func (z mockCarbonZipper) ScaleToCommonStep() bool {
 	return true
 }
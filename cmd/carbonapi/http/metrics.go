@@ -27,7 +27,13 @@ var ApiMetrics = struct {
 
 	RenderRequests metrics.Counter
 
-	FindRequests metrics.Counter
+	FindRequests    metrics.Counter
+	FindCacheHits   metrics.Counter
+	FindCacheMisses metrics.Counter
+
+	TagsRequests    metrics.Counter
+	TagsCacheHits   metrics.Counter
+	TagsCacheMisses metrics.Counter
 
 	MemcacheTimeouts metrics.UGauge
 
@@ -49,7 +55,13 @@ var ApiMetrics = struct {
 	Requests503: metrics.NewCounter(),
 	Requests5xx: metrics.NewCounter(),
 
-	FindRequests: metrics.NewCounter(),
+	FindRequests:    metrics.NewCounter(),
+	FindCacheHits:   metrics.NewCounter(),
+	FindCacheMisses: metrics.NewCounter(),
+
+	TagsRequests:    metrics.NewCounter(),
+	TagsCacheHits:   metrics.NewCounter(),
+	TagsCacheMisses: metrics.NewCounter(),
 }
 
 var ZipperMetrics = struct {
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ansel1/merry"
+
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+	"github.com/go-graphite/carbonapi/zipper/helper"
+)
+
+const partialResultsHeader = "X-Carbonapi-Partial-Results"
+
+// errFailedBackends is reported for a target without series if targets are fetched together and some of backends
+// failed to reply
+var errFailedBackends = merry.New("no series fetched, some of backends failed").WithHTTPCode(http.StatusServiceUnavailable)
+
+// targetError describes why the target is missing from partial results
+type targetError struct {
+	Target         string   `json:"target"`
+	Code           int      `json:"code"`
+	Message        string   `json:"message"`
+	FailedBackends []string `json:"failedBackends,omitempty"`
+}
+
+// partialResponse is json render response with partialResults=1, successful series are kept in graphite's format
+type partialResponse struct {
+	Series json.RawMessage `json:"series"`
+	Errors []targetError   `json:"errors"`
+}
+
+// targetErrors returns errors in order of targets, "not found" isn't an error as it's the empty result
+func targetErrors(targets []string, errors map[string]merry.Error, failedServers map[string]*utilctx.FailedServers) []targetError {
+	res := make([]targetError, 0, len(errors))
+	for _, target := range targets {
+		err, ok := errors[target]
+		if !ok {
+			continue
+		}
+		code, msg := helper.HttpErrorCodeMessage(err)
+		if code == http.StatusNotFound {
+			continue
+		}
+		e := targetError{Target: target, Code: code, Message: msg}
+		if f, ok := failedServers[target]; ok {
+			e.FailedBackends = f.List()
+		}
+		res = append(res, e)
+	}
+	return res
+}
+
+func marshalPartialResponse(series []byte, errors []targetError) ([]byte, error) {
+	return json.Marshal(&partialResponse{Series: series, Errors: errors})
+}
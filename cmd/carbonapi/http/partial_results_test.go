@@ -0,0 +1,126 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ansel1/merry"
+	pb "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	"github.com/go-graphite/carbonapi/expr/types"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
+)
+
+type mockPartialZipper struct {
+	mockCarbonZipper
+}
+
+// Render fails foo.fail (as if backends were unavailable) and foo.missing, other metrics are found
+func (z mockPartialZipper) Render(ctx context.Context, request pb.MultiFetchRequest) ([]*types.MetricData, *zipperTypes.Stats, merry.Error) {
+	var result []*types.MetricData
+	var err merry.Error
+	for _, m := range request.Metrics {
+		switch m.PathExpression {
+		case "foo.fail":
+			if failed := utilctx.GetFailedServers(ctx); failed != nil {
+				failed.Add("backend2", "backend1")
+			}
+			err = merry.New("backends are unavailable").WithHTTPCode(http.StatusServiceUnavailable)
+		case "foo.missing":
+			if err == nil {
+				err = merry.New("not found").WithHTTPCode(http.StatusNotFound)
+			}
+		default:
+			res, _, _ := z.mockCarbonZipper.Render(ctx, pb.MultiFetchRequest{Metrics: []pb.FetchRequest{m}})
+			result = append(result, res...)
+		}
+	}
+	if len(result) == 0 {
+		return nil, nil, err
+	}
+	// as zipper does, errors are not fatal if something is fetched
+	return result, nil, merry.WithHTTPCode(err, http.StatusOK)
+}
+
+func TestRenderPartialResults(t *testing.T) {
+	defer func() { _ = config.Config.SetZipper(newMockCarbonZipper()) }()
+	assert.NoError(t, config.Config.SetZipper(mockPartialZipper{}))
+	saved := config.Config.CombineMultipleTargetsInOne
+	defer func() { config.Config.CombineMultipleTargetsInOne = saved }()
+
+	type response struct {
+		Series []map[string]interface{} `json:"series"`
+		Errors []targetError            `json:"errors"`
+	}
+	failed := targetError{
+		Target:         "foo.fail",
+		Code:           http.StatusServiceUnavailable,
+		Message:        "backends are unavailable",
+		FailedBackends: []string{"backend1", "backend2"},
+	}
+
+	for _, combined := range []bool{false, true} {
+		t.Run(fmt.Sprintf("combined=%v", combined), func(t *testing.T) {
+			config.Config.CombineMultipleTargetsInOne = combined
+
+			req, rr := setUpRequest(t, "/render/?target=foo.bar&target=foo.fail&target=foo.missing&from=-10minutes&format=json&partialResults=1")
+			renderHandler(rr, req)
+			assert.Equal(t, http.StatusOK, rr.Code)
+
+			var resp response
+			assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			if assert.Len(t, resp.Series, 1) {
+				assert.Equal(t, "foo.bar", resp.Series[0]["target"])
+			}
+			if combined {
+				// targets are fetched together, so it's unknown if foo.missing isn't found or wasn't fetched
+				assert.Equal(t, "2", rr.Header().Get(partialResultsHeader))
+				assert.Equal(t, []targetError{
+					{
+						Target:         "foo.fail",
+						Code:           http.StatusServiceUnavailable,
+						Message:        "no series fetched, some of backends failed",
+						FailedBackends: []string{"backend1", "backend2"},
+					},
+					{
+						Target:         "foo.missing",
+						Code:           http.StatusServiceUnavailable,
+						Message:        "no series fetched, some of backends failed",
+						FailedBackends: []string{"backend1", "backend2"},
+					},
+				}, resp.Errors)
+			} else {
+				assert.Equal(t, "1", rr.Header().Get(partialResultsHeader))
+				assert.Equal(t, []targetError{failed}, resp.Errors)
+			}
+
+			// all targets failed
+			req, rr = setUpRequest(t, "/render/?target=foo.fail&target=sumSeries(foo.fail)&from=-10minutes&format=json&partialResults=1")
+			renderHandler(rr, req)
+			assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+			resp = response{}
+			assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			assert.Empty(t, resp.Series)
+			failedSum := failed
+			failedSum.Target = "sumSeries(foo.fail)"
+			assert.Equal(t, []targetError{failed, failedSum}, resp.Errors)
+
+			// nothing failed
+			req, rr = setUpRequest(t, "/render/?target=foo.bar&target=foo.missing&from=-10minutes&format=json&partialResults=1")
+			renderHandler(rr, req)
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Empty(t, rr.Header().Get(partialResultsHeader))
+			assert.Contains(t, rr.Body.String(), `"errors":[]`)
+		})
+	}
+
+	req, rr := setUpRequest(t, "/render/?target=foo.bar&from=-10minutes&format=csv&partialResults=1")
+	renderHandler(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,582 @@
+package http
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/lomik/zapwriter"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/go-graphite/carbonapi/carbonapipb"
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	"github.com/go-graphite/carbonapi/expr"
+	"github.com/go-graphite/carbonapi/expr/tags"
+	"github.com/go-graphite/carbonapi/expr/types"
+	"github.com/go-graphite/carbonapi/pkg/parser"
+	"github.com/go-graphite/carbonapi/pkg/promql"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
+)
+
+const (
+	// promLookbackDelta is how far back the latest sample is looked for, the same as Prometheus default
+	promLookbackDelta = 5 * 60
+	// promMaxPoints is the maximum number of points per series in query_range response, the same as Prometheus limit
+	promMaxPoints = 11000
+
+	promErrorBadData     = "bad_data"
+	promErrorExecution   = "execution"
+	promErrorTimeout     = "timeout"
+	promErrorUnavailable = "unavailable"
+	promErrorInternal    = "internal"
+)
+
+type promResponse struct {
+	Status    string      `json:"status"`
+	Data      interface{} `json:"data,omitempty"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+type promQueryData struct {
+	ResultType string      `json:"resultType"`
+	Result     interface{} `json:"result"`
+}
+
+// promSample is marshaled as [timestamp, "value"]
+type promSample struct {
+	Timestamp int64
+	Value     float64
+}
+
+func (s promSample) MarshalJSON() ([]byte, error) {
+	b := make([]byte, 0, 32)
+	b = append(b, '[')
+	b = strconv.AppendInt(b, s.Timestamp, 10)
+	b = append(b, `,"`...)
+	switch {
+	case math.IsNaN(s.Value):
+		b = append(b, "NaN"...)
+	case math.IsInf(s.Value, 1):
+		b = append(b, "+Inf"...)
+	case math.IsInf(s.Value, -1):
+		b = append(b, "-Inf"...)
+	default:
+		b = strconv.AppendFloat(b, s.Value, 'f', -1, 64)
+	}
+	b = append(b, `"]`...)
+	return b, nil
+}
+
+type promSeries struct {
+	Metric map[string]string `json:"metric"`
+	Values []promSample      `json:"values"`
+}
+
+type promVectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  promSample        `json:"value"`
+}
+
+type promRequest struct {
+	uuid             string
+	accessLogDetails *carbonapipb.AccessLogDetails
+}
+
+func newPromRequest(r *http.Request, handler string) *promRequest {
+	uid := uuid.NewV4()
+	ctx := utilctx.SetUUID(r.Context(), uid.String())
+	username := requestUsername(r)
+	requestHeaders := utilctx.GetLogHeaders(ctx)
+	srcIP, srcPort := splitRemoteAddr(r.RemoteAddr)
+
+	return &promRequest{
+		uuid: uid.String(),
+		accessLogDetails: &carbonapipb.AccessLogDetails{
+			Handler:        handler,
+			Username:       username,
+			CarbonapiUUID:  uid.String(),
+			URL:            r.URL.RequestURI(),
+			PeerIP:         srcIP,
+			PeerPort:       srcPort,
+			Host:           r.Host,
+			Referer:        r.Referer(),
+			URI:            r.RequestURI,
+			RequestHeaders: requestHeaders,
+		},
+	}
+}
+
+func (p *promRequest) writeData(w http.ResponseWriter, data interface{}) {
+	b, err := json.Marshal(promResponse{Status: "success", Data: data})
+	if err != nil {
+		p.writeError(w, promErrorInternal, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(ctxHeaderUUID, p.uuid)
+	w.Header().Set("Content-Type", contentTypeJSON)
+	_, _ = w.Write(b)
+	p.accessLogDetails.HTTPCode = http.StatusOK
+	p.accessLogDetails.CarbonapiResponseSizeBytes = int64(len(b))
+}
+
+func (p *promRequest) writeError(w http.ResponseWriter, errorType, msg string, status int) {
+	p.accessLogDetails.Reason = msg
+	p.accessLogDetails.HTTPCode = int32(status)
+	b, _ := json.Marshal(promResponse{Status: "error", ErrorType: errorType, Error: msg})
+	w.Header().Set(ctxHeaderUUID, p.uuid)
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+// writeMerryError converts carbonapi error to Prometheus error type and status code
+func (p *promRequest) writeMerryError(w http.ResponseWriter, err error) {
+	code := merry.HTTPCode(err)
+	msg := merry.Message(err)
+	switch {
+	case code == http.StatusBadRequest:
+		p.writeError(w, promErrorBadData, msg, http.StatusBadRequest)
+	case code == http.StatusGatewayTimeout:
+		p.writeError(w, promErrorTimeout, msg, http.StatusServiceUnavailable)
+	case code == http.StatusServiceUnavailable:
+		p.writeError(w, promErrorUnavailable, msg, http.StatusServiceUnavailable)
+	case code >= 500:
+		p.writeError(w, promErrorInternal, msg, http.StatusInternalServerError)
+	default:
+		p.writeError(w, promErrorExecution, msg, http.StatusUnprocessableEntity)
+	}
+}
+
+// parsePromTime parses unix timestamp (possibly fractional) or RFC3339 time
+func parsePromTime(s string, defaultTime time.Time) (int64, error) {
+	if s == "" {
+		return defaultTime.Unix(), nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, merry.Errorf("cannot parse %q to a valid timestamp", s)
+		}
+		return int64(math.Floor(f)), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, merry.Errorf("cannot parse %q to a valid timestamp", s)
+	}
+	return t.Unix(), nil
+}
+
+// promLabels returns Prometheus label set of the series. Metric name is kept as __name__ only if the query
+// result is still identified by it, like Prometheus does. Graphite-only aggregatedBy tag is dropped.
+func promLabels(m *types.MetricData, keepName bool) map[string]string {
+	seriesTags := m.Tags
+	if len(seriesTags) == 0 {
+		seriesTags = tags.ExtractTags(types.ExtractName(m.Name))
+	}
+	return promTagsLabels(seriesTags, keepName)
+}
+
+func promTagsLabels(seriesTags map[string]string, keepName bool) map[string]string {
+	labels := make(map[string]string, len(seriesTags))
+	for k, v := range seriesTags {
+		if k == "aggregatedBy" {
+			continue
+		}
+		if k == "name" {
+			if keepName {
+				labels["__name__"] = v
+			}
+			continue
+		}
+		labels[k] = v
+	}
+	return labels
+}
+
+// promSampleAt returns latest non-null value at or before ts, but not older than promLookbackDelta
+func promSampleAt(m *types.MetricData, ts int64) (float64, bool) {
+	if m.StepTime <= 0 || ts < m.StartTime {
+		return 0, false
+	}
+	i := (ts - m.StartTime) / m.StepTime
+	if i >= int64(len(m.Values)) {
+		i = int64(len(m.Values)) - 1
+	}
+	for ; i >= 0; i-- {
+		if ts-(m.StartTime+i*m.StepTime) > promLookbackDelta {
+			break
+		}
+		if !math.IsNaN(m.Values[i]) {
+			return m.Values[i], true
+		}
+	}
+	return 0, false
+}
+
+// promEval translates PromQL query and evaluates it over [from, until] range
+func promEval(r *http.Request, p *promRequest, query string, from, until int64) (*promql.Query, []*types.MetricData, error) {
+	q, err := promql.Translate(query)
+	if err != nil {
+		return nil, nil, merry.WithHTTPCode(err, http.StatusBadRequest)
+	}
+	if q.Scalar {
+		return q, nil, nil
+	}
+
+	p.accessLogDetails.Targets = []string{q.Target}
+	p.accessLogDetails.From = from
+	p.accessLogDetails.Until = until
+
+	if queryLengthLimitExceeded([]string{q.Target}, config.Config.MaxQueryLength) {
+		return nil, nil, merry.New("total target length limit exceeded").WithHTTPCode(http.StatusBadRequest)
+	}
+
+	exp, e, err := parser.ParseExpr(q.Target)
+	if err != nil || e != "" {
+		return nil, nil, merry.New(buildParseErrorString(q.Target, e, err)).WithHTTPCode(http.StatusBadRequest)
+	}
+
+	ctx := utilctx.SetUUID(r.Context(), p.uuid)
+	memoryAccountant := utilctx.NewMemoryAccountant(int64(config.Config.MaxMemoryPerRequestMB) * 1024 * 1024)
+	ctx = utilctx.SetMemoryAccountant(ctx, memoryAccountant)
+	defer func() {
+		p.accessLogDetails.MemoryPeakBytes = memoryAccountant.Peak()
+	}()
+	ApiMetrics.RenderRequests.Add(1)
+	values := make(map[parser.MetricRequest][]*types.MetricData)
+	result, mErr := expr.FetchAndEvalExp(ctx, config.Config.Evaluator, exp, from, until, values)
+	if mErr == nil {
+		mErr = merry.Wrap(memoryAccountant.Err())
+	}
+	if mErr != nil {
+		if merry.Is(mErr, zipperTypes.ErrNoMetricsFetched) || merry.HTTPCode(mErr) == http.StatusNotFound {
+			return q, nil, nil
+		}
+		return nil, nil, mErr
+	}
+
+	size := 0
+	for _, m := range result {
+		size += m.Size()
+	}
+	p.accessLogDetails.CarbonzipperResponseSizeBytes = int64(size)
+
+	return q, result, nil
+}
+
+func promQueryRangeHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	p := newPromRequest(r, "prometheus_query_range")
+	logAsError := false
+	defer func() {
+		deferredAccessLogging(zapwriter.Logger("access"), p.accessLogDetails, t0, logAsError)
+	}()
+
+	if err := r.ParseForm(); err != nil {
+		p.writeError(w, promErrorBadData, err.Error(), http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+
+	now := timeNow()
+	start, err := parsePromTime(r.FormValue("start"), now.Add(-time.Hour))
+	if err != nil {
+		p.writeError(w, promErrorBadData, "invalid parameter \"start\": "+err.Error(), http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+	end, err := parsePromTime(r.FormValue("end"), now)
+	if err != nil {
+		p.writeError(w, promErrorBadData, "invalid parameter \"end\": "+err.Error(), http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+	if end < start {
+		p.writeError(w, promErrorBadData, "end timestamp must not be before start time", http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+	stepDuration, err := promql.ParseDuration(r.FormValue("step"))
+	if err != nil || stepDuration < time.Second {
+		p.writeError(w, promErrorBadData, "invalid parameter \"step\": zero or negative query resolution step widths are not accepted", http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+	step := int64(stepDuration / time.Second)
+	if (end-start)/step > promMaxPoints {
+		p.writeError(w, promErrorBadData, "exceeded maximum resolution of 11,000 points per timeseries. Try decreasing the query resolution (?step=XX)", http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+
+	query := r.FormValue("query")
+	q, result, err := promEval(r, p, query, start-promLookbackDelta, end)
+	if err != nil {
+		p.writeMerryError(w, err)
+		logAsError = true
+		return
+	}
+
+	matrix := make([]promSeries, 0, len(result))
+	if q.Scalar {
+		s := promSeries{Metric: map[string]string{}}
+		for ts := start; ts <= end; ts += step {
+			s.Values = append(s.Values, promSample{Timestamp: ts, Value: q.Value})
+		}
+		matrix = append(matrix, s)
+	}
+	for _, m := range result {
+		s := promSeries{Metric: promLabels(m, q.KeepName)}
+		for ts := start; ts <= end; ts += step {
+			if v, ok := promSampleAt(m, ts); ok {
+				s.Values = append(s.Values, promSample{Timestamp: ts, Value: v})
+			}
+		}
+		if len(s.Values) > 0 {
+			matrix = append(matrix, s)
+		}
+	}
+
+	p.writeData(w, promQueryData{ResultType: "matrix", Result: matrix})
+}
+
+func promQueryHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	p := newPromRequest(r, "prometheus_query")
+	logAsError := false
+	defer func() {
+		deferredAccessLogging(zapwriter.Logger("access"), p.accessLogDetails, t0, logAsError)
+	}()
+
+	if err := r.ParseForm(); err != nil {
+		p.writeError(w, promErrorBadData, err.Error(), http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+
+	ts, err := parsePromTime(r.FormValue("time"), timeNow())
+	if err != nil {
+		p.writeError(w, promErrorBadData, "invalid parameter \"time\": "+err.Error(), http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+
+	query := r.FormValue("query")
+	q, result, err := promEval(r, p, query, ts-promLookbackDelta, ts)
+	if err != nil {
+		p.writeMerryError(w, err)
+		logAsError = true
+		return
+	}
+
+	if q.Scalar {
+		p.writeData(w, promQueryData{ResultType: "scalar", Result: promSample{Timestamp: ts, Value: q.Value}})
+		return
+	}
+
+	vector := make([]promVectorSample, 0, len(result))
+	for _, m := range result {
+		if v, ok := promSampleAt(m, ts); ok {
+			vector = append(vector, promVectorSample{
+				Metric: promLabels(m, q.KeepName),
+				Value:  promSample{Timestamp: ts, Value: v},
+			})
+		}
+	}
+
+	p.writeData(w, promQueryData{ResultType: "vector", Result: vector})
+}
+
+func promSeriesHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	p := newPromRequest(r, "prometheus_series")
+	logAsError := false
+	defer func() {
+		deferredAccessLogging(zapwriter.Logger("access"), p.accessLogDetails, t0, logAsError)
+	}()
+
+	if err := r.ParseForm(); err != nil {
+		p.writeError(w, promErrorBadData, err.Error(), http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+
+	matches := r.Form["match[]"]
+	if len(matches) == 0 {
+		p.writeError(w, promErrorBadData, "no match[] parameter provided", http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+
+	now := timeNow()
+	start, err := parsePromTime(r.FormValue("start"), now.Add(-time.Hour))
+	if err != nil {
+		p.writeError(w, promErrorBadData, "invalid parameter \"start\": "+err.Error(), http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+	end, err := parsePromTime(r.FormValue("end"), now)
+	if err != nil {
+		p.writeError(w, promErrorBadData, "invalid parameter \"end\": "+err.Error(), http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+
+	// series are found in tag index, there is no need to fetch their data
+	params := url.Values{}
+	params.Set("from", strconv.FormatInt(start, 10))
+	params.Set("until", strconv.FormatInt(end, 10))
+	queries, err := promTagQueries(matches, params)
+	if err != nil {
+		p.writeError(w, promErrorBadData, err.Error(), http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+
+	ctx := utilctx.SetUUID(r.Context(), p.uuid)
+	seen := make(map[string]struct{})
+	series := make([]map[string]string, 0)
+	for _, query := range queries {
+		names, mErr := config.Config.ZipperInstance.FindSeries(ctx, query, -1)
+		if mErr != nil && !merry.Is(mErr, zipperTypes.ErrNoMetricsFetched) && (!merry.Is(mErr, zipperTypes.ErrNonFatalErrors) || config.Config.Upstreams.RequireSuccessAll) {
+			p.writeMerryError(w, mErr)
+			logAsError = true
+			return
+		}
+		for _, name := range names {
+			labels := promTagsLabels(tags.ExtractTags(name), true)
+			key := promLabelsKey(labels)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			series = append(series, labels)
+		}
+	}
+
+	p.writeData(w, series)
+}
+
+func promLabelsKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(labels[k])
+		sb.WriteByte(';')
+	}
+	return sb.String()
+}
+
+// promTagQueries converts match[] selectors to graphite autocomplete queries, one per selector
+func promTagQueries(matches []string, params url.Values) ([]string, error) {
+	if len(matches) == 0 {
+		return []string{params.Encode()}, nil
+	}
+	queries := make([]string, 0, len(matches))
+	for _, match := range matches {
+		exprs, err := promql.ParseSelector(match)
+		if err != nil {
+			return nil, err
+		}
+		v := url.Values{}
+		for k, vv := range params {
+			v[k] = vv
+		}
+		v["expr"] = exprs
+		queries = append(queries, v.Encode())
+	}
+	return queries, nil
+}
+
+func promLabelsHandler(w http.ResponseWriter, r *http.Request) {
+	t0 := time.Now()
+	p := newPromRequest(r, "prometheus_labels")
+	logAsError := false
+	defer func() {
+		deferredAccessLogging(zapwriter.Logger("access"), p.accessLogDetails, t0, logAsError)
+	}()
+
+	if err := r.ParseForm(); err != nil {
+		p.writeError(w, promErrorBadData, err.Error(), http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+
+	var name string
+	isValues := false
+	if strings.HasPrefix(r.URL.Path, config.Config.Prefix+"/api/v1/label/") {
+		// /api/v1/label/<name>/values
+		rest := strings.TrimPrefix(r.URL.Path, config.Config.Prefix+"/api/v1/label/")
+		if !strings.HasSuffix(rest, "/values") {
+			p.writeError(w, promErrorBadData, "unknown endpoint", http.StatusNotFound)
+			logAsError = true
+			return
+		}
+		name = strings.TrimSuffix(rest, "/values")
+		if name == "" {
+			p.writeError(w, promErrorBadData, "label name is empty", http.StatusBadRequest)
+			logAsError = true
+			return
+		}
+		if name == "__name__" {
+			name = "name"
+		}
+		isValues = true
+		p.accessLogDetails.Handler = "prometheus_label_values"
+	}
+
+	params := url.Values{}
+	if isValues {
+		params.Set("tag", name)
+	}
+	queries, err := promTagQueries(r.Form["match[]"], params)
+	if err != nil {
+		p.writeError(w, promErrorBadData, err.Error(), http.StatusBadRequest)
+		logAsError = true
+		return
+	}
+
+	ctx := utilctx.SetUUID(r.Context(), p.uuid)
+	unique := make(map[string]struct{})
+	for _, query := range queries {
+		var res []string
+		var mErr merry.Error
+		if isValues {
+			res, mErr = config.Config.ZipperInstance.TagValues(ctx, query, -1)
+		} else {
+			res, mErr = config.Config.ZipperInstance.TagNames(ctx, query, -1)
+		}
+		if mErr != nil && !merry.Is(mErr, zipperTypes.ErrNoMetricsFetched) && (!merry.Is(mErr, zipperTypes.ErrNonFatalErrors) || config.Config.Upstreams.RequireSuccessAll) {
+			p.writeMerryError(w, mErr)
+			logAsError = true
+			return
+		}
+		for _, v := range res {
+			if !isValues && v == "name" {
+				v = "__name__"
+			}
+			unique[v] = struct{}{}
+		}
+	}
+
+	data := make([]string, 0, len(unique))
+	for v := range unique {
+		data = append(data, v)
+	}
+	sort.Strings(data)
+
+	p.writeData(w, data)
+}
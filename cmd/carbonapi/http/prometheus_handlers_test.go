@@ -0,0 +1,88 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromQueryRangeHandler(t *testing.T) {
+	req, rr := setUpRequest(t, `/api/v1/query_range?query=foo_bar{env="prod"}&start=1510913280&end=1510913400&step=60`)
+	promQueryRangeHandler(rr, req)
+
+	expected := `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"__name__":"foo.bar"},"values":[[1510913340,"1510913759"],[1510913400,"1510913818"]]}]}}`
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expected, rr.Body.String())
+}
+
+func TestPromQueryHandler(t *testing.T) {
+	req, rr := setUpRequest(t, `/api/v1/query?query=sum(foo_bar)&time=1510913460`)
+	promQueryHandler(rr, req)
+
+	expected := `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1510913460,"1510913818"]}]}}`
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expected, rr.Body.String())
+
+	req, rr = setUpRequest(t, `/api/v1/query?query=2*3&time=1510913460`)
+	promQueryHandler(rr, req)
+
+	expected = `{"status":"success","data":{"resultType":"scalar","result":[1510913460,"6"]}}`
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expected, rr.Body.String())
+}
+
+func TestPromHandlerErrors(t *testing.T) {
+	tests := []struct {
+		url  string
+		code int
+		body string
+	}{
+		{
+			url:  `/api/v1/query_range?query=foo_bar&start=1510913280&end=1510913400&step=0`,
+			code: http.StatusBadRequest,
+			body: `{"status":"error","errorType":"bad_data","error":"invalid parameter \"step\": zero or negative query resolution step widths are not accepted"}`,
+		},
+		{
+			url:  `/api/v1/query_range?query=foo_bar&start=1510913400&end=1510913280&step=60`,
+			code: http.StatusBadRequest,
+			body: `{"status":"error","errorType":"bad_data","error":"end timestamp must not be before start time"}`,
+		},
+		{
+			url:  `/api/v1/query?query=histogram_quantile(0.9,foo_bar)`,
+			code: http.StatusBadRequest,
+		},
+		{
+			url:  `/api/v1/series`,
+			code: http.StatusBadRequest,
+			body: `{"status":"error","errorType":"bad_data","error":"no match[] parameter provided"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, rr := setUpRequest(t, tt.url)
+			if req.URL.Path == "/api/v1/series" {
+				promSeriesHandler(rr, req)
+			} else if req.URL.Path == "/api/v1/query" {
+				promQueryHandler(rr, req)
+			} else {
+				promQueryRangeHandler(rr, req)
+			}
+			assert.Equal(t, tt.code, rr.Code)
+			if tt.body != "" {
+				assert.Equal(t, tt.body, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestPromSeriesHandler(t *testing.T) {
+	req, rr := setUpRequest(t, `/api/v1/series?match[]=foo_bar&match[]=foo_bar{env="prod"}&start=1510913280&end=1510913400`)
+	promSeriesHandler(rr, req)
+
+	// series are taken from FindSeries of the mock, each of them is returned once
+	expected := `{"status":"success","data":[{"__name__":"foo","dc":"a","env":"prod"},{"__name__":"bar","dc":"b","env":"prod"},{"__name__":"baz","dc":"a","env":"dev"}]}`
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, expected, rr.Body.String())
+}
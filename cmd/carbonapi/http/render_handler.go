@@ -27,6 +27,8 @@ import (
 	"github.com/go-graphite/carbonapi/pkg/parser"
 	utilctx "github.com/go-graphite/carbonapi/util/ctx"
 	"github.com/go-graphite/carbonapi/zipper/helper"
+	"github.com/go-graphite/carbonapi/zipper/httpHeaders"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
 )
 
 func cleanupParams(r *http.Request) {
@@ -71,7 +73,7 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 	// TODO: Migrate to context.WithTimeout
 	// ctx, _ := context.WithTimeout(context.TODO(), config.Config.ZipperTimeout)
 	ctx := utilctx.SetUUID(r.Context(), uid.String())
-	username, _, _ := r.BasicAuth()
+	username := requestUsername(r)
 	requestHeaders := utilctx.GetLogHeaders(ctx)
 
 	logger := zapwriter.Logger("render").With(
@@ -96,9 +98,16 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 		RequestHeaders: requestHeaders,
 	}
 
+	memoryAccountant := utilctx.NewMemoryAccountant(int64(config.Config.MaxMemoryPerRequestMB) * 1024 * 1024)
+	ctx = utilctx.SetMemoryAccountant(ctx, memoryAccountant)
+	backendTimings := &utilctx.BackendTimings{}
+	ctx = utilctx.SetBackendTimings(ctx, backendTimings)
+
 	logAsError := false
 	defer func() {
+		accessLogDetails.MemoryPeakBytes = memoryAccountant.Peak()
 		deferredAccessLogging(accessLogger, accessLogDetails, t0, logAsError)
+		auditRender(accessLogDetails, backendTimings, t0)
 	}()
 
 	err := r.ParseForm()
@@ -114,7 +123,9 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 	template := r.FormValue("template")
 	maxDataPoints, _ := strconv.ParseInt(r.FormValue("maxDataPoints"), 10, 64)
 	ctx = utilctx.SetMaxDatapoints(ctx, maxDataPoints)
-	useCache := !parser.TruthyBool(r.FormValue("noCache"))
+	capture := utilctx.GetCapture(ctx)
+	// captured request must reach backends, so their responses are recorded
+	useCache := !parser.TruthyBool(r.FormValue("noCache")) && capture == nil
 	noNullPoints := parser.TruthyBool(r.FormValue("noNullPoints"))
 	// status will be checked later after we'll setup everything else
 	format, ok, formatRaw := getFormat(r, pngFormat)
@@ -126,6 +137,14 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 		jsonp = r.FormValue("jsonp")
 	}
 
+	// successful series are returned with errors of failed targets instead of failing the whole request
+	partialResults := parser.TruthyBool(r.FormValue("partialResults"))
+	if partialResults && format != jsonFormat {
+		setError(w, accessLogDetails, "partialResults is supported only for json format", http.StatusBadRequest, uid.String())
+		logAsError = true
+		return
+	}
+
 	timestampFormat := strings.ToLower(r.FormValue("timestampFormat"))
 	if timestampFormat == "" {
 		timestampFormat = "s"
@@ -147,6 +166,19 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if xFilesFactorStr := r.FormValue("xFilesFactor"); xFilesFactorStr != "" {
+		xFilesFactor, err := strconv.ParseFloat(xFilesFactorStr, 32)
+		if err != nil || xFilesFactor < 0 || xFilesFactor > 1 {
+			setError(w, accessLogDetails, "xFilesFactor should be a number between 0 and 1", http.StatusBadRequest, uid.String())
+			logAsError = true
+			return
+		}
+		ctx = utilctx.SetXFilesFactor(ctx, float32(xFilesFactor))
+	}
+
+	// timestamps are in milliseconds and sub-second intervals are allowed
+	highPrecision := parser.TruthyBool(r.FormValue("highPrecisionTimestamps"))
+
 	now := timeNow()
 	now32 := now.Unix()
 
@@ -156,6 +188,12 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 	qtz := r.FormValue("tz")
 	from32 := date.DateParamToEpoch(from, qtz, now.Add(-24*time.Hour).Unix(), config.Config.DefaultTimeZone)
 	until32 := date.DateParamToEpoch(until, qtz, now.Unix(), config.Config.DefaultTimeZone)
+	if qtz != "" {
+		// time-of-day and calendar functions evaluate in request time zone
+		if tz, err := time.LoadLocation(qtz); err == nil {
+			ctx = utilctx.SetTimeZone(ctx, tz)
+		}
+	}
 
 	var (
 		responseCacheKey     string
@@ -170,6 +208,18 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 		// recalc duration
 		duration = time.Second * time.Duration(until32-from32)
 		responseCacheKey = responseCacheComputeKey(from32, until32, targets, formatRaw, maxDataPoints, noNullPoints, template)
+		if qtz != "" {
+			responseCacheKey += " tz:" + qtz
+		}
+		if highPrecision {
+			responseCacheKey += " highPrecisionTimestamps"
+		}
+		if xFilesFactor := r.FormValue("xFilesFactor"); xFilesFactor != "" {
+			responseCacheKey += " xFilesFactor:" + xFilesFactor
+		}
+		if partialResults {
+			responseCacheKey += " partialResults"
+		}
 		if useCache {
 			responseCacheTimeout = getCacheTimeout(logger, r, now32, until32, duration, &config.Config.ResponseCacheConfig)
 			backendCacheTimeout = getCacheTimeout(logger, r, now32, until32, duration, &config.Config.BackendCacheConfig)
@@ -181,6 +231,11 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 			backendCacheTimeout = getCacheTimeout(logger, r, now32, until32, duration, &config.Config.BackendCacheConfig)
 		}
 	}
+	responseCacheKey = aclCacheKey(ctx, responseCacheKey)
+
+	if capture != nil {
+		capture.SetTimeRange(from32, until32)
+	}
 
 	accessLogDetails.UseCache = useCache
 	accessLogDetails.FromRaw = from
@@ -198,7 +253,9 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if format == protoV3Format {
+	// requests from other carbonapi with functions it pushed down to us, see filteringFunctionsTarget
+	var filteredTargets map[string]pb.FetchRequest
+	if format == protoV3Format || format == protoV3StreamFormat {
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
 			setError(w, accessLogDetails, "failed to parse message body: "+err.Error(), http.StatusBadRequest, uid.String())
@@ -215,9 +272,31 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 
 		from32 = pv3Request.Metrics[0].StartTime
 		until32 = pv3Request.Metrics[0].StopTime
+		highPrecision = pv3Request.Metrics[0].HighPrecisionTimestamps
 		targets = make([]string, len(pv3Request.Metrics))
 		for i, r := range pv3Request.Metrics {
 			targets[i] = r.PathExpression
+			if config.Config.ServeFilteringFunctions {
+				targets[i] = filteringFunctionsTarget(r)
+			}
+			if targets[i] != r.PathExpression {
+				if filteredTargets == nil {
+					filteredTargets = make(map[string]pb.FetchRequest)
+				}
+				filteredTargets[targets[i]] = r
+			}
+		}
+	}
+
+	if highPrecision {
+		if !format.SupportsHighPrecisionTimestamps() {
+			setError(w, accessLogDetails, "highPrecisionTimestamps are not supported for format: "+formatRaw, http.StatusBadRequest, uid.String())
+			logAsError = true
+			return
+		}
+		if format != protoV3Format && format != protoV3StreamFormat {
+			from32 *= 1000
+			until32 *= 1000
 		}
 	}
 
@@ -271,6 +350,7 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	errors := make(map[string]merry.Error)
+	failedServers := make(map[string]*utilctx.FailedServers)
 
 	var backendCacheKey string
 	if len(config.Config.TruncateTime) > 0 {
@@ -278,16 +358,37 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 	} else {
 		backendCacheKey = backendCacheComputeKey(from, until, targets, maxDataPoints, noNullPoints)
 	}
+	if highPrecision {
+		backendCacheKey += " highPrecisionTimestamps"
+	}
+	if xFilesFactor := r.FormValue("xFilesFactor"); xFilesFactor != "" {
+		backendCacheKey += " xFilesFactor:" + xFilesFactor
+	}
+	backendCacheKey = aclCacheKey(ctx, backendCacheKey)
 
 	results, err := backendCacheFetchResults(logger, useCache, backendCacheKey, accessLogDetails)
+	var stream *renderStream
 
 	if err != nil {
 		ApiMetrics.BackendCacheMisses.Add(1)
 
 		results = make([]*types.MetricData, 0)
 		values := make(map[parser.MetricRequest][]*types.MetricData)
+		pushedDown := &utilctx.PushedDown{}
+		ctx := utilctx.SetPushedDown(ctx, pushedDown)
+		defer func() {
+			accessLogDetails.PushedDown = pushedDown.List()
+			for _, series := range values {
+				accessLogDetails.TotalMetricsCount += uint64(len(series))
+			}
+		}()
 
-		if config.Config.CombineMultipleTargetsInOne && len(targets) > 0 {
+		// streamed responses are written as soon as each target is evaluated
+		if format == protoV3StreamFormat {
+			stream = &renderStream{w: w, carbonapiUUID: uid.String()}
+		}
+
+		if config.Config.CombineMultipleTargetsInOne && stream == nil && len(targets) > 0 && len(filteredTargets) == 0 {
 			exprs := make([]parser.Expr, 0, len(targets))
 			for _, target := range targets {
 				exp, e, err := parser.ParseExpr(target)
@@ -297,17 +398,36 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 					logAsError = true
 					return
 				}
+				if highPrecision {
+					exp.SetTimeUnit(parser.Milliseconds)
+				}
 				exprs = append(exprs, exp)
 			}
 
 			ApiMetrics.RenderRequests.Add(1)
 
-			result, errs := expr.FetchAndEvalExprs(ctx, config.Config.Evaluator, exprs, from32, until32, values)
-			if errs != nil {
-				errors = errs
+			fetchCtx := ctx
+			var failed *utilctx.FailedServers
+			if partialResults {
+				failed = &utilctx.FailedServers{}
+				fetchCtx = utilctx.SetFailedServers(ctx, failed)
 			}
 
-			results = append(results, result...)
+			result, errs := expr.FetchAndEvalExprs(fetchCtx, config.Config.Evaluator, exprs, from32, until32, values)
+			for i, target := range targets {
+				err := errs[i]
+				if partialResults && err == nil && len(result[i]) == 0 && len(failed.List()) > 0 {
+					// series of all targets are fetched at once, so it's unknown if they are missing or weren't fetched
+					err = errFailedBackends
+				}
+				if err != nil {
+					errors[target] = err
+					if partialResults {
+						failedServers[target] = failed
+					}
+				}
+				results = append(results, result[i]...)
+			}
 		} else {
 			for _, target := range targets {
 				exp, e, err := parser.ParseExpr(target)
@@ -317,21 +437,70 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 					logAsError = true
 					return
 				}
+				if highPrecision {
+					exp.SetTimeUnit(parser.Milliseconds)
+				}
 
 				ApiMetrics.RenderRequests.Add(1)
 
-				result, err := expr.FetchAndEvalExp(ctx, config.Config.Evaluator, exp, from32, until32, values)
+				targetCtx := ctx
+				if partialResults {
+					failed := &utilctx.FailedServers{}
+					failedServers[target] = failed
+					targetCtx = utilctx.SetFailedServers(ctx, failed)
+				}
+
+				result, err := expr.FetchAndEvalExp(targetCtx, config.Config.Evaluator, exp, from32, until32, values)
+				if request, ok := filteredTargets[target]; ok {
+					setAppliedFunctions(result, request)
+				}
 				if err != nil {
 					errors[target] = merry.Wrap(err)
-					if config.Config.Upstreams.RequireSuccessAll {
+					if memoryAccountant.Err() != nil {
+						stream.abort(err)
+						break
+					}
+					if config.Config.Upstreams.RequireSuccessAll && !partialResults {
 						code := merry.HTTPCode(err)
 						if code != http.StatusOK && code != http.StatusNotFound {
+							stream.abort(err)
 							break
 						}
 					}
 				}
 
 				results = append(results, result...)
+				if highPrecision && stream != nil {
+					// streamed series are written before the flag is set for all results below
+					for _, r := range result {
+						r.HighPrecisionTimestamps = true
+					}
+				}
+				if err := stream.write(result); err != nil {
+					break
+				}
+			}
+		}
+
+		if stream.started() && stream.err != nil {
+			// status is already sent, so response is cut without the end of the stream to let client know it failed
+			accessLogDetails.Metrics = targets
+			accessLogDetails.CarbonapiResponseSizeBytes = stream.written()
+			accessLogDetails.Reason = stream.err.Error()
+			logAsError = true
+			return
+		}
+
+		if err := memoryAccountant.Err(); err != nil {
+			setError(w, accessLogDetails, err.Error(), http.StatusUnprocessableEntity, uid.String())
+			logAsError = true
+			return
+		}
+
+		if highPrecision {
+			// series generated by functions (e.g. constantLine) are built from the millisecond time range too
+			for _, r := range results {
+				r.HighPrecisionTimestamps = true
 			}
 		}
 
@@ -346,10 +515,22 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 		size += result.Size()
 	}
 
+	if stream.started() {
+		accessLogDetails.Metrics = targets
+		accessLogDetails.CarbonzipperResponseSizeBytes = int64(size)
+		accessLogDetails.HaveNonFatalErrors = len(errors) > 0
+		if err := stream.close(); err != nil {
+			accessLogDetails.Reason = err.Error()
+			logAsError = true
+		}
+		accessLogDetails.CarbonapiResponseSizeBytes = stream.written()
+		return
+	}
+
 	var body []byte
 
 	returnCode := http.StatusOK
-	if len(results) == 0 || (len(errors) > 0 && config.Config.Upstreams.RequireSuccessAll) {
+	if len(results) == 0 || (len(errors) > 0 && config.Config.Upstreams.RequireSuccessAll && !partialResults) {
 		// Obtain error code from the errors
 		// In case we have only "Not Found" errors, result should be 404
 		// Otherwise it should be 500
@@ -361,7 +542,7 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 			returnCode = config.Config.NotFoundStatusCode
 		}
 
-		if returnCode == http.StatusBadRequest || returnCode == http.StatusNotFound || returnCode == http.StatusForbidden || returnCode >= 500 {
+		if !partialResults && (returnCode == http.StatusBadRequest || returnCode == http.StatusNotFound || returnCode == http.StatusForbidden || returnCode >= 500) {
 			setErrors(w, accessLogDetails, errMsgs, returnCode, uid.String())
 			logAsError = true
 			return
@@ -376,6 +557,18 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		body = types.MarshalJSON(results, timestampMultiplier, noNullPoints)
+		if partialResults {
+			failedTargets := targetErrors(targets, errors, failedServers)
+			if len(failedTargets) > 0 {
+				w.Header().Set(partialResultsHeader, strconv.Itoa(len(failedTargets)))
+			}
+			body, err = marshalPartialResponse(body, failedTargets)
+			if err != nil {
+				setError(w, accessLogDetails, err.Error(), http.StatusInternalServerError, uid.String())
+				logAsError = true
+				return
+			}
+		}
 	case protoV2Format:
 		body, err = types.MarshalProtobufV2(results)
 		if err != nil {
@@ -390,6 +583,23 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 			logAsError = true
 			return
 		}
+	case protoV3StreamFormat:
+		// results from backend cache or without series, response isn't kept for response cache
+		stream = &renderStream{w: w, carbonapiUUID: uid.String(), code: returnCode}
+		accessLogDetails.Metrics = targets
+		accessLogDetails.CarbonzipperResponseSizeBytes = int64(size)
+		accessLogDetails.HaveNonFatalErrors = len(errors) > 0
+		err := stream.write(results)
+		if err == nil {
+			err = stream.close()
+		}
+		accessLogDetails.CarbonapiResponseSizeBytes = stream.written()
+		if err != nil {
+			// headers are already sent, so only thing that could be done is to log the error
+			accessLogDetails.Reason = err.Error()
+			logAsError = true
+		}
+		return
 	case rawFormat:
 		body = types.MarshalRaw(results)
 	case csvFormat:
@@ -408,7 +618,8 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 
 	writeResponse(w, returnCode, body, format, jsonp, uid.String())
 
-	if len(results) != 0 {
+	// errors of partial results are transient, so such response isn't cached
+	if len(results) != 0 && !(partialResults && len(errors) > 0) {
 		tc := time.Now()
 		config.Config.ResponseCache.Set(responseCacheKey, body, responseCacheTimeout)
 		td := time.Since(tc).Nanoseconds()
@@ -419,6 +630,128 @@ func renderHandler(w http.ResponseWriter, r *http.Request) {
 	accessLogDetails.HaveNonFatalErrors = gotErrors
 }
 
+// renderStream writes results as a stream of length-delimited FetchResponse messages, so downstream carbonapi could
+// start decoding them before the whole response is received. Results of each target are written and flushed as soon as
+// they are ready, stream is ended by close. Methods of nil renderStream do nothing.
+type renderStream struct {
+	w             http.ResponseWriter
+	carbonapiUUID string
+	// code is the status of the response, 200 if not set
+	code int
+
+	sw  *zipperTypes.FetchStreamWriter
+	err error
+}
+
+func (s *renderStream) started() bool {
+	return s != nil && s.sw != nil
+}
+
+func (s *renderStream) written() int64 {
+	if !s.started() {
+		return 0
+	}
+	return s.sw.Written()
+}
+
+// abort marks started stream as failed, so it isn't closed
+func (s *renderStream) abort(err error) {
+	if s.started() && s.err == nil {
+		s.err = err
+	}
+}
+
+// start sends headers, so status of the response can't be changed after that
+func (s *renderStream) start() {
+	if s.code == 0 {
+		s.code = http.StatusOK
+	}
+	s.w.Header().Set(ctxHeaderUUID, s.carbonapiUUID)
+	s.w.Header().Set("Content-Type", httpHeaders.ContentTypeCarbonAPIv3PBStream)
+	s.w.WriteHeader(s.code)
+	s.sw = zipperTypes.NewFetchStreamWriter(s.w)
+}
+
+// write sends results to the client, stream isn't started until there is something to write
+func (s *renderStream) write(results []*types.MetricData) error {
+	if s == nil || len(results) == 0 {
+		return nil
+	}
+	if s.err != nil {
+		return s.err
+	}
+	if s.sw == nil {
+		s.start()
+	}
+	for _, metric := range results {
+		if s.err = s.sw.Write(&metric.FetchResponse); s.err != nil {
+			return s.err
+		}
+	}
+	if s.err = s.sw.Flush(); s.err != nil {
+		return s.err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func (s *renderStream) close() error {
+	if s == nil {
+		return nil
+	}
+	if !s.started() {
+		s.start()
+	}
+	return s.sw.Close()
+}
+
+// filteringFunctionsTarget returns target for fetch request with filtering functions applied, e.g.
+// sumSeries(a.*) for a.* with sumSeries. consolidateBy is always applied by the caller, so in that case request is
+// served as is.
+func filteringFunctionsTarget(request pb.FetchRequest) string {
+	target := request.PathExpression
+	for _, f := range request.FilterFunctions {
+		if f.Name == "consolidateBy" {
+			return request.PathExpression
+		}
+	}
+	for _, f := range request.FilterFunctions {
+		args := make([]string, 0, len(f.Arguments)+1)
+		args = append(args, target)
+		for _, arg := range f.Arguments {
+			args = append(args, filteringFunctionArg(arg))
+		}
+		target = f.Name + "(" + strings.Join(args, ",") + ")"
+	}
+	return target
+}
+
+// filteringFunctionArg returns argument as it's written in target. Only constants are passed as is, anything else is
+// quoted as a string, so an argument can't change the structure of the target
+func filteringFunctionArg(arg string) string {
+	exp, rest, err := parser.ParseExpr(arg)
+	if err == nil && rest == "" && (exp.IsConst() || exp.IsString() || exp.IsBool()) {
+		return exp.ToString()
+	}
+	arg = strings.ReplaceAll(arg, `\`, `\\`)
+	arg = strings.ReplaceAll(arg, `'`, `\'`)
+	return "'" + arg + "'"
+}
+
+// setAppliedFunctions marks results of filtering functions, so caller could match them with the request
+func setAppliedFunctions(results []*types.MetricData, request pb.FetchRequest) {
+	applied := make([]string, 0, len(request.FilterFunctions))
+	for _, f := range request.FilterFunctions {
+		applied = append(applied, f.Name)
+	}
+	for _, r := range results {
+		r.PathExpression = request.PathExpression
+		r.AppliedFunctions = applied
+	}
+}
+
 func responseCacheComputeKey(from, until int64, targets []string, format string, maxDataPoints int64, noNullPoints bool, template string) string {
 	var responseCacheKey stringutils.Builder
 	responseCacheKey.Grow(256)
@@ -1,12 +1,20 @@
 package http
 
 import (
+	"bytes"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/ansel1/merry"
+
 	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	"github.com/go-graphite/carbonapi/zipper/httpHeaders"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
+	pb "github.com/go-graphite/protocol/carbonapi_v3_pb"
 	"github.com/lomik/zapwriter"
+	"github.com/stretchr/testify/assert"
 )
 
 func BenchmarkResponseCacheComputeKey(b *testing.B) {
@@ -123,3 +131,164 @@ func Test_getCacheTimeout(t *testing.T) {
 		})
 	}
 }
+
+func Test_filteringFunctionsTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		request pb.FetchRequest
+		want    string
+	}{
+		{
+			name:    "no functions",
+			request: pb.FetchRequest{PathExpression: "a.*"},
+			want:    "a.*",
+		},
+		{
+			name: "chain of functions",
+			request: pb.FetchRequest{
+				PathExpression: "a.*.b",
+				FilterFunctions: []*pb.FilteringFunction{
+					{Name: "groupByNode", Arguments: []string{"1", "'sum'"}},
+					{Name: "sumSeries"},
+				},
+			},
+			want: "sumSeries(groupByNode(a.*.b,1,'sum'))",
+		},
+		{
+			name: "arguments are quoted",
+			request: pb.FetchRequest{
+				PathExpression: "a.*",
+				FilterFunctions: []*pb.FilteringFunction{
+					{Name: "aliasByNode", Arguments: []string{"1),sumSeries(b.*"}},
+					{Name: "alias", Arguments: []string{`it's`}},
+				},
+			},
+			want: `alias(aliasByNode(a.*,'1),sumSeries(b.*'),'it\'s')`,
+		},
+		{
+			name: "consolidateBy",
+			request: pb.FetchRequest{
+				PathExpression:  "a.*",
+				FilterFunctions: []*pb.FilteringFunction{{Name: "consolidateBy", Arguments: []string{"max"}}},
+			},
+			want: "a.*",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filteringFunctionsTarget(tt.request); got != tt.want {
+				t.Errorf("filteringFunctionsTarget() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderHandlerStream(t *testing.T) {
+	request := pb.MultiFetchRequest{
+		Metrics: []pb.FetchRequest{{
+			Name:           "foo.bar",
+			PathExpression: "foo.bar",
+			StartTime:      1510913280,
+			StopTime:       1510913460,
+		}},
+	}
+	body, err := request.Marshal()
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/render/?format=carbonapi_v3_pb_stream", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+	renderHandler(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, httpHeaders.ContentTypeCarbonAPIv3PBStream, rr.Header().Get("Content-Type"))
+
+	var got []pb.FetchResponse
+	err = zipperTypes.ReadFetchStream(rr.Body, func(r *pb.FetchResponse) error {
+		got = append(got, *r)
+		return nil
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "foo.bar", got[0].Name)
+		assert.Len(t, got[0].Values, 3)
+	}
+}
+
+func TestRenderHandlerStreamFailure(t *testing.T) {
+	defer func() { _ = config.Config.SetZipper(newMockCarbonZipper()) }()
+	assert.NoError(t, config.Config.SetZipper(mockPartialZipper{}))
+	saved := config.Config.Upstreams.RequireSuccessAll
+	defer func() { config.Config.Upstreams.RequireSuccessAll = saved }()
+	config.Config.Upstreams.RequireSuccessAll = true
+
+	render := func(names ...string) *httptest.ResponseRecorder {
+		var request pb.MultiFetchRequest
+		for _, name := range names {
+			request.Metrics = append(request.Metrics, pb.FetchRequest{Name: name, PathExpression: name, StartTime: 1510913280, StopTime: 1510913460})
+		}
+		body, err := request.Marshal()
+		assert.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/render/?format=carbonapi_v3_pb_stream", bytes.NewReader(body))
+		rr := httptest.NewRecorder()
+		renderHandler(rr, req)
+		return rr
+	}
+
+	// series of the first target are already sent when the second one fails, so stream is left without the end
+	rr := render("foo.bar", "foo.fail")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var got []string
+	err := zipperTypes.ReadFetchStream(rr.Body, func(r *pb.FetchResponse) error {
+		got = append(got, r.Name)
+		return nil
+	})
+	assert.True(t, merry.Is(err, zipperTypes.ErrUnmarshalFailed))
+	assert.Equal(t, []string{"foo.bar"}, got)
+
+	// nothing is sent yet, so error is returned as usual
+	rr = render("foo.fail", "foo.bar")
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+	// not found is served with notFoundStatusCode (200 by default) and complete empty stream
+	rr = render("foo.missing")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	got = got[:0]
+	err = zipperTypes.ReadFetchStream(rr.Body, func(r *pb.FetchResponse) error {
+		got = append(got, r.Name)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestRenderHandlerHighPrecisionTimestamps(t *testing.T) {
+	req, rr := setUpRequest(t, "/render/?target=foo.bar&from=-10minutes&format=json&timestampFormat=ms&highPrecisionTimestamps=1")
+	renderHandler(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `[{"target":"foo.bar","datapoints":[[null,1510913280000],[1510913759,1510913340000],[1510913818,1510913400000]],"tags":{}}]`, rr.Body.String())
+
+	req, rr = setUpRequest(t, "/render/?target=foo.bar&from=-10minutes&format=png&highPrecisionTimestamps=1")
+	renderHandler(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestRenderHandlerXFilesFactor(t *testing.T) {
+	req, rr := setUpRequest(t, "/render/?target=foo.bar&from=-10minutes&format=json&maxDataPoints=1&xFilesFactor=1")
+	renderHandler(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `[{"target":"foo.bar","datapoints":[[null,1510913280]],"tags":{}}]`, rr.Body.String())
+
+	req, rr = setUpRequest(t, "/render/?target=foo.bar&from=-10minutes&format=json&maxDataPoints=1&xFilesFactor=0.5")
+	renderHandler(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `[{"target":"foo.bar","datapoints":[[1510913788.5,1510913280]],"tags":{}}]`, rr.Body.String())
+
+	req, rr = setUpRequest(t, "/render/?target=foo.bar&from=-10minutes&format=json&xFilesFactor=2")
+	renderHandler(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -1,8 +1,13 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -10,8 +15,12 @@ import (
 	"github.com/ansel1/merry"
 	uuid "github.com/satori/go.uuid"
 
+	"github.com/go-graphite/carbonapi/cache"
 	"github.com/go-graphite/carbonapi/carbonapipb"
 	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	"github.com/go-graphite/carbonapi/expr/tags"
+	"github.com/go-graphite/carbonapi/pkg/auth"
+	"github.com/go-graphite/carbonapi/pkg/parser"
 	utilctx "github.com/go-graphite/carbonapi/util/ctx"
 	"github.com/go-graphite/carbonapi/zipper/helper"
 	"github.com/go-graphite/carbonapi/zipper/types"
@@ -19,6 +28,17 @@ import (
 	"go.uber.org/zap"
 )
 
+// tagValueDetails and tagDetails are the same as graphite-web returns for /tags/<tag>
+type tagValueDetails struct {
+	Count int    `json:"count"`
+	Value string `json:"value"`
+}
+
+type tagDetails struct {
+	Tag    string            `json:"tag"`
+	Values []tagValueDetails `json:"values"`
+}
+
 func tagHandler(w http.ResponseWriter, r *http.Request) {
 	t0 := time.Now()
 	uuid := uuid.NewV4()
@@ -27,7 +47,7 @@ func tagHandler(w http.ResponseWriter, r *http.Request) {
 	// TODO: Migrate to context.WithTimeout
 	ctx := utilctx.SetUUID(r.Context(), carbonapiUUID)
 	requestHeaders := utilctx.GetLogHeaders(ctx)
-	username, _, _ := r.BasicAuth()
+	username := requestUsername(r)
 
 	logger := zapwriter.Logger("tag").With(
 		zap.String("carbonapi_uuid", uuid.String()),
@@ -63,6 +83,16 @@ func tagHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	tagsPath := strings.Trim(strings.TrimPrefix(r.URL.Path, config.Config.Prefix+"/tags"), "/")
+	switch tagsPath {
+	case "tagSeries", "tagMultiSeries", "delSeries":
+		accessLogDetails.Handler = "tags_write"
+		if !tagsWrite(ctx, w, r, tagsPath, accessLogDetails, carbonapiUUID) {
+			logAsError = true
+		}
+		return
+	}
+
 	prettyStr := r.FormValue("pretty")
 	limit := int64(-1)
 	limitStr := r.FormValue("limit")
@@ -77,48 +107,96 @@ func tagHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	q := r.URL.Query()
+	useCache := !parser.TruthyBool(r.FormValue("noCache"))
+	accessLogDetails.UseCache = useCache
+
+	q := make(url.Values, len(r.Form))
+	for k, v := range r.Form {
+		q[k] = v
+	}
+	q.Del("noCache")
+	cacheKey := "tags:" + tagsPath + "?" + q.Encode()
 	q.Del("pretty")
 	rawQuery := q.Encode()
 
-	if queryLengthLimitExceeded(r.Form["query"], config.Config.MaxQueryLength) {
+	if queryLengthLimitExceeded(r.Form["query"], config.Config.MaxQueryLength) || queryLengthLimitExceeded(r.Form["expr"], config.Config.MaxQueryLength) {
 		setError(w, accessLogDetails, "query length limit exceeded", http.StatusBadRequest, carbonapiUUID)
 		logAsError = true
 		return
 	}
 
-	// TODO(civil): Implement caching
-	var res []string
-	if strings.HasSuffix(r.URL.Path, "tags") || strings.HasSuffix(r.URL.Path, "tags/") {
-		res, err = config.Config.ZipperInstance.TagNames(ctx, rawQuery, limit)
-	} else if strings.HasSuffix(r.URL.Path, "values") || strings.HasSuffix(r.URL.Path, "values/") {
-		res, err = config.Config.ZipperInstance.TagValues(ctx, rawQuery, limit)
-	} else {
+	var fetch func() (interface{}, error)
+	switch {
+	case tagsPath == "findSeries":
+		if len(r.Form["expr"]) == 0 {
+			setError(w, accessLogDetails, "no tag expressions specified", http.StatusBadRequest, carbonapiUUID)
+			logAsError = true
+			return
+		}
+		fetch = func() (interface{}, error) {
+			return config.Config.ZipperInstance.FindSeries(ctx, rawQuery, limit)
+		}
+	case tagsPath == "" || strings.HasSuffix(tagsPath, "tags"):
+		fetch = func() (interface{}, error) {
+			return config.Config.ZipperInstance.TagNames(ctx, rawQuery, limit)
+		}
+	case strings.HasSuffix(tagsPath, "values"):
+		fetch = func() (interface{}, error) {
+			return config.Config.ZipperInstance.TagValues(ctx, rawQuery, limit)
+		}
+	case !strings.Contains(tagsPath, "/"):
+		var filter *regexp.Regexp
+		if f := r.FormValue("filter"); f != "" {
+			// graphite-web matches values from the beginning
+			filter, err = regexp.Compile("^(?:" + f + ")")
+			if err != nil {
+				setError(w, accessLogDetails, "invalid filter: "+err.Error(), http.StatusBadRequest, carbonapiUUID)
+				logAsError = true
+				return
+			}
+		}
+		fetch = func() (interface{}, error) {
+			return getTagDetails(ctx, tagsPath, filter, limit)
+		}
+	default:
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 		accessLogDetails.HTTPCode = http.StatusNotFound
 		return
 	}
 
-	// TODO(civil): Implement stats
-	if err != nil && !merry.Is(err, types.ErrNoMetricsFetched) && (!merry.Is(err, types.ErrNonFatalErrors) || config.Config.Upstreams.RequireSuccessAll) {
-		code := merry.HTTPCode(err)
-		setError(w, accessLogDetails, helper.MerryRootError(err), code, carbonapiUUID)
+	var fetchErr, marshalErr error
+	cacheTimeout := config.Config.TagDB.CacheDurationSec
+	b, fromCache := findCacheFetch(ctx, cacheKey, useCache, cacheTimeout, ApiMetrics.TagsCacheHits, ApiMetrics.TagsCacheMisses, func() ([]byte, bool, bool) {
+		ApiMetrics.TagsRequests.Add(1)
+		res, err := fetch()
+		if err != nil && !merry.Is(err, types.ErrNoMetricsFetched) && (!merry.Is(err, types.ErrNonFatalErrors) || config.Config.Upstreams.RequireSuccessAll) {
+			fetchErr = err
+			return nil, false, false
+		}
+
+		var b []byte
+		if prettyStr == "1" {
+			b, marshalErr = json.MarshalIndent(res, "", "\t")
+		} else {
+			b, marshalErr = json.Marshal(res)
+		}
+		return b, tagsIsEmpty(res), marshalErr == nil
+	})
+	if fetchErr != nil {
+		setError(w, accessLogDetails, helper.MerryRootError(fetchErr), merry.HTTPCode(fetchErr), carbonapiUUID)
 		logAsError = true
 		return
 	}
-
-	var b []byte
-	if prettyStr == "1" {
-		b, err = json.MarshalIndent(res, "", "\t")
-	} else {
-		b, err = json.Marshal(res)
-	}
-
-	if err != nil {
-		setError(w, accessLogDetails, err.Error(), http.StatusInternalServerError, carbonapiUUID)
+	if marshalErr != nil {
+		setError(w, accessLogDetails, marshalErr.Error(), http.StatusInternalServerError, carbonapiUUID)
 		logAsError = true
 		return
 	}
+	if fromCache {
+		accessLogDetails.FromCache = true
+	} else {
+		accessLogDetails.CacheTimeout = cacheTimeout
+	}
 
 	w.Header().Set("Content-Type", contentTypeJSON)
 	w.Header().Set(ctxHeaderUUID, carbonapiUUID)
@@ -126,3 +204,132 @@ func tagHandler(w http.ResponseWriter, r *http.Request) {
 	accessLogDetails.Runtime = time.Since(t0).Seconds()
 	accessLogDetails.HTTPCode = http.StatusOK
 }
+
+// tagsIsEmpty returns true if there are no tags, values or series in the response
+func tagsIsEmpty(res interface{}) bool {
+	switch v := res.(type) {
+	case []string:
+		return len(v) == 0
+	case *tagDetails:
+		return v == nil || len(v.Values) == 0
+	}
+	return res == nil
+}
+
+var errTooManyTagSeries = merry.New("too many series for tag details").WithHTTPCode(http.StatusUnprocessableEntity)
+
+// getTagDetails returns values of the tag with amount of series for each of them, sorted by value. Counting is refused
+// if the tag has more than tagdb.maxDetailsSeries series
+func getTagDetails(ctx context.Context, tag string, filter *regexp.Regexp, limit int64) (*tagDetails, error) {
+	query := url.Values{"expr": []string{tag + "!="}}.Encode()
+	maxSeries := config.Config.TagDB.MaxDetailsSeries
+	fetchLimit := int64(-1)
+	if maxSeries > 0 {
+		fetchLimit = maxSeries + 1
+	}
+	series, err := config.Config.ZipperInstance.FindSeries(ctx, query, fetchLimit)
+	if err != nil && !merry.Is(err, types.ErrNonFatalErrors) {
+		return nil, err
+	}
+	if maxSeries > 0 && int64(len(series)) > maxSeries {
+		return nil, errTooManyTagSeries.Here()
+	}
+
+	counts := make(map[string]int)
+	for _, s := range series {
+		v, ok := tags.ExtractTags(s)[tag]
+		if !ok || (filter != nil && !filter.MatchString(v)) {
+			continue
+		}
+		counts[v]++
+	}
+
+	res := &tagDetails{
+		Tag:    tag,
+		Values: make([]tagValueDetails, 0, len(counts)),
+	}
+	for v, c := range counts {
+		res.Values = append(res.Values, tagValueDetails{Count: c, Value: v})
+	}
+	sort.Slice(res.Values, func(i, j int) bool { return res.Values[i].Value < res.Values[j].Value })
+	if limit > 0 && int64(len(res.Values)) > limit {
+		res.Values = res.Values[:limit]
+	}
+
+	return res, err
+}
+
+// tagsWrite forwards tag writes to the configured tag DB and passes its response back, returns false on failure
+func tagsWrite(ctx context.Context, w http.ResponseWriter, r *http.Request, endpoint string, accessLogDetails *carbonapipb.AccessLogDetails, carbonapiUUID string) bool {
+	if r.Method != http.MethodPost {
+		setError(w, accessLogDetails, "HTTP POST required", http.StatusMethodNotAllowed, carbonapiUUID)
+		return false
+	}
+	if config.Config.TagDB.URL == "" {
+		setError(w, accessLogDetails, "tagdb is not configured", http.StatusNotImplemented, carbonapiUUID)
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.Config.TagDB.Timeout)
+	defer cancel()
+
+	if acl := auth.GetACL(ctx); acl != nil {
+		for _, path := range r.Form["path"] {
+			if !acl.AllowMetric(path) {
+				setError(w, accessLogDetails, "access denied to "+path, http.StatusForbidden, carbonapiUUID)
+				return false
+			}
+		}
+	}
+
+	body := r.PostForm.Encode()
+	if body == "" {
+		body = r.Form.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(config.Config.TagDB.URL, "/")+"/tags/"+endpoint, strings.NewReader(body))
+	if err != nil {
+		setError(w, accessLogDetails, err.Error(), http.StatusInternalServerError, carbonapiUUID)
+		return false
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(ctxHeaderUUID, carbonapiUUID)
+	if config.Config.TagDB.User != "" {
+		req.SetBasicAuth(config.Config.TagDB.User, config.Config.TagDB.Password)
+	}
+
+	client := &http.Client{Timeout: config.Config.TagDB.Timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		setError(w, accessLogDetails, "tagdb request failed: "+err.Error(), http.StatusBadGateway, carbonapiUUID)
+		return false
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		setError(w, accessLogDetails, "tagdb request failed: "+err.Error(), http.StatusBadGateway, carbonapiUUID)
+		return false
+	}
+
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		w.Header().Set("Content-Type", ct)
+	}
+	w.Header().Set(ctxHeaderUUID, carbonapiUUID)
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(b)
+	accessLogDetails.HTTPCode = int32(resp.StatusCode)
+
+	if resp.StatusCode >= 400 {
+		return false
+	}
+	purgeTagsCache()
+	return true
+}
+
+// purgeTagsCache drops cached tag responses of all users after a tag write, otherwise reads serve stale data until
+// tagdb.cacheDurationSec passes
+func purgeTagsCache() {
+	if p, ok := config.Config.FindCache.(cache.Purger); ok {
+		p.Purge("tags:")
+	}
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+)
+
+func TestTagsFindSeriesHandler(t *testing.T) {
+	req, rr := setUpRequest(t, "/tags/findSeries?expr=env%3Dprod&noCache=1")
+	tagHandler(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `["foo;dc=a;env=prod","bar;dc=b;env=prod","baz;dc=a;env=dev"]`, rr.Body.String())
+
+	req, rr = setUpRequest(t, "/tags/findSeries")
+	tagHandler(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestTagsDetailsHandler(t *testing.T) {
+	req, rr := setUpRequest(t, "/tags/dc?noCache=1")
+	tagHandler(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"tag":"dc","values":[{"count":2,"value":"a"},{"count":1,"value":"b"}]}`, rr.Body.String())
+
+	req, rr = setUpRequest(t, "/tags/env?filter=pr&noCache=1")
+	tagHandler(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"tag":"env","values":[{"count":2,"value":"prod"}]}`, rr.Body.String())
+
+	defer func(n int64) { config.Config.TagDB.MaxDetailsSeries = n }(config.Config.TagDB.MaxDetailsSeries)
+	config.Config.TagDB.MaxDetailsSeries = 2
+	req, rr = setUpRequest(t, "/tags/dc?noCache=1")
+	tagHandler(rr, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestTagsCache(t *testing.T) {
+	hits := ApiMetrics.TagsCacheHits.Count()
+
+	for i := 0; i < 2; i++ {
+		req, rr := setUpRequest(t, "/tags/findSeries?expr=dc%3Da")
+		tagHandler(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `["foo;dc=a;env=prod","bar;dc=b;env=prod","baz;dc=a;env=dev"]`, rr.Body.String())
+	}
+
+	assert.Equal(t, hits+1, ApiMetrics.TagsCacheHits.Count())
+}
+
+func TestTagsWriteHandler(t *testing.T) {
+	var gotPath string
+	var gotForm url.Values
+	tagDB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = r.ParseForm()
+		gotForm = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `"disk.used;datacenter=dc1;server=web01"`)
+	}))
+	defer tagDB.Close()
+
+	defer func(url string) { config.Config.TagDB.URL = url }(config.Config.TagDB.URL)
+
+	config.Config.TagDB.URL = ""
+	req := httptest.NewRequest(http.MethodPost, "/tags/tagSeries", strings.NewReader(url.Values{"path": {"disk.used;server=web01;datacenter=dc1"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr := httptest.NewRecorder()
+	tagHandler(rr, req)
+	assert.Equal(t, http.StatusNotImplemented, rr.Code)
+
+	// cached read must not survive the write
+	req, rr = setUpRequest(t, "/tags/dc")
+	tagHandler(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	_, err := config.Config.FindCache.Get("tags:dc?")
+	assert.NoError(t, err)
+
+	config.Config.TagDB.URL = tagDB.URL
+	req = httptest.NewRequest(http.MethodPost, "/tags/tagSeries", strings.NewReader(url.Values{"path": {"disk.used;server=web01;datacenter=dc1"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr = httptest.NewRecorder()
+	tagHandler(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `"disk.used;datacenter=dc1;server=web01"`, rr.Body.String())
+	assert.Equal(t, "/tags/tagSeries", gotPath)
+	assert.Equal(t, "disk.used;server=web01;datacenter=dc1", gotForm.Get("path"))
+	_, err = config.Config.FindCache.Get("tags:dc?")
+	assert.Error(t, err)
+
+	req, rr = setUpRequest(t, "/tags/delSeries?path=disk.used")
+	tagHandler(rr, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
@@ -9,7 +9,9 @@ supported requests:
     /functions/
     /info/?target=
     /lb_check/
+    /metrics/details/
     /metrics/find/?query=
+    /metrics/index.json
 	/render/?target=
 	/tags/autoComplete/tags/
     /tags/autoComplete/values/
@@ -12,7 +12,6 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
-	"time"
 
 	"github.com/gorilla/handlers"
 	"github.com/lomik/zapwriter"
@@ -67,7 +66,11 @@ func main() {
 		)
 	}
 
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+
 	wg := sync.WaitGroup{}
+	var allServers []*http.Server
 	serve := func(listen config.Listener, handler http.Handler) {
 		l := &net.ListenConfig{Control: helper.ReusePort}
 		h, p, err := net.SplitHostPort(listen.Address)
@@ -113,16 +116,17 @@ func main() {
 			)
 		}
 
-		servers := make([]*http.Server, 0)
-
 		for _, ip := range ips {
 			address := (&net.TCPAddr{IP: ip, Port: port}).String()
 			s := &http.Server{
 				Addr:     address,
 				Handler:  handler,
 				ErrorLog: httpLogger,
+				BaseContext: func(net.Listener) context.Context {
+					return requestsCtx
+				},
 			}
-			servers = append(servers, s)
+			allServers = append(allServers, s)
 			isTLS := false
 			if len(listen.ServerTLSConfig.CACertFiles) > 0 {
 				tlsConfig, warns, err := tlsconfig.ParseServerTLSConfig(&listen.ServerTLSConfig, &listen.ClientTLSConfig)
@@ -163,20 +167,6 @@ func main() {
 				wg.Done()
 			}(listener, isTLS)
 		}
-
-		go func() {
-			stop := make(chan os.Signal, 1)
-			signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
-			<-stop
-			logger.Info("stoping carbonapi")
-			// initiating the shutdown
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
-			for _, s := range servers {
-				s.Shutdown(ctx)
-			}
-			cancel()
-		}()
-
 	}
 
 	if config.Config.Expvar.Enabled {
@@ -216,7 +206,17 @@ func main() {
 		serve(listener, handler)
 	}
 
-	wg.Wait()
+	served := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(served)
+	}()
+
+	select {
+	case <-stop:
+		shutdown(logger, allServers, config.Config.Shutdown)
+	case <-served:
+	}
 
 	if g != nil {
 		g.Stop()
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/go-graphite/carbonapi/cmd/carbonapi/config"
+	carbonapiHttp "github.com/go-graphite/carbonapi/cmd/carbonapi/http"
+)
+
+// requestsCtx is a base context for all requests, including zipper requests that ignore client timeout. It's
+// cancelled if requests are still running when drain timeout expires on shutdown.
+var requestsCtx, cancelRequests = context.WithCancel(context.Background())
+
+// shutdown stops servers gracefully: readiness checks start failing, so load balancers stop sending new requests,
+// after delay servers stop accepting connections and wait for in-flight requests to finish for drain timeout.
+// Requests that are still running after that are cancelled.
+func shutdown(logger *zap.Logger, servers []*http.Server, cfg config.ShutdownConfig) {
+	carbonapiHttp.SetShuttingDown()
+	logger.Info("stopping carbonapi",
+		zap.Duration("delay", cfg.Delay),
+		zap.Duration("drain_timeout", cfg.DrainTimeout),
+	)
+	time.Sleep(cfg.Delay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.DrainTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, s := range servers {
+		wg.Add(1)
+		go func(s *http.Server) {
+			defer wg.Done()
+			if err := s.Shutdown(ctx); err != nil {
+				logger.Warn("drain timeout expired, cancelling in-flight requests",
+					zap.String("address", s.Addr),
+					zap.Error(err),
+				)
+			}
+		}(s)
+	}
+	wg.Wait()
+	cancelRequests()
+}
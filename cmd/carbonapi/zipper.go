@@ -48,12 +48,15 @@ func (z zipper) Find(ctx context.Context, req pb.MultiGlobRequest) (*pb.MultiGlo
 	if z.ignoreClientTimeout {
 		uuid := util.GetUUID(ctx)
 		hdrs := util.GetPassHeaders(ctx)
-		newCtx = util.SetUUID(context.Background(), uuid)
+		newCtx = util.SetUUID(requestsCtx, uuid)
 		newCtx = util.SetPassHeaders(newCtx, hdrs)
 	}
 
 	res, stats, err := z.z.FindProtoV3(newCtx, &req)
 	z.statsSender(stats)
+	if capture := util.GetCapture(ctx); capture != nil {
+		capture.AddFind(req, res, err)
+	}
 
 	return res, stats, err
 }
@@ -63,7 +66,7 @@ func (z zipper) Info(ctx context.Context, metrics []string) (*pb.ZipperInfoRespo
 	if z.ignoreClientTimeout {
 		uuid := util.GetUUID(ctx)
 		hdrs := util.GetPassHeaders(ctx)
-		newCtx = util.SetUUID(context.Background(), uuid)
+		newCtx = util.SetUUID(requestsCtx, uuid)
 		newCtx = util.SetPassHeaders(newCtx, hdrs)
 	}
 
@@ -83,20 +86,32 @@ func (z zipper) Render(ctx context.Context, request pb.MultiFetchRequest) ([]*ty
 	if z.ignoreClientTimeout {
 		uuid := util.GetUUID(ctx)
 		hdrs := util.GetPassHeaders(ctx)
-		newCtx = util.SetUUID(context.Background(), uuid)
+		newCtx = util.SetUUID(requestsCtx, uuid)
 		newCtx = util.SetPassHeaders(newCtx, hdrs)
+		newCtx = util.SetMemoryAccountant(newCtx, util.GetMemoryAccountant(ctx))
+		newCtx = util.SetBackendTimings(newCtx, util.GetBackendTimings(ctx))
 	}
 
 	pbresp, stats, err := z.z.FetchProtoV3(newCtx, &request)
 	z.statsSender(stats)
+	if capture := util.GetCapture(ctx); capture != nil {
+		capture.AddFetch(request, pbresp, err)
+	}
+	if failed := util.GetFailedServers(ctx); failed != nil && stats != nil {
+		failed.Add(stats.FailedServers...)
+	}
 
 	if pbresp != nil {
 		for i := range pbresp.Metrics {
 			tags := tags2.ExtractTags(pbresp.Metrics[i].Name)
-			result = append(result, &types.MetricData{
+			r := &types.MetricData{
 				FetchResponse: pbresp.Metrics[i],
 				Tags:          tags,
-			})
+			}
+			// values are charged by zipper when they are fetched, values allocated for series derived from them are
+			// charged in the same accountant
+			r.SetMemoryAccountant(util.GetMemoryAccountant(ctx))
+			result = append(result, r)
 		}
 	}
 
@@ -118,6 +133,36 @@ func (z zipper) RenderCompat(ctx context.Context, metrics []string, from, until
 	return z.Render(ctx, req)
 }
 
+func (z zipper) List(ctx context.Context) (*pb.ListMetricsResponse, *zipperTypes.Stats, merry.Error) {
+	newCtx := ctx
+	if z.ignoreClientTimeout {
+		uuid := util.GetUUID(ctx)
+		hdrs := util.GetPassHeaders(ctx)
+		newCtx = util.SetUUID(requestsCtx, uuid)
+		newCtx = util.SetPassHeaders(newCtx, hdrs)
+	}
+
+	res, stats, err := z.z.ListProtoV3(newCtx)
+	z.statsSender(stats)
+
+	return res, stats, err
+}
+
+func (z zipper) Details(ctx context.Context) (*pb.MetricDetailsResponse, *zipperTypes.Stats, merry.Error) {
+	newCtx := ctx
+	if z.ignoreClientTimeout {
+		uuid := util.GetUUID(ctx)
+		hdrs := util.GetPassHeaders(ctx)
+		newCtx = util.SetUUID(requestsCtx, uuid)
+		newCtx = util.SetPassHeaders(newCtx, hdrs)
+	}
+
+	res, stats, err := z.z.StatsProtoV3(newCtx)
+	z.statsSender(stats)
+
+	return res, stats, err
+}
+
 func (z zipper) TagNames(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
 	return z.z.TagNames(ctx, query, limit)
 }
@@ -126,6 +171,26 @@ func (z zipper) TagValues(ctx context.Context, query string, limit int64) ([]str
 	return z.z.TagValues(ctx, query, limit)
 }
 
+func (z zipper) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	return z.z.FindSeries(ctx, query, limit)
+}
+
 func (z zipper) ScaleToCommonStep() bool {
 	return z.z.ScaleToCommonStep
 }
+
+func (z zipper) PushdownFunctions(ctx context.Context, pathExpression string) map[string]struct{} {
+	return z.z.PushdownFunctions(ctx, pathExpression)
+}
+
+func (z zipper) Status() zipperTypes.BackendStatus {
+	return z.z.Status()
+}
+
+func (z zipper) TLDs() map[string][]string {
+	return z.z.TLDs()
+}
+
+func (z zipper) RefreshTLDs(ctx context.Context) (map[string][]string, merry.Error) {
+	return z.z.RefreshTLDs(ctx)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+// diffBodies returns line diff of expected and actual response bodies or empty string if they are the same.
+// JSON is indented first, so the diff points to the series and datapoints that differ.
+func diffBodies(expected, got, contentType string) string {
+	if strings.HasPrefix(contentType, contentTypeJSON) {
+		expected = indentJSON(expected)
+		got = indentJSON(got)
+	}
+	if expected == got {
+		return ""
+	}
+	return diffLines(strings.Split(expected, "\n"), strings.Split(got, "\n"))
+}
+
+func indentJSON(s string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(strings.TrimSpace(s)), "", " "); err != nil {
+		return s
+	}
+	return buf.String()
+}
+
+// diffLines returns unified-like diff of lines with diffContext lines around changes
+func diffLines(a, b []string) string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	type line struct {
+		op   byte
+		text string
+	}
+	lines := make([]line, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, line{' ', a[i]})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			lines = append(lines, line{'+', b[j]})
+			j++
+		default:
+			lines = append(lines, line{'-', a[i]})
+			i++
+		}
+	}
+
+	var sb strings.Builder
+	last := -1
+	for k := range lines {
+		if lines[k].op == ' ' {
+			continue
+		}
+		from := k - diffContext
+		if from <= last {
+			from = last + 1
+		} else if from < 0 {
+			from = 0
+		}
+		if last >= 0 && from > last+1 {
+			sb.WriteString("...\n")
+		}
+		to := k + diffContext
+		if to >= len(lines) {
+			to = len(lines) - 1
+		}
+		for n := from; n <= to; n++ {
+			if n > k && lines[n].op != ' ' {
+				// next change continues the same hunk
+				to = n - 1
+				break
+			}
+			fmt.Fprintf(&sb, "%c %s\n", lines[n].op, lines[n].text)
+		}
+		last = to
+	}
+
+	return sb.String()
+}
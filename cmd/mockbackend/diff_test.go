@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_diffBodies(t *testing.T) {
+	tests := []struct {
+		name        string
+		expected    string
+		got         string
+		contentType string
+		want        string
+	}{
+		{
+			name:        "same json with different whitespace",
+			expected:    "[{\"target\":\"a\",\"datapoints\":[[1,1]]}]\n",
+			got:         `[{"target": "a", "datapoints": [[1, 1]]}]`,
+			contentType: contentTypeJSON,
+			want:        "",
+		},
+		{
+			name:        "json datapoint",
+			expected:    `{"a":[1,2,3,4,5,6,7,8,9]}`,
+			got:         `{"a":[1,2,3,4,0,6,7,8,9]}`,
+			contentType: contentTypeJSON,
+			want: `    2,
+    3,
+    4,
+-   5,
++   0,
+    6,
+    7,
+    8,
+`,
+		},
+		{
+			name:        "separate hunks",
+			expected:    strings.Join([]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}, "\n"),
+			got:         strings.Join([]string{"A", "b", "c", "d", "e", "f", "g", "h", "i", "J"}, "\n"),
+			contentType: contentTypeCSV,
+			want: `- a
++ A
+  b
+  c
+  d
+...
+  g
+  h
+  i
+- j
++ J
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffBodies(tt.expected, tt.got, tt.contentType); got != tt.want {
+				t.Errorf("diffBodies() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
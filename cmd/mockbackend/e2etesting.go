@@ -48,6 +48,7 @@ type ExpectedResponse struct {
 	ContentType     string           `yaml:"contentType"`
 	ErrBody         string           `yaml:"errBody"`
 	ErrSort         bool             `yaml:"errSort"`
+	Body            string           `yaml:"body"` // compared as is (json is indented first), other checks are skipped
 	ExpectedResults []ExpectedResult `yaml:"expectedResults"`
 }
 
@@ -276,6 +277,13 @@ func doTest(logger *zap.Logger, t *Query, verbose bool) []error {
 		return failures
 	}
 
+	if t.ExpectedResponse.Body != "" {
+		if diff := diffBodies(t.ExpectedResponse.Body, string(b), contentType); diff != "" {
+			failures = append(failures, merry2.Errorf("response body is different (-expected +got):\n%s", diff))
+		}
+		return failures
+	}
+
 	switch contentType {
 	case "image/png":
 	case "image/svg+xml":
@@ -442,20 +450,7 @@ func e2eTest(logger *zap.Logger, noapp, breakOnError, verbose bool) bool {
 	)
 	runningApps := make(map[string]*runner)
 	if !noapp {
-		wgStart := sync.WaitGroup{}
-		for i, c := range cfg.Test.Apps {
-			r := new(&cfg.Test.Apps[i], logger)
-			wgStart.Add(1)
-			runningApps[c.Name] = r
-			go func() {
-				wgStart.Done()
-				r.Run()
-			}()
-		}
-
-		wgStart.Wait()
-		logger.Info("will sleep for 1 seconds to start all required apps")
-		time.Sleep(1 * time.Second)
+		runningApps = startApps(logger)
 	}
 
 	for _, t := range cfg.Test.Queries {
@@ -503,3 +498,27 @@ func e2eTest(logger *zap.Logger, noapp, breakOnError, verbose bool) bool {
 
 	return failed
 }
+
+func startApps(logger *zap.Logger) map[string]*runner {
+	runningApps := make(map[string]*runner)
+	if len(cfg.Test.Apps) == 0 {
+		return runningApps
+	}
+
+	wgStart := sync.WaitGroup{}
+	for i, c := range cfg.Test.Apps {
+		r := new(&cfg.Test.Apps[i], logger)
+		wgStart.Add(1)
+		runningApps[c.Name] = r
+		go func() {
+			wgStart.Done()
+			r.Run()
+		}()
+	}
+
+	wgStart.Wait()
+	logger.Info("will sleep for 1 seconds to start all required apps")
+	time.Sleep(1 * time.Second)
+
+	return runningApps
+}
@@ -0,0 +1,363 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Faults describes failures injected into responses of the listener or of a single expression.
+//
+// Requests are counted separately for the listener and for every expression. While request number is within the
+// Sequence, corresponding step is replied, after that (or if there is no Sequence) random faults are injected
+// according to Latency and rates. With Loop the Sequence is repeated forever, which is useful to emulate flapping.
+type Faults struct {
+	Seed         int64       `yaml:"seed"`
+	Latency      *Latency    `yaml:"latency"`
+	ErrorRate    float64     `yaml:"errorRate"`
+	ErrorCode    int         `yaml:"errorCode"`
+	TruncateRate float64     `yaml:"truncateRate"`
+	DropRate     float64     `yaml:"dropRate"`
+	Sequence     []FaultStep `yaml:"sequence"`
+	Loop         bool        `yaml:"loop"`
+}
+
+// Latency is a distribution of delays before reply.
+// Supported distributions: fixed (ms), uniform (minMS..maxMS), normal (meanMS, stdDevMS) and exponential (meanMS).
+// Result is always clamped to [minMS, maxMS] if those are set.
+type Latency struct {
+	Distribution string `yaml:"distribution"`
+	MS           int    `yaml:"ms"`
+	MinMS        int    `yaml:"minMS"`
+	MaxMS        int    `yaml:"maxMS"`
+	MeanMS       int    `yaml:"meanMS"`
+	StdDevMS     int    `yaml:"stdDevMS"`
+}
+
+// FaultStep is a scripted reply, repeated Repeat times in a row.
+// Truncate cuts response body in half (with matching Content-Length), Drop sends half of the body and resets connection.
+type FaultStep struct {
+	DelayMS  int  `yaml:"delayMS"`
+	Code     int  `yaml:"code"`
+	Truncate bool `yaml:"truncate"`
+	Drop     bool `yaml:"drop"`
+	Repeat   int  `yaml:"repeat"`
+}
+
+type faultAction struct {
+	delay    time.Duration
+	code     int
+	truncate bool
+	drop     bool
+}
+
+func (a *faultAction) merge(b faultAction) {
+	if a.code == 0 {
+		a.code = b.code
+	}
+	a.truncate = a.truncate || b.truncate
+	a.drop = a.drop || b.drop
+}
+
+func (a faultAction) isEmpty() bool {
+	return a.delay == 0 && a.code == 0 && !a.truncate && !a.drop
+}
+
+type faultInjector struct {
+	sync.Mutex
+	Faults
+	requests int
+	steps    []FaultStep
+	rnd      *rand.Rand
+}
+
+func newFaultInjector(f *Faults) *faultInjector {
+	if f == nil {
+		return nil
+	}
+
+	seed := f.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	fi := &faultInjector{
+		Faults: *f,
+		rnd:    rand.New(rand.NewSource(seed)),
+	}
+	if fi.ErrorCode == 0 {
+		fi.ErrorCode = http.StatusServiceUnavailable
+	}
+	for _, s := range f.Sequence {
+		repeat := s.Repeat
+		if repeat <= 0 {
+			repeat = 1
+		}
+		for i := 0; i < repeat; i++ {
+			fi.steps = append(fi.steps, s)
+		}
+	}
+
+	return fi
+}
+
+func (fi *faultInjector) next() faultAction {
+	if fi == nil {
+		return faultAction{}
+	}
+
+	fi.Lock()
+	defer fi.Unlock()
+
+	n := fi.requests
+	fi.requests++
+	if len(fi.steps) > 0 && (fi.Loop || n < len(fi.steps)) {
+		s := fi.steps[n%len(fi.steps)]
+		return faultAction{
+			delay:    time.Duration(s.DelayMS) * time.Millisecond,
+			code:     s.Code,
+			truncate: s.Truncate,
+			drop:     s.Drop,
+		}
+	}
+
+	var a faultAction
+	if fi.Latency != nil {
+		a.delay = fi.Latency.sample(fi.rnd)
+	}
+	if fi.ErrorRate > 0 && fi.rnd.Float64() < fi.ErrorRate {
+		a.code = fi.ErrorCode
+	}
+	if fi.TruncateRate > 0 && fi.rnd.Float64() < fi.TruncateRate {
+		a.truncate = true
+	}
+	if fi.DropRate > 0 && fi.rnd.Float64() < fi.DropRate {
+		a.drop = true
+	}
+
+	return a
+}
+
+func (l *Latency) sample(rnd *rand.Rand) time.Duration {
+	var ms float64
+	switch l.Distribution {
+	case "", "fixed":
+		ms = float64(l.MS)
+	case "uniform":
+		ms = float64(l.MinMS) + rnd.Float64()*float64(l.MaxMS-l.MinMS)
+	case "normal":
+		ms = float64(l.MeanMS) + rnd.NormFloat64()*float64(l.StdDevMS)
+	case "exponential":
+		ms = rnd.ExpFloat64() * float64(l.MeanMS)
+	}
+
+	ms = math.Max(ms, float64(l.MinMS))
+	if l.MaxMS > 0 {
+		ms = math.Min(ms, float64(l.MaxMS))
+	}
+
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+func (l *Latency) validate() error {
+	switch l.Distribution {
+	case "", "fixed", "uniform", "normal", "exponential":
+	default:
+		return fmt.Errorf("unknown latency distribution %q", l.Distribution)
+	}
+	if l.MaxMS > 0 && l.MinMS > l.MaxMS {
+		return fmt.Errorf("latency minMS is greater than maxMS")
+	}
+	return nil
+}
+
+func (f *Faults) validate() error {
+	if f == nil {
+		return nil
+	}
+	for name, rate := range map[string]float64{"errorRate": f.ErrorRate, "truncateRate": f.TruncateRate, "dropRate": f.DropRate} {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("%s should be between 0 and 1", name)
+		}
+	}
+	if f.Latency != nil {
+		return f.Latency.validate()
+	}
+	return nil
+}
+
+type faultStateKey struct{}
+
+// faultState accumulates faults of the listener and of all expressions used to serve the request
+type faultState struct {
+	sync.Mutex
+	faultAction
+}
+
+func (cfg *listener) initFaults() error {
+	if err := cfg.Faults.validate(); err != nil {
+		return fmt.Errorf("listener faults: %w", err)
+	}
+	cfg.faults = newFaultInjector(cfg.Faults)
+
+	cfg.expressionFaults = make(map[string]*faultInjector)
+	for k, v := range cfg.Expressions {
+		if err := v.Faults.validate(); err != nil {
+			return fmt.Errorf("faults of expression %q: %w", k, err)
+		}
+		if v.Faults != nil {
+			cfg.expressionFaults[k] = newFaultInjector(v.Faults)
+		}
+	}
+
+	return nil
+}
+
+// injectFaults applies faults configured for the expression to the request that is being served
+func (cfg *listener) injectFaults(req *http.Request, expression string) {
+	fi, ok := cfg.expressionFaults[expression]
+	if !ok {
+		return
+	}
+	state, ok := req.Context().Value(faultStateKey{}).(*faultState)
+	if !ok {
+		return
+	}
+
+	a := fi.next()
+	if a.isEmpty() {
+		return
+	}
+	cfg.logger.Info("injecting faults",
+		zap.String("expression", expression),
+		zap.Duration("delay", a.delay),
+		zap.Int("code", a.code),
+		zap.Bool("truncate", a.truncate),
+		zap.Bool("drop", a.drop),
+	)
+	time.Sleep(a.delay)
+
+	state.Lock()
+	state.merge(a)
+	state.Unlock()
+}
+
+// withFaults buffers the response of the handler and damages it according to configured faults
+func (cfg *listener) withFaults(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		state := &faultState{faultAction: cfg.faults.next()}
+		logger := cfg.logger.With(
+			zap.String("function", "withFaults"),
+			zap.String("path", req.URL.Path),
+		)
+		if !state.isEmpty() {
+			logger.Info("injecting faults",
+				zap.Duration("delay", state.delay),
+				zap.Int("code", state.code),
+				zap.Bool("truncate", state.truncate),
+				zap.Bool("drop", state.drop),
+			)
+		}
+		time.Sleep(state.delay)
+		if state.code != 0 {
+			http.Error(wr, http.StatusText(state.code), state.code)
+			return
+		}
+
+		buf := &bufferedResponseWriter{header: make(http.Header)}
+		h.ServeHTTP(buf, req.WithContext(context.WithValue(req.Context(), faultStateKey{}, state)))
+
+		state.Lock()
+		a := state.faultAction
+		state.Unlock()
+
+		switch {
+		case a.code != 0:
+			http.Error(wr, http.StatusText(a.code), a.code)
+		case a.drop:
+			dropConnection(logger, wr, buf)
+		default:
+			body := buf.body.Bytes()
+			if a.truncate {
+				body = body[:len(body)/2]
+			}
+			for k, v := range buf.header {
+				wr.Header()[k] = v
+			}
+			wr.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			wr.WriteHeader(buf.statusCode())
+			_, _ = wr.Write(body)
+		}
+	})
+}
+
+// dropConnection sends headers and half of the body and then resets connection
+func dropConnection(logger *zap.Logger, wr http.ResponseWriter, buf *bufferedResponseWriter) {
+	hj, ok := wr.(http.Hijacker)
+	if !ok {
+		logger.Error("connection can't be hijacked, will respond with truncated body")
+		body := buf.body.Bytes()
+		_, _ = wr.Write(body[:len(body)/2])
+		return
+	}
+
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		logger.Error("failed to hijack connection", zap.Error(err))
+		return
+	}
+
+	if buf.body.Len() > 0 {
+		code := buf.statusCode()
+		buf.header.Set("Content-Length", strconv.Itoa(buf.body.Len()))
+		_, _ = fmt.Fprintf(rw, "HTTP/1.1 %d %s\r\n", code, http.StatusText(code))
+		_ = buf.header.Write(rw)
+		_, _ = rw.WriteString("\r\n")
+		_, _ = rw.Write(buf.body.Bytes()[:buf.body.Len()/2])
+		_ = rw.Flush()
+	}
+
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		// send RST instead of FIN
+		_ = tcpConn.SetLinger(0)
+	}
+	_ = conn.Close()
+}
+
+type bufferedResponseWriter struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	if w.code == 0 {
+		w.code = code
+	}
+}
+
+func (w *bufferedResponseWriter) statusCode() int {
+	if w.code == 0 {
+		return http.StatusOK
+	}
+	return w.code
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func Test_faultInjector(t *testing.T) {
+	fi := newFaultInjector(&Faults{
+		Sequence: []FaultStep{
+			{Code: 503, Repeat: 2},
+			{DelayMS: 100},
+		},
+	})
+
+	want := []faultAction{
+		{code: 503},
+		{code: 503},
+		{delay: 100 * time.Millisecond},
+		{},
+		{},
+	}
+	for i, w := range want {
+		if got := fi.next(); got != w {
+			t.Errorf("request %d: got %+v, want %+v", i, got, w)
+		}
+	}
+
+	fi = newFaultInjector(&Faults{
+		Sequence: []FaultStep{{Drop: true}, {}},
+		Loop:     true,
+	})
+	for i := 0; i < 4; i++ {
+		if got := fi.next(); got.drop != (i%2 == 0) {
+			t.Errorf("request %d: got drop=%v", i, got.drop)
+		}
+	}
+
+	fi = newFaultInjector(&Faults{Seed: 1, ErrorRate: 1, TruncateRate: 1})
+	if got := fi.next(); got.code != 503 || !got.truncate || got.drop {
+		t.Errorf("unexpected random faults: %+v", got)
+	}
+
+	var nilInjector *faultInjector
+	if got := nilInjector.next(); !got.isEmpty() {
+		t.Errorf("nil injector returned %+v", got)
+	}
+}
+
+func Test_latencySample(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	tests := []Latency{
+		{MS: 10},
+		{Distribution: "uniform", MinMS: 10, MaxMS: 20},
+		{Distribution: "normal", MeanMS: 15, StdDevMS: 100, MinMS: 10, MaxMS: 20},
+		{Distribution: "exponential", MeanMS: 15, MinMS: 10, MaxMS: 20},
+	}
+	for _, l := range tests {
+		t.Run(l.Distribution, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				d := l.sample(rnd)
+				if d < 10*time.Millisecond || d > 20*time.Millisecond {
+					t.Fatalf("sample %v is out of range", d)
+				}
+			}
+		})
+	}
+
+	if err := (&Latency{Distribution: "pareto"}).validate(); err == nil {
+		t.Errorf("unknown distribution should fail validation")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"math/rand"
 	"net/http"
@@ -17,6 +18,14 @@ import (
 	"github.com/go-graphite/carbonapi/intervalset"
 )
 
+type findTreeJSON struct {
+	ID            string `json:"id"`
+	Text          string `json:"text"`
+	AllowChildren int    `json:"allowChildren"`
+	Expandable    int    `json:"expandable"`
+	Leaf          int    `json:"leaf"`
+}
+
 func (cfg *listener) findHandler(wr http.ResponseWriter, req *http.Request) {
 	_ = req.ParseMultipartForm(16 * 1024 * 1024)
 	hdrs := make(map[string][]string)
@@ -82,7 +91,8 @@ func (cfg *listener) findHandler(wr http.ResponseWriter, req *http.Request) {
 		Metrics: []carbonapi_v3_pb.GlobResponse{},
 	}
 
-	if query[0] == "*" {
+	// VictoriaMetrics asks for "*." to get only top-level nodes
+	if query[0] == "*" || query[0] == "*." {
 		returnMap := make(map[string]struct{})
 		for m := range cfg.Listener.Expressions {
 			response := cfg.Expressions[m]
@@ -113,6 +123,7 @@ func (cfg *listener) findHandler(wr http.ResponseWriter, req *http.Request) {
 		for _, m := range query {
 			globMatches := []carbonapi_v3_pb.GlobMatch{}
 			if response, ok := cfg.Expressions[m]; ok {
+				cfg.injectFaults(req, m)
 				if response.ReplyDelayMS > 0 {
 					delay := time.Duration(response.ReplyDelayMS) * time.Millisecond
 					time.Sleep(delay)
@@ -126,7 +137,7 @@ func (cfg *listener) findHandler(wr http.ResponseWriter, req *http.Request) {
 				for _, metric := range cfg.Expressions[m].Data {
 					globMatches = append(globMatches, carbonapi_v3_pb.GlobMatch{
 						Path:   metric.MetricName,
-						IsLeaf: true,
+						IsLeaf: !metric.Branch,
 					})
 				}
 				multiGlobs.Metrics = append(multiGlobs.Metrics,
@@ -148,6 +159,26 @@ func (cfg *listener) findHandler(wr http.ResponseWriter, req *http.Request) {
 
 	var b []byte
 	switch format {
+	case jsonFormat:
+		// graphite-web's treejson format, as served by VictoriaMetrics
+		result := make([]findTreeJSON, 0)
+		for _, globs := range multiGlobs.Metrics {
+			for _, metric := range globs.Matches {
+				t := findTreeJSON{
+					ID:   metric.Path,
+					Text: metric.Path[strings.LastIndex(metric.Path, ".")+1:],
+				}
+				if metric.IsLeaf {
+					t.Leaf = 1
+				} else {
+					t.ID += "."
+					t.AllowChildren = 1
+					t.Expandable = 1
+				}
+				result = append(result, t)
+			}
+		}
+		b, err = json.Marshal(result)
 	case protoV2Format:
 		response := carbonapi_v2_pb.GlobResponse{
 			Name:    query[0],
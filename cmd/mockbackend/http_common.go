@@ -12,13 +12,16 @@ type Response struct {
 	PathExpression string   `yaml:"pathExpression"`
 	Data           []Metric `yaml:"data"`
 	Tags           []string `yaml:"tags"`
+	Faults         *Faults  `yaml:"faults"`
 }
 
 type Metric struct {
 	MetricName string    `yaml:"metricName"`
 	Step       int       `yaml:"step"`
+	StepMs     int       `yaml:"stepMs"` // sub-second step, used instead of step by prometheus handlers
 	StartTime  int       `yaml:"startTime"`
 	Values     []float64 `yaml:"values"`
+	Branch     bool      `yaml:"branch"` // non-leaf node in find responses
 }
 
 type metricForJson struct {
@@ -88,6 +91,7 @@ func copyResponse(src Response) Response {
 	dst := Response{
 		PathExpression: src.PathExpression,
 		ReplyDelayMS:   src.ReplyDelayMS,
+		Faults:         src.Faults,
 		Data:           make([]Metric, len(src.Data)),
 	}
 
@@ -97,6 +101,7 @@ func copyResponse(src Response) Response {
 			Values:     make([]float64, len(src.Data[i].Values)),
 			StartTime:  src.Data[i].StartTime,
 			Step:       src.Data[i].Step,
+			Branch:     src.Data[i].Branch,
 		}
 
 		copy(dst.Data[i].Values, src.Data[i].Values)
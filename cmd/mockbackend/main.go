@@ -24,6 +24,8 @@ type Listener struct {
 	Code           int                 `yaml:"httpCode"` // global responce code
 	ShuffleResults bool                `yaml:"shuffleResults"`
 	EmptyBody      bool                `yaml:"emptyBody"`
+	Version        string              `yaml:"version"` // version reported to prometheus and victoriametrics clients
+	Faults         *Faults             `yaml:"faults"`
 	Expressions    map[string]Response `yaml:"expressions"`
 }
 
@@ -32,9 +34,25 @@ var cfg = MainConfig{}
 type listener struct {
 	Listener
 	logger *zap.Logger
+
+	faults           *faultInjector
+	expressionFaults map[string]*faultInjector
 }
 
 func main() {
+	logger, err := zap.NewProduction()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		if replay(logger, os.Args[2:]) {
+			// skipcq: CRT-D0011
+			os.Exit(1)
+		}
+		return
+	}
+
 	config := flag.String("config", "average.yaml", "yaml where it would be possible to get data")
 	verbose := flag.Bool("verbose", false, "verbose reporting")
 	testonly := flag.Bool("testonly", false, "run only unit test")
@@ -42,24 +60,12 @@ func main() {
 	test := flag.Bool("test", false, "run unit test if present")
 	breakOnError := flag.Bool("break", false, "break and wait user response if request failed")
 	flag.Parse()
-	logger, err := zap.NewProduction()
-	if err != nil {
-		log.Fatal(err)
-	}
 
 	if *config == "" {
 		logger.Fatal("failed to get config, it should be non-null")
 	}
 
-	f, err := os.Open(*config)
-	if err != nil {
-		logger.Fatal("failed to read config", zap.Error(err))
-	}
-
-	decoder := yaml.NewDecoder(f)
-	decoder.SetStrict(true)
-	err = decoder.Decode(&cfg)
-	if err != nil {
+	if err := loadConfig(*config); err != nil {
 		logger.Fatal("failed to read config", zap.Error(err))
 		return
 	}
@@ -68,59 +74,10 @@ func main() {
 		zap.Any("config", cfg),
 	)
 
-	httpServers := make([]*http.Server, 0)
-	wg := sync.WaitGroup{}
-	wgStart := sync.WaitGroup{}
+	var httpServers []*http.Server
+	wg := &sync.WaitGroup{}
 	if !*testonly {
-		for _, c := range cfg.Listeners {
-			logger := logger.With(zap.String("listener", c.Address))
-			listener := listener{
-				Listener: c,
-				logger:   logger,
-			}
-
-			if listener.Address == "" {
-				listener.Address = ":9070"
-			}
-
-			if listener.Code == 0 {
-				listener.Code = http.StatusOK
-			}
-
-			logger.Info("started",
-				zap.String("listener", listener.Address),
-				zap.Any("config", c),
-			)
-
-			mux := http.NewServeMux()
-			mux.HandleFunc("/render", listener.renderHandler)
-			mux.HandleFunc("/render/", listener.renderHandler)
-			mux.HandleFunc("/metrics/find", listener.findHandler)
-			mux.HandleFunc("/metrics/find/", listener.findHandler)
-			mux.HandleFunc("/tags/autoComplete/values", listener.tagsValuesHandler)
-			mux.HandleFunc("/tags/autoComplete/tags", listener.tagsNamesHandler)
-
-			wg.Add(1)
-			wgStart.Add(1)
-			server := &http.Server{
-				Addr:    listener.Address,
-				Handler: mux,
-			}
-			go func(h *http.Server) {
-				wgStart.Done()
-				err = h.ListenAndServe()
-				if err != nil && err != http.ErrServerClosed {
-					logger.Error("failed to start server",
-						zap.Error(err),
-					)
-				}
-				wg.Done()
-			}(server)
-
-			wgStart.Wait()
-			httpServers = append(httpServers, server)
-		}
-		logger.Info("all listeners started")
+		httpServers, wg = startListeners(logger)
 	}
 
 	failed := false
@@ -130,12 +87,7 @@ func main() {
 
 	if !*testonly {
 		if *test {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			for i := range httpServers {
-				// we don't care about error here
-				_ = httpServers[i].Shutdown(ctx)
-			}
-			cancel()
+			shutdownListeners(httpServers)
 		}
 
 		wg.Wait()
@@ -146,3 +98,93 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+func loadConfig(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cfg = MainConfig{}
+	decoder := yaml.NewDecoder(f)
+	decoder.SetStrict(true)
+	return decoder.Decode(&cfg)
+}
+
+// startListeners starts http servers for all listeners from config, wait group is done when all of them are stopped
+func startListeners(logger *zap.Logger) ([]*http.Server, *sync.WaitGroup) {
+	httpServers := make([]*http.Server, 0)
+	wg := &sync.WaitGroup{}
+	wgStart := sync.WaitGroup{}
+	for _, c := range cfg.Listeners {
+		logger := logger.With(zap.String("listener", c.Address))
+		listener := listener{
+			Listener: c,
+			logger:   logger,
+		}
+
+		if listener.Address == "" {
+			listener.Address = ":9070"
+		}
+
+		if listener.Code == 0 {
+			listener.Code = http.StatusOK
+		}
+
+		if err := listener.initFaults(); err != nil {
+			logger.Fatal("invalid faults config", zap.Error(err))
+		}
+
+		logger.Info("started",
+			zap.String("listener", listener.Address),
+			zap.Any("config", c),
+		)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/render", listener.renderHandler)
+		mux.HandleFunc("/render/", listener.renderHandler)
+		mux.HandleFunc("/metrics/find", listener.findHandler)
+		mux.HandleFunc("/metrics/find/", listener.findHandler)
+		mux.HandleFunc("/tags/autoComplete/values", listener.tagsValuesHandler)
+		mux.HandleFunc("/tags/autoComplete/tags", listener.tagsNamesHandler)
+		mux.HandleFunc("/api/v1/query_range", listener.promQueryRangeHandler)
+		mux.HandleFunc("/api/v1/series", listener.promSeriesHandler)
+		mux.HandleFunc("/api/v1/labels", listener.promLabelsHandler)
+		mux.HandleFunc("/api/v1/label/", listener.promLabelsHandler)
+		mux.HandleFunc("/api/v1/status/buildinfo", listener.promBuildInfoHandler)
+		mux.HandleFunc("/metrics", listener.vmMetricsHandler)
+
+		wg.Add(1)
+		wgStart.Add(1)
+		server := &http.Server{
+			Addr:    listener.Address,
+			Handler: listener.withFaults(mux),
+		}
+		go func(h *http.Server) {
+			wgStart.Done()
+			err := h.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				logger.Error("failed to start server",
+					zap.Error(err),
+				)
+			}
+			wg.Done()
+		}(server)
+
+		wgStart.Wait()
+		httpServers = append(httpServers, server)
+	}
+	logger.Info("all listeners started")
+
+	return httpServers, wg
+}
+
+func shutdownListeners(httpServers []*http.Server) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	for i := range httpServers {
+		// we don't care about error here
+		_ = httpServers[i].Shutdown(ctx)
+	}
+	cancel()
+}
@@ -0,0 +1,529 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/go-graphite/carbonapi/zipper/protocols/prometheus/helpers"
+)
+
+// Prometheus and VictoriaMetrics emulation. Series are built from all metrics in listener's Expressions: metric name
+// becomes __name__ label and graphite tags (name;tag=value) become labels. Response code and delay of every expression
+// that contributed a series are honored.
+
+type promMatcher struct {
+	label string
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+type promSeries struct {
+	labels map[string]string
+	metric Metric
+}
+
+type promResponse struct {
+	Status    string      `json:"status"`
+	ErrorType string      `json:"errorType,omitempty"`
+	Error     string      `json:"error,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+type promMatrix struct {
+	ResultType string         `json:"resultType"`
+	Result     []promInterval `json:"result"`
+}
+
+type promInterval struct {
+	Metric map[string]string `json:"metric"`
+	Values [][2]interface{}  `json:"values"`
+}
+
+// parsePromSelector parses series selector, e.g. `name{label="value",label2=~"re"}`
+func parsePromSelector(s string) ([]promMatcher, error) {
+	s = strings.TrimSpace(s)
+	var matchers []promMatcher
+
+	idx := strings.IndexByte(s, '{')
+	name := s
+	if idx >= 0 {
+		name = s[:idx]
+	}
+	name = strings.TrimSpace(name)
+	if name != "" {
+		matchers = append(matchers, promMatcher{label: "__name__", op: "=", value: name})
+	}
+	if idx < 0 {
+		return matchers, nil
+	}
+
+	s = s[idx+1:]
+	for {
+		s = strings.TrimLeft(s, " ,")
+		if s == "" {
+			return nil, fmt.Errorf("unexpected end of selector")
+		}
+		if s[0] == '}' {
+			break
+		}
+
+		n := strings.IndexAny(s, "=!")
+		if n <= 0 {
+			return nil, fmt.Errorf("label name expected in %q", s)
+		}
+		m := promMatcher{label: strings.TrimSpace(s[:n])}
+		s = s[n:]
+		for _, op := range []string{"=~", "!~", "!=", "="} {
+			if strings.HasPrefix(s, op) {
+				m.op = op
+				break
+			}
+		}
+		if m.op == "" {
+			return nil, fmt.Errorf("unknown operator in %q", s)
+		}
+		s = strings.TrimSpace(s[len(m.op):])
+
+		if s == "" || s[0] != '"' {
+			return nil, fmt.Errorf("quoted value expected for label %q", m.label)
+		}
+		end := 1
+		for end < len(s) && s[end] != '"' {
+			if s[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(s) {
+			return nil, fmt.Errorf("unterminated value for label %q", m.label)
+		}
+		m.value = s[1:end]
+		// graphite's seriesByTag is converted without escaping, so keep raw value if it's not a valid go string
+		if v, err := strconv.Unquote(s[:end+1]); err == nil {
+			m.value = v
+		}
+		s = s[end+1:]
+
+		var err error
+		switch m.op {
+		case "=~", "!~":
+			m.re, err = regexp.Compile("^(?:" + m.value + ")$")
+		case "=":
+			if m.label == "__graphite__" {
+				m.re, err = globToRegexp(m.value)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+
+	return matchers, nil
+}
+
+// globToRegexp converts graphite glob to regexp that matches single path, as VictoriaMetrics does for __graphite__
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	re := helpers.ConvertGraphiteTargetToPromQL(glob)
+	if strings.HasSuffix(glob, "*") {
+		// trailing asterisk is expanded across the dots for find requests, but shouldn't be for __graphite__
+		re = strings.TrimSuffix(re, ".*") + "[^.]*"
+	}
+	return regexp.Compile("^(?:" + re + ")$")
+}
+
+func (m *promMatcher) matches(labels map[string]string) bool {
+	if m.label == "__graphite__" {
+		return m.re.MatchString(labels["__name__"])
+	}
+
+	v := labels[m.label]
+	switch m.op {
+	case "=":
+		return v == m.value
+	case "!=":
+		return v != m.value
+	case "=~":
+		return m.re.MatchString(v)
+	default:
+		return !m.re.MatchString(v)
+	}
+}
+
+func metricLabels(name string) map[string]string {
+	parts := strings.Split(name, ";")
+	labels := map[string]string{"__name__": parts[0]}
+	for _, p := range parts[1:] {
+		if idx := strings.IndexByte(p, '='); idx > 0 {
+			labels[p[:idx]] = p[idx+1:]
+		}
+	}
+	return labels
+}
+
+// promSeries returns series matching any of the selectors. Non-zero code is returned if one of the contributing
+// expressions is configured to fail.
+func (cfg *listener) promSeries(req *http.Request, logger *zap.Logger, selectors []string) ([]promSeries, int, error) {
+	matchersList := make([][]promMatcher, 0, len(selectors))
+	for _, s := range selectors {
+		matchers, err := parsePromSelector(s)
+		if err != nil {
+			return nil, 0, err
+		}
+		matchersList = append(matchersList, matchers)
+	}
+
+	keys := make([]string, 0, len(cfg.Expressions))
+	for k := range cfg.Expressions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var series []promSeries
+	var matchedKeys []string
+	seen := make(map[string]bool)
+	code := 0
+	delayMS := 0
+	for _, k := range keys {
+		response := cfg.Expressions[k]
+		keyMatched := false
+		for _, m := range response.Data {
+			labels := metricLabels(m.MetricName)
+			matched := false
+			for _, matchers := range matchersList {
+				matched = true
+				for i := range matchers {
+					if !matchers[i].matches(labels) {
+						matched = false
+						break
+					}
+				}
+				if matched {
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+
+			if !keyMatched {
+				keyMatched = true
+				matchedKeys = append(matchedKeys, k)
+			}
+			if response.ReplyDelayMS > delayMS {
+				delayMS = response.ReplyDelayMS
+			}
+			if code == 0 && response.Code != 0 && response.Code != http.StatusOK {
+				code = response.Code
+			}
+			if seen[m.MetricName] {
+				continue
+			}
+			seen[m.MetricName] = true
+			series = append(series, promSeries{labels: labels, metric: m})
+		}
+	}
+
+	for _, k := range matchedKeys {
+		cfg.injectFaults(req, k)
+	}
+
+	if delayMS > 0 {
+		delay := time.Duration(delayMS) * time.Millisecond
+		logger.Info("will add extra delay",
+			zap.Duration("delay", delay),
+		)
+		time.Sleep(delay)
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].metric.MetricName < series[j].metric.MetricName
+	})
+	if cfg.Listener.ShuffleResults {
+		rand.Shuffle(len(series), func(i, j int) {
+			series[i], series[j] = series[j], series[i]
+		})
+	}
+
+	return series, code, nil
+}
+
+// parsePromDuration parses duration in prometheus format: either float number of seconds or go duration
+func parsePromDuration(s string) (float64, error) {
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		return v, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return d.Seconds(), nil
+}
+
+// samples evaluates metric at start + N*step the way prometheus does: last non-NaN sample within lookback is used
+func (s *promSeries) samples(start, end, step, lookback float64) [][2]interface{} {
+	mStep := float64(s.metric.Step)
+	if s.metric.StepMs != 0 {
+		mStep = float64(s.metric.StepMs) / 1000
+	}
+	if mStep == 0 {
+		mStep = 1
+	}
+	mStart := float64(s.metric.StartTime)
+	if mStart == 0 {
+		mStart = mStep
+	}
+
+	var res [][2]interface{}
+	for n := 0; start+float64(n)*step <= end; n++ {
+		t := start + float64(n)*step
+		idx := int(math.Floor((t - mStart) / mStep))
+		if idx >= len(s.metric.Values) {
+			idx = len(s.metric.Values) - 1
+		}
+		for ; idx >= 0; idx-- {
+			ts := mStart + float64(idx)*mStep
+			if ts <= t-lookback {
+				break
+			}
+			if v := s.metric.Values[idx]; !math.IsNaN(v) {
+				res = append(res, [2]interface{}{t, strconv.FormatFloat(v, 'f', -1, 64)})
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+func (cfg *listener) writePromResponse(wr http.ResponseWriter, logger *zap.Logger, code int, response promResponse) {
+	b, err := json.Marshal(response)
+	if err != nil {
+		logger.Error("failed to marshal", zap.Error(err))
+		http.Error(wr, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	wr.Header().Set("Content-Type", contentTypeJSON)
+	wr.WriteHeader(code)
+	if cfg.EmptyBody {
+		return
+	}
+	_, _ = wr.Write(b)
+}
+
+func (cfg *listener) writePromError(wr http.ResponseWriter, logger *zap.Logger, code int, errorType string, err error) {
+	logger.Error("request failed",
+		zap.Int("code", code),
+		zap.Error(err),
+	)
+	cfg.writePromResponse(wr, logger, code, promResponse{
+		Status:    "error",
+		ErrorType: errorType,
+		Error:     err.Error(),
+	})
+}
+
+func (cfg *listener) promLogger(function string, req *http.Request) *zap.Logger {
+	_ = req.ParseForm()
+	logger := cfg.logger.With(
+		zap.String("function", function),
+		zap.String("method", req.Method),
+		zap.String("path", req.URL.Path),
+		zap.Any("form", req.Form),
+	)
+	logger.Info("got request")
+	return logger
+}
+
+func (cfg *listener) promQueryRangeHandler(wr http.ResponseWriter, req *http.Request) {
+	logger := cfg.promLogger("promQueryRangeHandler", req)
+	if cfg.Code != http.StatusOK {
+		wr.WriteHeader(cfg.Code)
+		return
+	}
+
+	start, err := strconv.ParseFloat(req.Form.Get("start"), 64)
+	if err != nil {
+		cfg.writePromError(wr, logger, http.StatusBadRequest, "bad_data", fmt.Errorf("invalid parameter \"start\": %w", err))
+		return
+	}
+	end, err := strconv.ParseFloat(req.Form.Get("end"), 64)
+	if err != nil {
+		cfg.writePromError(wr, logger, http.StatusBadRequest, "bad_data", fmt.Errorf("invalid parameter \"end\": %w", err))
+		return
+	}
+	step, err := parsePromDuration(req.Form.Get("step"))
+	if err != nil || step <= 0 {
+		cfg.writePromError(wr, logger, http.StatusBadRequest, "bad_data", fmt.Errorf("invalid parameter \"step\": %q", req.Form.Get("step")))
+		return
+	}
+	// VictoriaMetrics passes max_lookback, prometheus always looks 5 minutes back
+	lookback := 300.0
+	if v := req.Form.Get("max_lookback"); v != "" {
+		if lookback, err = parsePromDuration(v); err != nil {
+			cfg.writePromError(wr, logger, http.StatusBadRequest, "bad_data", fmt.Errorf("invalid parameter \"max_lookback\": %w", err))
+			return
+		}
+	}
+
+	series, code, err := cfg.promSeries(req, logger, []string{req.Form.Get("query")})
+	if err != nil {
+		cfg.writePromError(wr, logger, http.StatusBadRequest, "bad_data", err)
+		return
+	}
+	if code != 0 {
+		cfg.writePromError(wr, logger, code, "execution", fmt.Errorf("%s", http.StatusText(code)))
+		return
+	}
+
+	matrix := promMatrix{
+		ResultType: "matrix",
+		Result:     make([]promInterval, 0, len(series)),
+	}
+	for i := range series {
+		values := series[i].samples(start, end, step, lookback)
+		if len(values) == 0 {
+			continue
+		}
+		matrix.Result = append(matrix.Result, promInterval{
+			Metric: series[i].labels,
+			Values: values,
+		})
+	}
+
+	logger.Info("will return", zap.Any("response", matrix))
+	cfg.writePromResponse(wr, logger, http.StatusOK, promResponse{Status: "success", Data: matrix})
+}
+
+func (cfg *listener) promSeriesHandler(wr http.ResponseWriter, req *http.Request) {
+	logger := cfg.promLogger("promSeriesHandler", req)
+	if cfg.Code != http.StatusOK {
+		wr.WriteHeader(cfg.Code)
+		return
+	}
+
+	selectors := req.Form["match[]"]
+	if len(selectors) == 0 {
+		cfg.writePromError(wr, logger, http.StatusBadRequest, "bad_data", fmt.Errorf("no match[] parameter provided"))
+		return
+	}
+
+	series, code, err := cfg.promSeries(req, logger, selectors)
+	if err != nil {
+		cfg.writePromError(wr, logger, http.StatusBadRequest, "bad_data", err)
+		return
+	}
+	if code != 0 {
+		cfg.writePromError(wr, logger, code, "execution", fmt.Errorf("%s", http.StatusText(code)))
+		return
+	}
+
+	data := make([]map[string]string, 0, len(series))
+	for i := range series {
+		data = append(data, series[i].labels)
+	}
+
+	logger.Info("will return", zap.Any("response", data))
+	cfg.writePromResponse(wr, logger, http.StatusOK, promResponse{Status: "success", Data: data})
+}
+
+// promLabelsHandler serves both /api/v1/labels and /api/v1/label/<name>/values
+func (cfg *listener) promLabelsHandler(wr http.ResponseWriter, req *http.Request) {
+	logger := cfg.promLogger("promLabelsHandler", req)
+	if cfg.Code != http.StatusOK {
+		wr.WriteHeader(cfg.Code)
+		return
+	}
+
+	label := ""
+	if req.URL.Path != "/api/v1/labels" {
+		label = strings.TrimPrefix(req.URL.Path, "/api/v1/label/")
+		if !strings.HasSuffix(label, "/values") {
+			http.NotFound(wr, req)
+			return
+		}
+		label = strings.TrimSuffix(label, "/values")
+	}
+
+	selectors := req.Form["match[]"]
+	if len(selectors) == 0 {
+		selectors = []string{`{__name__=~".*"}`}
+	}
+
+	series, code, err := cfg.promSeries(req, logger, selectors)
+	if err != nil {
+		cfg.writePromError(wr, logger, http.StatusBadRequest, "bad_data", err)
+		return
+	}
+	if code != 0 {
+		cfg.writePromError(wr, logger, code, "execution", fmt.Errorf("%s", http.StatusText(code)))
+		return
+	}
+
+	unique := make(map[string]struct{})
+	for i := range series {
+		if label == "" {
+			for k := range series[i].labels {
+				unique[k] = struct{}{}
+			}
+		} else if v, ok := series[i].labels[label]; ok {
+			unique[v] = struct{}{}
+		}
+	}
+	data := make([]string, 0, len(unique))
+	for k := range unique {
+		data = append(data, k)
+	}
+	sort.Strings(data)
+
+	logger.Info("will return", zap.Strings("response", data))
+	cfg.writePromResponse(wr, logger, http.StatusOK, promResponse{Status: "success", Data: data})
+}
+
+func (cfg *listener) promBuildInfoHandler(wr http.ResponseWriter, req *http.Request) {
+	logger := cfg.promLogger("promBuildInfoHandler", req)
+	if cfg.Code != http.StatusOK {
+		wr.WriteHeader(cfg.Code)
+		return
+	}
+	if cfg.Version == "" {
+		http.NotFound(wr, req)
+		return
+	}
+
+	cfg.writePromResponse(wr, logger, http.StatusOK, promResponse{
+		Status: "success",
+		Data: map[string]string{
+			"version": strings.TrimPrefix(cfg.Version, "v"),
+		},
+	})
+}
+
+// vmMetricsHandler exposes version the same way as VictoriaMetrics does on /metrics, carbonapi uses it to detect
+// supported features
+func (cfg *listener) vmMetricsHandler(wr http.ResponseWriter, req *http.Request) {
+	_ = cfg.promLogger("vmMetricsHandler", req)
+	if cfg.Code != http.StatusOK {
+		wr.WriteHeader(cfg.Code)
+		return
+	}
+	if cfg.Version == "" {
+		http.NotFound(wr, req)
+		return
+	}
+
+	wr.Header().Set("Content-Type", contentTypeRaw)
+	_, _ = fmt.Fprintf(wr, "vm_app_version{version=\"victoria-metrics-%s\", short_version=\"%s\"} 1\n", cfg.Version, cfg.Version)
+}
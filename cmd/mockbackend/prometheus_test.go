@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_parsePromSelector(t *testing.T) {
+	labels := metricLabels("cpu.usage;dc=east;host=web1")
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{selector: `{__name__=~"cpu\\.[^.]*?"}`, want: true},
+		{selector: `{__name__=~"cpu"}`, want: false},
+		{selector: `cpu.usage{host=~"web.*", dc!="west"}`, want: true},
+		{selector: `cpu.usage{host!~"web.*"}`, want: false},
+		{selector: `{dc="east"}`, want: true},
+		{selector: `{rack=""}`, want: true},
+		{selector: `{__graphite__="cpu.*"}`, want: true},
+		{selector: `{__graphite__="cpu.{usage,idle}"}`, want: true},
+		{selector: `{__graphite__="*"}`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			matchers, err := parsePromSelector(tt.selector)
+			if err != nil {
+				t.Fatalf("parsePromSelector(%q) returned error: %v", tt.selector, err)
+			}
+			got := true
+			for i := range matchers {
+				got = got && matchers[i].matches(labels)
+			}
+			if got != tt.want {
+				t.Errorf("parsePromSelector(%q) matches = %v, want %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+
+	for _, s := range []string{`{host=web}`, `{host="web"`, `{host~"web"}`} {
+		if _, err := parsePromSelector(s); err == nil {
+			t.Errorf("parsePromSelector(%q) expected to fail", s)
+		}
+	}
+}
@@ -94,6 +94,7 @@ func (cfg *listener) renderHandler(wr http.ResponseWriter, req *http.Request) {
 	httpCode := http.StatusOK
 	for _, target := range targets {
 		if response, ok := cfg.Expressions[target]; ok {
+			cfg.injectFaults(req, target)
 			if response.ReplyDelayMS > 0 {
 				delay := time.Duration(response.ReplyDelayMS) * time.Millisecond
 				logger.Info("will add extra delay",
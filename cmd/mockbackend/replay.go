@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+// replay runs test cases captured by carbonapi (see capture in carbonapi configuration): listeners serve recorded
+// responses of backends, recorded queries are sent to carbonapi and responses are compared with the recorded ones.
+// Returns true if any of queries failed.
+func replay(logger *zap.Logger, args []string) bool {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	endpoint := fs.String("carbonapi", "", "carbonapi address (e.x. http://127.0.0.1:8081), overrides endpoint of captured queries")
+	noapp := fs.Bool("noapp", false, "do not run applications from test case")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s replay [flags] case.yaml...\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return true
+	}
+
+	failed := false
+	for _, path := range fs.Args() {
+		if err := loadConfig(path); err != nil {
+			logger.Error("failed to read test case", zap.String("file", path), zap.Error(err))
+			failed = true
+			continue
+		}
+		if cfg.Test == nil {
+			logger.Error("test case has no queries", zap.String("file", path))
+			failed = true
+			continue
+		}
+
+		httpServers, wg := startListeners(logger)
+		if replayCase(logger, path, *endpoint, *noapp) {
+			failed = true
+		}
+		shutdownListeners(httpServers)
+		wg.Wait()
+	}
+
+	return failed
+}
+
+func replayCase(logger *zap.Logger, path, endpoint string, noapp bool) bool {
+	runningApps := make(map[string]*runner)
+	if !noapp {
+		runningApps = startApps(logger)
+	}
+
+	failed := false
+	for _, t := range cfg.Test.Queries {
+		if endpoint != "" {
+			t.Endpoint = endpoint
+		}
+		failures := doTest(logger, &t, true)
+		if len(failures) == 0 {
+			fmt.Printf("PASS %s %s\n", path, t.URL)
+			continue
+		}
+		failed = true
+		fmt.Printf("FAIL %s %s\n", path, t.URL)
+		for _, err := range failures {
+			fmt.Println(err.Error())
+		}
+	}
+
+	for _, v := range runningApps {
+		v.Finish()
+	}
+
+	return failed
+}
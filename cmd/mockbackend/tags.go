@@ -46,6 +46,7 @@ func (cfg *listener) tagsAutocompleteHandler(wr http.ResponseWriter, req *http.R
 	returnCode := http.StatusOK
 	var tags []string
 	if response, ok := cfg.Expressions[url]; ok {
+		cfg.injectFaults(req, url)
 		if response.ReplyDelayMS > 0 {
 			delay := time.Duration(response.ReplyDelayMS) * time.Millisecond
 			time.Sleep(delay)
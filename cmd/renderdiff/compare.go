@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/go-graphite/carbonapi/pkg/parser"
+)
+
+// series is a series in graphite's json render format, null values are nil
+type series struct {
+	Target     string            `json:"target"`
+	Datapoints [][2]*float64     `json:"datapoints"`
+	Tags       map[string]string `json:"tags,omitempty"`
+}
+
+// response is the result of the render request to one of the servers
+type response struct {
+	Target string   `json:"target"`
+	Code   int      `json:"code"`
+	Series []series `json:"series,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+type tolerance struct {
+	abs float64
+	rel float64
+}
+
+func (t tolerance) equal(v1, v2 *float64) bool {
+	if v1 == nil || v2 == nil {
+		return v1 == nil && v2 == nil
+	}
+	a, b := *v1, *v2
+	if a == b || (math.IsNaN(a) && math.IsNaN(b)) {
+		return true
+	}
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return false
+	}
+	diff := math.Abs(a - b)
+	return diff <= t.abs || diff <= t.rel*math.Max(math.Abs(a), math.Abs(b))
+}
+
+func formatValue(v *float64) string {
+	if v == nil {
+		return "null"
+	}
+	return fmt.Sprintf("%v", *v)
+}
+
+// compareResponses returns incompatibilities of carbonapi's response with graphite-web's one
+func compareResponses(capi, grWeb *response, tol tolerance) []string {
+	if capi.Code != grWeb.Code {
+		return []string{fmt.Sprintf("http code mismatch: got %v (%v), should be %v (%v)", capi.Code, capi.Error, grWeb.Code, grWeb.Error)}
+	}
+	if grWeb.Code != 200 {
+		return nil
+	}
+
+	var incompatibilities []string
+	if len(capi.Series) != len(grWeb.Series) {
+		incompatibilities = append(incompatibilities, fmt.Sprintf("amount of series mismatch: got %v, should be %v", len(capi.Series), len(grWeb.Series)))
+	}
+
+	capiNames := seriesNames(capi.Series)
+	grWebNames := seriesNames(grWeb.Series)
+	if !reflect.DeepEqual(capiNames, grWebNames) {
+		if sorted(capiNames) == sorted(grWebNames) {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("order of series mismatch: got %v, should be %v", capiNames, grWebNames))
+		} else {
+			incompatibilities = append(incompatibilities, fmt.Sprintf("series names mismatch: got %v, should be %v", capiNames, grWebNames))
+		}
+	}
+
+	// series are matched by name if names are unique, so order mismatch is reported only once
+	capiByName := make(map[string]*series, len(capi.Series))
+	for i := range capi.Series {
+		capiByName[capi.Series[i].Target] = &capi.Series[i]
+	}
+	byName := len(capiByName) == len(capi.Series)
+	for i := range grWeb.Series {
+		s2 := &grWeb.Series[i]
+		var s1 *series
+		if byName {
+			s1 = capiByName[s2.Target]
+		} else if i < len(capi.Series) {
+			s1 = &capi.Series[i]
+		}
+		if s1 == nil {
+			continue
+		}
+		incompatibilities = append(incompatibilities, compareSeries(s1, s2, tol)...)
+	}
+
+	return incompatibilities
+}
+
+func compareSeries(s1, s2 *series, tol tolerance) []string {
+	var incompatibilities []string
+	if !reflect.DeepEqual(normalizeTags(s1.Tags), normalizeTags(s2.Tags)) {
+		incompatibilities = append(incompatibilities, fmt.Sprintf("%v: tags mismatch: got %v, should be %v", s2.Target, s1.Tags, s2.Tags))
+	}
+	if len(s1.Datapoints) != len(s2.Datapoints) {
+		return append(incompatibilities, fmt.Sprintf("%v: amount of datapoints mismatch: got %v, should be %v", s2.Target, len(s1.Datapoints), len(s2.Datapoints)))
+	}
+	for i := range s2.Datapoints {
+		if !tol.equal(s1.Datapoints[i][1], s2.Datapoints[i][1]) {
+			return append(incompatibilities, fmt.Sprintf("%v: timestamp of datapoint %v mismatch: got %v, should be %v",
+				s2.Target, i, formatValue(s1.Datapoints[i][1]), formatValue(s2.Datapoints[i][1])))
+		}
+		if !tol.equal(s1.Datapoints[i][0], s2.Datapoints[i][0]) {
+			// the first mismatch is enough to find the problem
+			return append(incompatibilities, fmt.Sprintf("%v: value at %v mismatch: got %v, should be %v",
+				s2.Target, formatValue(s2.Datapoints[i][1]), formatValue(s1.Datapoints[i][0]), formatValue(s2.Datapoints[i][0])))
+		}
+	}
+	return incompatibilities
+}
+
+func seriesNames(s []series) []string {
+	names := make([]string, 0, len(s))
+	for i := range s {
+		names = append(names, s[i].Target)
+	}
+	return names
+}
+
+func sorted(names []string) string {
+	names = append([]string(nil), names...)
+	sort.Strings(names)
+	return fmt.Sprint(names)
+}
+
+func normalizeTags(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	return tags
+}
+
+// targetFunctions returns names of functions used in the target
+func targetFunctions(target string) []string {
+	e, _, err := parser.ParseExpr(target)
+	if err != nil {
+		return nil
+	}
+	seen := make(map[string]struct{})
+	var walk func(e parser.Expr)
+	walk = func(e parser.Expr) {
+		if !e.IsFunc() {
+			return
+		}
+		seen[e.Target()] = struct{}{}
+		for _, arg := range e.Args() {
+			walk(arg)
+		}
+		for _, arg := range e.NamedArgs() {
+			walk(arg)
+		}
+	}
+	walk(e)
+
+	functions := make([]string, 0, len(seen))
+	for f := range seen {
+		functions = append(functions, f)
+	}
+	sort.Strings(functions)
+	return functions
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func points(values ...float64) [][2]*float64 {
+	res := make([][2]*float64, 0, len(values))
+	for i := range values {
+		ts := float64(60 * (i + 1))
+		var v *float64
+		if !math.IsNaN(values[i]) {
+			v = &values[i]
+		}
+		res = append(res, [2]*float64{v, &ts})
+	}
+	return res
+}
+
+func TestCompareResponses(t *testing.T) {
+	tol := tolerance{abs: 1e-9, rel: 1e-6}
+	nan := math.NaN()
+	grWeb := &response{Code: 200, Series: []series{
+		{Target: "a", Datapoints: points(1, nan, 1.0/3), Tags: map[string]string{"name": "a"}},
+		{Target: "b", Datapoints: points(2, 2, 2), Tags: map[string]string{"name": "b"}},
+	}}
+
+	tests := []struct {
+		name string
+		capi *response
+		want []string
+	}{
+		{
+			name: "equal within tolerance",
+			capi: &response{Code: 200, Series: []series{
+				{Target: "a", Datapoints: points(1, nan, 0.3333333333), Tags: map[string]string{"name": "a"}},
+				{Target: "b", Datapoints: points(2, 2, 2), Tags: map[string]string{"name": "b"}},
+			}},
+		},
+		{
+			name: "different values and tags",
+			capi: &response{Code: 200, Series: []series{
+				{Target: "a", Datapoints: points(1, 0, 0.3333), Tags: map[string]string{"name": "a"}},
+				{Target: "b", Datapoints: points(2, 2, 2)},
+			}},
+			want: []string{
+				"a: value at 120 mismatch: got 0, should be null",
+				"b: tags mismatch: got map[], should be map[name:b]",
+			},
+		},
+		{
+			name: "order of series",
+			capi: &response{Code: 200, Series: []series{
+				{Target: "b", Datapoints: points(2, 2, 2), Tags: map[string]string{"name": "b"}},
+				{Target: "a", Datapoints: points(1, nan, 1.0/3), Tags: map[string]string{"name": "a"}},
+			}},
+			want: []string{"order of series mismatch: got [b a], should be [a b]"},
+		},
+		{
+			name: "missing series and datapoints",
+			capi: &response{Code: 200, Series: []series{
+				{Target: "a", Datapoints: points(1, nan), Tags: map[string]string{"name": "a"}},
+			}},
+			want: []string{
+				"amount of series mismatch: got 1, should be 2",
+				"series names mismatch: got [a], should be [a b]",
+				"a: amount of datapoints mismatch: got 2, should be 3",
+			},
+		},
+		{
+			name: "error",
+			capi: &response{Code: 400, Error: "bad target"},
+			want: []string{"http code mismatch: got 400 (bad target), should be 200 ()"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, compareResponses(tt.capi, grWeb, tol))
+		})
+	}
+}
+
+func TestTargetFunctions(t *testing.T) {
+	assert.Equal(t, []string{"alias", "movingAverage", "sumSeries"}, targetFunctions("alias(sumSeries(movingAverage(a.*, 3), b), 'x')"))
+	assert.Empty(t, targetFunctions("a.b"))
+	assert.Nil(t, targetFunctions("sumSeries(a"))
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// corpus is a list of targets that are rendered by both servers over the same data (e.x. served by mockbackend)
+type corpus struct {
+	From    string   `yaml:"from"`
+	Until   string   `yaml:"until"`
+	Targets []string `yaml:"targets"`
+}
+
+// savedCorpus is graphite-web's output for the corpus, so carbonapi could be checked without running graphite-web
+type savedCorpus struct {
+	Version   string     `json:"version"`
+	Responses []response `json:"responses"`
+}
+
+const maxErrorLength = 200
+
+func render(client *http.Client, baseURL, target, from, until string) *response {
+	params := url.Values{
+		"target":  []string{target},
+		"format":  []string{"json"},
+		"noCache": []string{"1"},
+	}
+	if from != "" {
+		params.Set("from", from)
+	}
+	if until != "" {
+		params.Set("until", until)
+	}
+
+	r := &response{Target: target}
+	res, err := client.Get(baseURL + "/render/?" + params.Encode())
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	defer res.Body.Close()
+
+	r.Code = res.StatusCode
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	if r.Code != http.StatusOK {
+		r.Error = strings.TrimSpace(string(body))
+		if len(r.Error) > maxErrorLength {
+			r.Error = r.Error[:maxErrorLength] + "..."
+		}
+		return r
+	}
+	if err := json.Unmarshal(body, &r.Series); err != nil {
+		r.Error = "failed to parse response: " + err.Error()
+	}
+	return r
+}
+
+func graphiteWebVersion(client *http.Client, baseURL string) string {
+	res, err := client.Get(baseURL + "/version/")
+	if err != nil {
+		log.Fatalf("failed to get version of graphite-web: %v", err)
+	}
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		log.Fatalf("failed to read response body for %v: %v", baseURL, err)
+	}
+	return strings.Trim(string(b), "\n")
+}
+
+func main() {
+	carbonapiURL := flag.String("carbonapi", "http://localhost:8079", "carbonapi base url")
+	graphiteWebURL := flag.String("graphiteweb", "http://localhost:8082", "graphite-web base url")
+	corpusFile := flag.String("corpus", "", "yaml with targets to compare")
+	savedFile := flag.String("graphiteweb-corpus", "", "use graphite-web responses saved with -save instead of querying graphite-web")
+	saveFile := flag.String("save", "", "save graphite-web responses to the file")
+	relTolerance := flag.Float64("tolerance", 1e-6, "relative tolerance for values")
+	absTolerance := flag.Float64("abs-tolerance", 1e-9, "absolute tolerance for values")
+	timeout := flag.Duration("timeout", 30*time.Second, "timeout of a request to carbonapi or graphite-web")
+
+	flag.Parse()
+
+	if *corpusFile == "" {
+		log.Fatal("corpus is required")
+	}
+	b, err := os.ReadFile(*corpusFile)
+	if err != nil {
+		log.Fatalf("failed to read corpus: %v", err)
+	}
+	var c corpus
+	if err := yaml.Unmarshal(b, &c); err != nil {
+		log.Fatalf("failed to parse corpus: %v", err)
+	}
+
+	client := &http.Client{Timeout: *timeout}
+	var saved savedCorpus
+	grWebResponses := make(map[string]*response)
+	if *savedFile != "" {
+		b, err := os.ReadFile(*savedFile)
+		if err != nil {
+			log.Fatalf("failed to read saved graphite-web responses: %v", err)
+		}
+		if err := json.Unmarshal(b, &saved); err != nil {
+			log.Fatalf("failed to parse saved graphite-web responses: %v", err)
+		}
+		for i := range saved.Responses {
+			grWebResponses[saved.Responses[i].Target] = &saved.Responses[i]
+		}
+	} else {
+		saved.Version = graphiteWebVersion(client, *graphiteWebURL)
+		for _, target := range c.Targets {
+			r := render(client, *graphiteWebURL, target, c.From, c.Until)
+			grWebResponses[target] = r
+			saved.Responses = append(saved.Responses, *r)
+		}
+	}
+
+	if *saveFile != "" {
+		b, err := json.MarshalIndent(&saved, "", "  ")
+		if err != nil {
+			log.Fatalf("failed to marshal graphite-web responses: %v", err)
+		}
+		if err := os.WriteFile(*saveFile, b, 0644); err != nil {
+			log.Fatalf("failed to save graphite-web responses: %v", err)
+		}
+	}
+
+	tol := tolerance{abs: *absTolerance, rel: *relTolerance}
+	rep := newReport(saved.Version)
+	for _, target := range c.Targets {
+		grWeb, ok := grWebResponses[target]
+		if !ok {
+			rep.add(target, []string{"no saved graphite-web response"})
+			continue
+		}
+		capi := render(client, *carbonapiURL, target, c.From, c.Until)
+		rep.add(target, compareResponses(capi, grWeb, tol))
+	}
+
+	rep.print(os.Stdout)
+	if len(rep.incompatibilities) > 0 {
+		// report is complete, but CI has to fail
+		os.Exit(1)
+	}
+}
+
+// report collects incompatibilities by target and function
+type report struct {
+	version           string
+	targets           int
+	incompatibilities map[string][]string
+	functions         map[string]*functionStats
+}
+
+type functionStats struct {
+	targets    int
+	compatible int
+}
+
+func newReport(version string) *report {
+	return &report{
+		version:           version,
+		incompatibilities: make(map[string][]string),
+		functions:         make(map[string]*functionStats),
+	}
+}
+
+// add records result for the target, all functions of the target are considered incompatible if it fails
+func (r *report) add(target string, incompatibilities []string) {
+	r.targets++
+	if len(incompatibilities) > 0 {
+		r.incompatibilities[target] = incompatibilities
+	}
+	for _, f := range targetFunctions(target) {
+		s, ok := r.functions[f]
+		if !ok {
+			s = &functionStats{}
+			r.functions[f] = s
+		}
+		s.targets++
+		if len(incompatibilities) == 0 {
+			s.compatible++
+		}
+	}
+}
+
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+func (r *report) print(w io.Writer) {
+	fmt.Fprintf(w, `## Graphite-web %s render compatibility
+Targets: %d, compatible: %d
+
+### Functions
+| Function                 | Targets | Compatible |
+| :------------------------|:------- |:---------- |
+`, r.version, r.targets, r.targets-len(r.incompatibilities))
+
+	functions := make([]string, 0, len(r.functions))
+	for f := range r.functions {
+		functions = append(functions, f)
+	}
+	sort.Strings(functions)
+	for _, f := range functions {
+		s := r.functions[f]
+		compatible := "yes"
+		if s.compatible == 0 {
+			compatible = "no"
+		} else if s.compatible < s.targets {
+			compatible = fmt.Sprintf("%d of %d", s.compatible, s.targets)
+		}
+		fmt.Fprintf(w, "| %v | %d | %v |\n", f, s.targets, compatible)
+	}
+
+	if len(r.incompatibilities) == 0 {
+		return
+	}
+
+	fmt.Fprint(w, `
+### Incompatible targets
+| Target                   | Incompatibilities                              |
+| :------------------------|:---------------------------------------------- |
+`)
+	targets := make([]string, 0, len(r.incompatibilities))
+	for t := range r.incompatibilities {
+		targets = append(targets, t)
+	}
+	sort.Strings(targets)
+	for _, t := range targets {
+		incompatibilities := make([]string, 0, len(r.incompatibilities[t]))
+		for _, i := range r.incompatibilities[t] {
+			incompatibilities = append(incompatibilities, escapeCell(i))
+		}
+		fmt.Fprintf(w, "| `%v` | %v |\n", escapeCell(t), strings.Join(incompatibilities, "<br>"))
+	}
+}
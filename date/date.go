@@ -5,130 +5,351 @@ import (
 	"strconv"
 	"strings"
 	"time"
-
-	"github.com/go-graphite/carbonapi/pkg/parser"
 )
 
 var errBadTime = errors.New("bad time")
+var errBadOffset = errors.New("bad time offset")
 var timeNow = time.Now
 
-// parseTime parses a time and returns hours and minutes
-func parseTime(s string) (hour, minute int, err error) {
+var months = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
 
-	switch s {
-	case "midnight":
-		return 0, 0, nil
-	case "noon":
-		return 12, 0, nil
-	case "teatime":
-		return 16, 0, nil
+// DateParamToEpoch turns a passed string parameter into a unix epoch.
+//
+// It follows graphite-web's parseATTime: unix timestamps, "HH:MM_YYYYMMDD", time and day references
+// ("noon", "midnight", "teatime", "3pm", "12:30am", "today", "yesterday", "tomorrow", "MM/DD/YY", "YYYYMMDD",
+// month names with a day like "jan5", weekday names) and relative offsets ("-1d", "+2h30min", "-1mon"),
+// optionally combined (e.g. "midnight+1d", "noon_tomorrow", "monday-1w"). References are evaluated in qtz
+// (or defaultTimeZone if qtz is empty or unknown), offsets are absolute, as in graphite-web.
+func DateParamToEpoch(s, qtz string, d int64, defaultTimeZone *time.Location) int64 {
+	if s == "" {
+		// return the default if nothing was passed
+		return d
+	}
+
+	tz := defaultTimeZone
+	if qtz != "" {
+		if z, err := time.LoadLocation(qtz); err == nil {
+			tz = z
+		}
+	}
+	if tz == nil {
+		tz = time.Local
 	}
 
-	parts := strings.Split(s, ":")
+	t, err := parseATTime(s, tz, timeNow())
+	if err != nil {
+		return d
+	}
+	return t
+}
+
+func parseATTime(s string, tz *time.Location, now time.Time) (int64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.NewReplacer("_", "", ",", "", " ", "").Replace(s)
+	if s == "" {
+		return 0, errBadTime
+	}
+
+	if isDigits(s) {
+		if !(len(s) == 8 && atoi(s[:4]) > 1900 && atoi(s[4:6]) < 13 && atoi(s[6:]) < 32) {
+			// We got a timestamp so returning it
+			ts, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return 0, errBadTime
+			}
+			return ts, nil
+		}
+		// not a timestamp, but YYYYMMDD
+	} else if strings.Contains(s, ":") && len(s) == 13 {
+		t, err := time.ParseInLocation("15:0420060102", s, time.UTC)
+		if err != nil {
+			return 0, errBadTime
+		}
+		return localize(t, tz).Unix(), nil
+	}
 
-	if len(parts) != 2 {
-		return 0, 0, errBadTime
+	var ref, offset string
+	if i := strings.IndexByte(s, '+'); i != -1 {
+		ref, offset = s[:i], s[i:]
+	} else if i := strings.IndexByte(s, '-'); i != -1 {
+		ref, offset = s[:i], s[i:]
+	} else {
+		ref = s
 	}
 
-	hour, err = strconv.Atoi(parts[0])
+	t, err := parseTimeReference(ref, tz, now)
 	if err != nil {
-		return 0, 0, errBadTime
+		return 0, err
 	}
 
-	minute, err = strconv.Atoi(parts[1])
+	o, err := parseTimeOffset(offset)
 	if err != nil {
-		return 0, 0, errBadTime
+		return 0, err
 	}
 
-	return hour, minute, nil
+	return t.Unix() + o, nil
 }
 
-var TimeFormats = []string{"20060102", "01/02/06"}
+// parseTimeReference parses time of day and day reference. Result is localized in tz.
+func parseTimeReference(ref string, tz *time.Location, now time.Time) (time.Time, error) {
+	now = now.In(tz)
+	if ref == "" || ref == "now" {
+		return now, nil
+	}
 
-// DateParamToEpoch turns a passed string parameter into a unix epoch
-func DateParamToEpoch(s, qtz string, d int64, defaultTimeZone *time.Location) int64 {
+	var err error
+	rawRef := ref
+	hour, minute := 0, 0
 
-	if s == "" {
-		// return the default if nothing was passed
-		return d
+	// time of day reference: HH:MM, optionally followed by am or pm
+	if i := strings.IndexByte(ref, ':'); i > 0 && i < 3 {
+		end := i + 3
+		if end > len(ref) {
+			end = len(ref)
+		}
+		if hour, err = strconv.Atoi(ref[:i]); err != nil {
+			return time.Time{}, errBadTime
+		}
+		if minute, err = strconv.Atoi(ref[i+1 : end]); err != nil {
+			return time.Time{}, errBadTime
+		}
+		ref = ref[end:]
+		if strings.HasPrefix(ref, "am") {
+			ref = ref[2:]
+		} else if strings.HasPrefix(ref, "pm") {
+			hour = (hour + 12) % 24
+			ref = ref[2:]
+		}
 	}
 
-	// relative timestamp
-	if s[0] == '-' {
-		offset, err := parser.IntervalString(s, -1)
-		if err != nil {
-			return d
+	// Xam or XXam
+	if i := strings.Index(ref, "am"); i > 0 && i < 3 {
+		if hour, err = strconv.Atoi(ref[:i]); err != nil {
+			return time.Time{}, errBadTime
 		}
-
-		return timeNow().Add(time.Duration(offset) * time.Second).Unix()
+		ref = ref[i+2:]
 	}
 
-	switch s {
-	case "now":
-		return timeNow().Unix()
-	case "midnight", "noon", "teatime":
-		yy, mm, dd := timeNow().Date()
-		hh, min, _ := parseTime(s) // error ignored, we know it's valid
-		dt := time.Date(yy, mm, dd, hh, min, 0, 0, defaultTimeZone)
-		return dt.Unix()
+	// Xpm or XXpm
+	if i := strings.Index(ref, "pm"); i > 0 && i < 3 {
+		if hour, err = strconv.Atoi(ref[:i]); err != nil {
+			return time.Time{}, errBadTime
+		}
+		hour = (hour + 12) % 24
+		ref = ref[i+2:]
 	}
 
-	sint, err := strconv.Atoi(s)
-	// need to check that len(s) != 8 to avoid turning 20060102 into seconds
-	if err == nil && len(s) != 8 {
-		return int64(sint) // We got a timestamp so returning it
+	switch {
+	case strings.HasPrefix(ref, "noon"):
+		hour, minute = 12, 0
+		ref = ref[4:]
+	case strings.HasPrefix(ref, "midnight"):
+		hour, minute = 0, 0
+		ref = ref[8:]
+	case strings.HasPrefix(ref, "teatime"):
+		hour, minute = 16, 0
+		ref = ref[7:]
 	}
 
-	s = strings.Replace(s, "_", " ", 1) // Go can't parse _ in date strings
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return time.Time{}, errBadTime
+	}
 
-	var ts, ds string
-	split := strings.Fields(s)
+	// wall clock date in tz, the same as naive datetime in graphite-web
+	yy, mm, dd := now.Date()
+	refDate := time.Date(yy, mm, dd, hour, minute, 0, 0, time.UTC)
 
+	// day reference
 	switch {
-	case len(split) == 1:
-		ds = s
-	case len(split) == 2:
-		ts, ds = split[0], split[1]
-	case len(split) > 2:
-		return d
+	case ref == "yesterday":
+		refDate = refDate.AddDate(0, 0, -1)
+	case ref == "today":
+	case ref == "tomorrow":
+		refDate = refDate.AddDate(0, 0, 1)
+	case strings.Count(ref, "/") == 2:
+		// MM/DD/YY[YY]
+		parts := strings.Split(ref, "/")
+		m, err1 := strconv.Atoi(parts[0])
+		d, err2 := strconv.Atoi(parts[1])
+		y, err3 := strconv.Atoi(parts[2])
+		if err1 != nil || err2 != nil || err3 != nil {
+			return time.Time{}, errBadTime
+		}
+		if y < 1900 {
+			y += 1900
+		}
+		if y < 1970 {
+			y += 100
+		}
+		if refDate, err = naiveDate(y, m, d, hour, minute); err != nil {
+			return time.Time{}, err
+		}
+	case len(ref) == 8 && isDigits(ref):
+		// YYYYMMDD
+		if refDate, err = naiveDate(atoi(ref[:4]), atoi(ref[4:6]), atoi(ref[6:]), hour, minute); err != nil {
+			return time.Time{}, err
+		}
+	case len(ref) >= 3 && indexOf(months, ref[:3]) != -1:
+		// month name and day of month
+		var d int
+		if len(ref) >= 2 && isDigits(ref[len(ref)-2:]) {
+			d = atoi(ref[len(ref)-2:])
+		} else if isDigits(ref[len(ref)-1:]) {
+			d = atoi(ref[len(ref)-1:])
+		} else {
+			return time.Time{}, errBadTime
+		}
+		if refDate, err = naiveDate(refDate.Year(), indexOf(months, ref[:3])+1, d, hour, minute); err != nil {
+			return time.Time{}, err
+		}
+	case len(ref) >= 3 && indexOf(weekdays, ref[:3]) != -1:
+		// day of week, the latest one including today
+		dayOffset := int(refDate.Weekday()) - indexOf(weekdays, ref[:3])
+		if dayOffset < 0 {
+			dayOffset += 7
+		}
+		refDate = refDate.AddDate(0, 0, -dayOffset)
+	case ref != "":
+		return time.Time{}, errors.New("unknown day reference: " + rawRef)
 	}
 
-	var tz = defaultTimeZone
-	if qtz != "" {
-		if z, err := time.LoadLocation(qtz); err != nil {
-			tz = z
+	return localize(refDate, tz), nil
+}
+
+// parseTimeOffset returns offset in seconds. Months are 30 days and years are 365 days, as in graphite-web.
+func parseTimeOffset(offset string) (int64, error) {
+	if offset == "" {
+		return 0, nil
+	}
+
+	sign := int64(1)
+	switch offset[0] {
+	case '+':
+		offset = offset[1:]
+	case '-':
+		sign = -1
+		offset = offset[1:]
+	}
+
+	var total int64
+	for offset != "" {
+		i := 0
+		for i < len(offset) && offset[i] >= '0' && offset[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return 0, errBadOffset
+		}
+		num, err := strconv.ParseInt(offset[:i], 10, 64)
+		if err != nil {
+			return 0, errBadOffset
 		}
+		offset = offset[i:]
+
+		i = 0
+		for i < len(offset) && offset[i] >= 'a' && offset[i] <= 'z' {
+			i++
+		}
+		unit := offset[:i]
+		offset = offset[i:]
+
+		var seconds int64
+		switch {
+		case strings.HasPrefix(unit, "s"):
+			seconds = 1
+		case strings.HasPrefix(unit, "min"):
+			seconds = 60
+		case strings.HasPrefix(unit, "h"):
+			seconds = 60 * 60
+		case strings.HasPrefix(unit, "d"):
+			seconds = 24 * 60 * 60
+		case strings.HasPrefix(unit, "w"):
+			seconds = 7 * 24 * 60 * 60
+		case strings.HasPrefix(unit, "mon"):
+			seconds = 30 * 24 * 60 * 60
+		case strings.HasPrefix(unit, "y"):
+			seconds = 365 * 24 * 60 * 60
+		case unit == "m":
+			// not supported by graphite-web, but was always accepted by carbonapi
+			seconds = 60
+		default:
+			return 0, errBadOffset
+		}
+		total += sign * num * seconds
 	}
 
-	var t time.Time
-dateStringSwitch:
-	switch ds {
-	case "today":
-		t = timeNow()
-		// nothing
-	case "yesterday":
-		t = timeNow().AddDate(0, 0, -1)
-	case "tomorrow":
-		t = timeNow().AddDate(0, 0, 1)
-	default:
-		for _, format := range TimeFormats {
-			t, err = time.ParseInLocation(format, ds, tz)
-			if err == nil {
-				break dateStringSwitch
-			}
+	return total, nil
+}
+
+// localize converts wall clock time (stored as UTC) to the time in tz. Ambiguous and non-existent times
+// during DST transitions are resolved like pytz does with is_dst=False (graphite-web default): standard
+// time is preferred for ambiguous ones and the offset before the transition is used for non-existent ones.
+func localize(naive time.Time, tz *time.Location) time.Time {
+	wall := naive.Unix()
+
+	var candidates []time.Time
+	seen := make(map[int]bool, 2)
+	for _, probe := range []int64{wall - 86400, wall, wall + 86400} {
+		_, offset := time.Unix(probe, 0).In(tz).Zone()
+		if seen[offset] {
+			continue
 		}
+		seen[offset] = true
+		t := time.Unix(wall-int64(offset), 0).In(tz)
+		if _, o := t.Zone(); o == offset {
+			candidates = append(candidates, t)
+		}
+	}
 
-		return d
+	switch len(candidates) {
+	case 0:
+		_, offset := time.Unix(wall-86400, 0).In(tz).Zone()
+		return time.Unix(wall-int64(offset), 0).In(tz)
+	case 1:
+		return candidates[0]
+	}
+
+	for _, t := range candidates {
+		if !t.IsDST() {
+			return t
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+// naiveDate returns wall clock time (stored as UTC), date must be valid
+func naiveDate(year, month, day, hour, minute int) (time.Time, error) {
+	t := time.Date(year, time.Month(month), day, hour, minute, 0, 0, time.UTC)
+	if month < 1 || month > 12 || day < 1 || t.Day() != day {
+		return time.Time{}, errBadTime
 	}
+	return t, nil
+}
 
-	var hour, minute int
-	if ts != "" {
-		hour, minute, _ = parseTime(ts)
-		// defaults to hour=0, minute=0 on error, which is midnight, which is fine for now
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
 	}
+	return true
+}
 
-	yy, mm, dd := t.Date()
-	t = time.Date(yy, mm, dd, hour, minute, 0, 0, defaultTimeZone)
+// atoi is used only for strings that are known to be digits
+func atoi(s string) int {
+	v, _ := strconv.Atoi(s)
+	return v
+}
 
-	return t.Unix()
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
 }
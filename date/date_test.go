@@ -47,3 +47,67 @@ func TestDateParamToEpoch(t *testing.T) {
 		}
 	}
 }
+
+func TestDateParamToEpochGraphiteFixtures(t *testing.T) {
+	defer func() { timeNow = time.Now }()
+
+	const def = -1
+	for _, tt := range attimeFixtures {
+		now := tt.now
+		timeNow = func() time.Time {
+			return time.Unix(now, 0)
+		}
+
+		want := tt.want
+		if !tt.ok {
+			want = def
+		}
+		got := DateParamToEpoch(tt.input, tt.tz, def, time.UTC)
+		if got != want {
+			t.Errorf("DateParamToEpoch(%q, %q) at %d = %d (%s), want %d (%s)", tt.input, tt.tz, tt.now, got, time.Unix(got, 0).UTC(), want, time.Unix(want, 0).UTC())
+		}
+	}
+}
+
+func TestDateParamToEpochTimeZone(t *testing.T) {
+	defer func() { timeNow = time.Now }()
+
+	// 2016-11-06 07:00 UTC, an hour after US DST end
+	timeNow = func() time.Time {
+		return time.Unix(1478415600, 0)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		input string
+		qtz   string
+		tz    *time.Location
+		want  int64
+	}{
+		// default time zone is used without tz
+		{"midnight", "", newYork, 1478404800},
+		// tz overrides default time zone
+		{"midnight", "America/New_York", time.UTC, 1478404800},
+		{"midnight", "UTC", newYork, 1478390400},
+		// unknown tz falls back to default time zone
+		{"midnight", "Unknown/Zone", newYork, 1478404800},
+		// 1:30 happens twice, standard time is preferred like in graphite-web
+		{"01:30_20161106", "America/New_York", time.UTC, 1478413800},
+		// midnight+1d is 24 hours later, even if day is 25 hours long
+		{"midnight+1d", "America/New_York", time.UTC, 1478491200},
+		{"midnight tomorrow", "America/New_York", time.UTC, 1478494800},
+		// m is not supported by graphite-web, but carbonapi always accepted it as minutes
+		{"-5m", "", time.UTC, 1478415300},
+		{"", "", time.UTC, 42},
+	}
+
+	for _, tt := range tests {
+		got := DateParamToEpoch(tt.input, tt.qtz, 42, tt.tz)
+		if got != tt.want {
+			t.Errorf("DateParamToEpoch(%q, %q)=%v, want %v", tt.input, tt.qtz, got, tt.want)
+		}
+	}
+}
@@ -14,8 +14,8 @@ import (
 
 var ErrInvalidConsolidationFunc = merry.New("Invalid Consolidation Function")
 
-// ConsolidationToFunc contains a map of graphite-compatible consolidation functions definitions to actual functions that can do aggregation
-// TODO(civil): take into account xFilesFactor
+// ConsolidationToFunc contains a map of graphite-compatible consolidation functions definitions to actual functions that can do aggregation.
+// Functions don't know about xFilesFactor, it should be checked with XFilesFactorValues before calling them.
 var ConsolidationToFunc = map[string]func([]float64) float64{
 	"average":  AggMean,
 	"avg_zero": AggMeanZero,
@@ -129,7 +129,7 @@ func summarizeToAggregate(f string) func([]float64) float64 {
 }
 
 // SummarizeValues summarizes values
-func SummarizeValues(f string, values []float64, XFilesFactor float32) float64 {
+func SummarizeValues(f string, values []float64, xFilesFactor float32) float64 {
 	rv := 0.0
 	total := 0
 
@@ -261,15 +261,31 @@ func SummarizeValues(f string, values []float64, XFilesFactor float32) float64 {
 		}
 	}
 
-	if total == 0 {
+	if !XFilesFactor(total, len(values), xFilesFactor) {
 		return math.NaN()
 	}
 
-	if float32(total)/float32(len(values)) < XFilesFactor {
-		return math.NaN()
+	return rv
+}
+
+// XFilesFactor reports if there are enough non-null values to consolidate them, same as graphite-web's xff:
+// at least one of values should be non-null and nonNull/total ratio should be not less than xFilesFactor
+func XFilesFactor(nonNull, total int, xFilesFactor float32) bool {
+	if nonNull <= 0 || total <= 0 {
+		return false
 	}
+	return float32(nonNull)/float32(total) >= xFilesFactor
+}
 
-	return rv
+// XFilesFactorValues checks XFilesFactor for values, NaNs are treated as nulls
+func XFilesFactorValues(values []float64, xFilesFactor float32) bool {
+	nonNull := 0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			nonNull++
+		}
+	}
+	return XFilesFactor(nonNull, len(values), xFilesFactor)
 }
 
 var consolidateFuncs []string
@@ -192,3 +192,28 @@ func TestCheckValidConsolidationFunc(t *testing.T) {
 	}
 
 }
+
+func TestXFilesFactorValues(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name         string
+		values       []float64
+		xFilesFactor float32
+		expected     bool
+	}{
+		{"no values", []float64{}, 0, false},
+		{"only nulls", []float64{nan, nan}, 0, false},
+		{"single value", []float64{nan, 1, nan}, 0, true},
+		{"enough values", []float64{nan, 1, 2, nan}, 0.5, true},
+		{"not enough values", []float64{nan, 1, nan, nan}, 0.5, false},
+		{"all values required", []float64{1, 2, 3}, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := XFilesFactorValues(tt.values, tt.xFilesFactor); got != tt.expected {
+				t.Errorf("XFilesFactorValues(%v, %v) = %v, want %v", tt.values, tt.xFilesFactor, got, tt.expected)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/go-graphite/carbonapi/pkg/parser"
 	utilctx "github.com/go-graphite/carbonapi/util/ctx"
 	zipper "github.com/go-graphite/carbonapi/zipper/interfaces"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
 )
 
 var ErrZipperNotInit = errors.New("zipper not initialized")
@@ -40,15 +41,48 @@ func (eval Evaluator) Fetch(ctx context.Context, exprs []parser.Expr, from, unti
 	// values related to this particular `target=`
 	targetValues := make(map[parser.MetricRequest][]*types.MetricData)
 
+	xFilesFactor, hasXFilesFactor := utilctx.GetXFilesFactor(ctx)
+	highPrecision := false
+	for _, exp := range exprs {
+		if exp.TimeUnit() == parser.Milliseconds {
+			highPrecision = true
+		}
+	}
+
+	var pushdowns map[string]*pushdown
+	pushedDown := utilctx.GetPushedDown(ctx)
+	// functions are evaluated by backends in seconds, so they are not pushed down for millisecond requests
+	if pd, ok := eval.zipper.(zipper.FunctionPushdown); ok && eval.passFunctionsToBackend && !highPrecision && pushedDown != nil {
+		pushdowns = findPushdowns(ctx, pd, exprs, from, until)
+	}
+	for pathExpression, p := range pushdowns {
+		metricRequestCache[pathExpression] = p.levels[0]
+		// avoid multiple requests in a http request, E.g render?target=sumSeries(a.*)&target=sumSeries(a.*)
+		if top := p.levels[len(p.levels)-1]; values[top] != nil {
+			targetValues[top] = nil
+			continue
+		}
+		if _, ok := values[p.levels[0]]; ok {
+			targetValues[p.levels[0]] = nil
+			continue
+		}
+		targetValues[p.levels[0]] = nil
+		multiFetchRequest.Metrics = append(multiFetchRequest.Metrics, p.fetchRequest(maxDataPoints))
+	}
+
 	haveFallbackSeries := false
 	for _, exp := range exprs {
 		for _, m := range exp.Metrics(from, until, fconfig.Config.DefaultTimeZone) {
+			if _, ok := pushdowns[m.Metric]; ok {
+				continue
+			}
 			fetchRequest := pb.FetchRequest{
-				Name:           m.Metric,
-				PathExpression: m.Metric,
-				StartTime:      m.From,
-				StopTime:       m.Until,
-				MaxDataPoints:  maxDataPoints,
+				Name:                    m.Metric,
+				PathExpression:          m.Metric,
+				StartTime:               m.From,
+				StopTime:                m.Until,
+				MaxDataPoints:           maxDataPoints,
+				HighPrecisionTimestamps: exp.TimeUnit() == parser.Milliseconds,
 			}
 			metricRequest := parser.MetricRequest{
 				Metric: fetchRequest.PathExpression,
@@ -101,11 +135,29 @@ func (eval Evaluator) Fetch(ctx context.Context, exprs []parser.Expr, from, unti
 			return nil, err
 		}
 		for _, metric := range metrics {
+			if highPrecision {
+				zipperTypes.FetchResponseToHighPrecision(&metric.FetchResponse)
+			}
+			if hasXFilesFactor {
+				// xFilesFactor of the request overrides one reported by backend, as graphite-web does
+				metric.XFilesFactor = xFilesFactor
+			}
 			metricRequest := metricRequestCache[metric.PathExpression]
+			p, pushed := pushdowns[metric.PathExpression]
+			if pushed {
+				// backend might apply only some of functions or ignore them at all, rest is evaluated by us
+				metricRequest = p.level(metric.AppliedFunctions)
+			}
 			if metric.RequestStartTime != 0 && metric.RequestStopTime != 0 {
 				metricRequest.From = metric.RequestStartTime
 				metricRequest.Until = metric.RequestStopTime
 			}
+			if pushed && len(metric.AppliedFunctions) > 0 {
+				if _, ok := targetValues[metricRequest]; !ok {
+					targetValues[metricRequest] = nil
+					pushedDown.Add(metricRequest.Metric)
+				}
+			}
 			data, ok := values[metricRequest]
 			if !ok {
 				data = make([]*types.MetricData, 0, 1)
@@ -132,11 +184,13 @@ func (eval Evaluator) Eval(ctx context.Context, exp parser.Expr, from, until int
 		return nil, err
 	}
 	if rewritten {
+		unit := exp.TimeUnit()
 		for _, target := range targets {
 			exp, _, err = parser.ParseExpr(target)
 			if err != nil {
 				return nil, err
 			}
+			exp.SetTimeUnit(unit)
 			targetValues, err := eval.Fetch(ctx, []parser.Expr{exp}, from, until, values)
 			if err != nil {
 				return nil, err
@@ -179,6 +233,13 @@ func EvalExpr(ctx context.Context, eval interfaces.Evaluator, e parser.Expr, fro
 	}
 	// evaluate the function
 
+	// subtree might be already evaluated by backend, see findPushdowns
+	if pushedDown := utilctx.GetPushedDown(ctx); pushedDown != nil && pushedDown.Len() > 0 {
+		if v := values[parser.MetricRequest{Metric: e.ToString(), From: from, Until: until}]; v != nil {
+			return v, nil
+		}
+	}
+
 	// all functions have arguments -- check we do too
 	if e.ArgsLen() == 0 {
 		err := merry.WithMessagef(parser.ErrMissingArgument, "target=%s: %s", e.Target(), parser.ErrMissingArgument)
@@ -190,6 +251,13 @@ func EvalExpr(ctx context.Context, eval interfaces.Evaluator, e parser.Expr, fro
 	metadata.FunctionMD.RUnlock()
 	if ok {
 		v, err := f.Do(ctx, eval, e, from, until, values)
+		if err == nil {
+			// values are charged before they are allocated, helpers that can't return an error leave exceeded limit in
+			// accountant, so request over the limit is aborted as soon as the function is done
+			if err = utilctx.GetMemoryAccountant(ctx).Err(); err != nil {
+				return nil, merry.Wrap(err)
+			}
+		}
 		if err != nil {
 			err = merry.WithMessagef(err, "function=%s: %s", e.Target(), err.Error())
 			if merry.Is(
@@ -203,6 +271,7 @@ func EvalExpr(ctx context.Context, eval interfaces.Evaluator, e parser.Expr, fro
 				parser.ErrMissingTimeseries,
 				parser.ErrMissingValues,
 				parser.ErrUnknownTimeUnits,
+				parser.ErrSubSecondInterval,
 				parser.ErrInvalidArg,
 			) {
 				err = merry.WithHTTPCode(err, 400)
@@ -250,23 +319,26 @@ func FetchAndEvalExp(ctx context.Context, eval interfaces.Evaluator, e parser.Ex
 	return res, nil
 }
 
-func FetchAndEvalExprs(ctx context.Context, eval interfaces.Evaluator, exprs []parser.Expr, from, until int64, values map[parser.MetricRequest][]*types.MetricData) ([]*types.MetricData, map[string]merry.Error) {
+// FetchAndEvalExprs fetches series of all expressions with a single request and evaluates them. Results and errors are
+// returned in order of exprs, error of the fetch is returned for each of them.
+func FetchAndEvalExprs(ctx context.Context, eval interfaces.Evaluator, exprs []parser.Expr, from, until int64, values map[parser.MetricRequest][]*types.MetricData) ([][]*types.MetricData, []merry.Error) {
+	res := make([][]*types.MetricData, len(exprs))
+	errors := make([]merry.Error, len(exprs))
+
 	targetValues, err := eval.Fetch(ctx, exprs, from, until, values)
 	if err != nil {
-		return nil, map[string]merry.Error{"*": merry.Wrap(err)}
+		for i := range errors {
+			errors[i] = merry.Wrap(err)
+		}
+		return res, errors
 	}
 
-	res := make([]*types.MetricData, 0, len(exprs))
-	var errors map[string]merry.Error
-	for _, exp := range exprs {
+	for i, exp := range exprs {
 		evaluationResult, err := eval.Eval(ctx, exp, from, until, targetValues)
 		if err != nil {
-			if errors == nil {
-				errors = make(map[string]merry.Error)
-			}
-			errors[exp.Target()] = merry.Wrap(err)
+			errors[i] = merry.Wrap(err)
 		}
-		res = append(res, evaluationResult...)
+		res[i] = evaluationResult
 	}
 
 	for mReq := range values {
@@ -8,7 +8,9 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/ansel1/merry"
 	pb "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/go-graphite/carbonapi/expr/functions"
 	"github.com/go-graphite/carbonapi/expr/helper"
@@ -17,6 +19,7 @@ import (
 	"github.com/go-graphite/carbonapi/pkg/parser"
 	th "github.com/go-graphite/carbonapi/tests"
 	"github.com/go-graphite/carbonapi/tests/compare"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
 )
 
 func init() {
@@ -52,25 +55,34 @@ func TestAlignToBucketSize(t *testing.T) {
 		inputStart int64
 		inputStop  int64
 		bucketSize int64
+		unit       int64
 		wantStart  int64
 		wantStop   int64
 	}{
 		{
-			13, 18, 5,
+			13, 18, 5, parser.Seconds,
 			10, 20,
 		},
 		{
-			13, 17, 5,
+			13, 17, 5, parser.Seconds,
 			10, 20,
 		},
 		{
-			13, 19, 5,
+			13, 19, 5, parser.Seconds,
 			10, 20,
 		},
+		{
+			1300, 1750, 250, parser.Milliseconds,
+			1250, 1750,
+		},
+		{
+			13100, 17900, 5000, parser.Milliseconds,
+			10000, 20000,
+		},
 	}
 
 	for _, test := range tests {
-		start, stop := helper.AlignToBucketSize(test.inputStart, test.inputStop, test.bucketSize)
+		start, stop := helper.AlignToBucketSize(test.inputStart, test.inputStop, test.bucketSize, test.unit)
 		if start != test.wantStart || stop != test.wantStop {
 			t.Errorf("TestAlignToBucketSize failed!\n%v\ngot start %d stop %d",
 				test,
@@ -86,28 +98,37 @@ func TestAlignToInterval(t *testing.T) {
 		inputStart int64
 		inputStop  int64
 		bucketSize int64
+		unit       int64
 		wantStart  int64
 	}{
 		{
-			91111, 92222, 5,
+			91111, 92222, 5, parser.Seconds,
 			91111,
 		},
 		{
-			91111, 92222, 60,
+			91111, 92222, 60, parser.Seconds,
 			91080,
 		},
 		{
-			91111, 92222, 3600,
+			91111, 92222, 3600, parser.Seconds,
 			90000,
 		},
 		{
-			91111, 92222, 86400,
+			91111, 92222, 86400, parser.Seconds,
 			86400,
 		},
+		{
+			91111500, 92222000, 5000, parser.Milliseconds,
+			91111500,
+		},
+		{
+			91111500, 92222000, 3600000, parser.Milliseconds,
+			90000000,
+		},
 	}
 
 	for _, test := range tests {
-		start := helper.AlignStartToInterval(test.inputStart, test.inputStop, test.bucketSize)
+		start := helper.AlignStartToInterval(test.inputStart, test.inputStop, test.bucketSize, test.unit)
 		if start != test.wantStart {
 			t.Errorf("TestAlignToInterval failed!\n%v\ngot start %d",
 				test,
@@ -550,3 +571,488 @@ func TestEvalCustomFromUntil(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalMemoryLimit(t *testing.T) {
+	accountant := utilctx.NewMemoryAccountant(6 * 8)
+	ctx := utilctx.SetMemoryAccountant(context.Background(), accountant)
+	m := map[parser.MetricRequest][]*types.MetricData{
+		{Metric: "metric1", From: 0, Until: 4}: {
+			types.MakeMetricData("metric1", []float64{1, 2, 3, 4}, 1, 0).SetMemoryAccountant(accountant),
+		},
+	}
+
+	eval, err := NewEvaluator(nil, th.NewTestZipper(nil), false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	exp, _, err := parser.ParseExpr("scale(metric1,2)")
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = EvalExpr(ctx, eval, exp, 0, 4, m)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4*8), accountant.Peak())
+
+	exp, _, err = parser.ParseExpr("offset(scale(metric1,2),1)")
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = EvalExpr(ctx, eval, exp, 0, 4, m)
+	assert.True(t, merry.Is(err, utilctx.ErrMemoryLimitExceeded), "unexpected error %v", err)
+}
+
+func TestMetrics(t *testing.T) {
+	tests := []struct {
+		target   string
+		from     int64
+		until    int64
+		expected []parser.MetricRequest
+		tz       string
+	}{
+		{
+			"hitcount(metric1, '1h', true)",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410343200,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"hitcount(metric1, '1h', alignToInterval=True)",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410343200,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"hitcount(metric1, '1h')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410346740,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"hitcount(timeShift(metric1, '-1h'),'1h', true)",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410343200,
+					Until:  1410346865,
+				},
+				{
+					Metric: "metric1",
+					From:   1410339600,
+					Until:  1410343265,
+				},
+			},
+			"UTC",
+		},
+		{
+			"holtWintersAberration(metric1)",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410346740,
+					Until:  1410346865,
+				},
+				{
+					Metric: "metric1",
+					From:   1409741940,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"holtWintersAberration(metric1,3,'6d')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410346740,
+					Until:  1410346865,
+				},
+				{
+					Metric: "metric1",
+					From:   1409828340,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"holtWintersConfidenceBands(metric1)",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1409741940,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"holtWintersConfidenceBands(metric1, 4, '1d')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410260340,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"holtWintersConfidenceBands(metric1, 4, bootstrapInterval='3d')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410087540,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"stlDecompose(metric1)",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410001140,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"anomalies(metric1,'zscore',3,'1h',seasons=2)",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410339540,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"holtWintersForecast(metric1,'1d')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410260340,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"smartSummarize(metric1, '1h', 'sum', 'seconds')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410346740,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"smartSummarize(metric1, '1h', 'sum', '1minutes')",
+			1410346745,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410346740,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"smartSummarize(metric1, '1h', 'sum', 'hours')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410343200,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"smartSummarize(metric1, '1h', 'sum', 'days')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410307200,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"smartSummarize(metric1, '1hours','sum','weeks5')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1409875200,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"smartSummarize(metric1, '1hours','sum','months')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1409529600,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"smartSummarize(metric1, '1hours','sum','y')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1388534400,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"timeShift(metric1, '1h')",
+			323869020,
+			323872620,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   323869020,
+					Until:  323872620,
+				},
+				{
+					Metric: "metric1",
+					From:   323865420,
+					Until:  323869020,
+				},
+			},
+			"Europe/Berlin",
+		},
+		{
+			"timeShift(metric1, '1d',true,true)",
+			323869020,
+			323872620,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   323869020,
+					Until:  323872620,
+				},
+				{
+					Metric: "metric1",
+					From:   323786220,
+					Until:  323789820,
+				},
+			},
+			"Europe/Berlin",
+		},
+		{
+			"movingAverage(metric1,'5min')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410346440,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"movingWindow(metric1,'5min','median')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410346440,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"movingMedian(metric1,5)",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410346740,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"exponentialMovingAverage(metric1,'1min')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410346680,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"consolidateBy(metric1,'max')",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric:            "metric1",
+					ConsolidationFunc: "max",
+					From:              1410346740,
+					Until:             1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"transformNull(metric1,0,referenceSeries=metric2)",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410346740,
+					Until:  1410346865,
+				},
+				{
+					Metric: "metric2",
+					From:   1410346740,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"timeStack(metric1,'1d',1,3)",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410260340,
+					Until:  1410260465,
+				},
+				{
+					Metric: "metric1",
+					From:   1410173940,
+					Until:  1410174065,
+				},
+			},
+			"UTC",
+		},
+		{
+			"seasonalZScore(metric1,'1h',3)",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410335940,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+		{
+			"sumSeries(metric1,metric2)",
+			1410346740,
+			1410346865,
+			[]parser.MetricRequest{
+				{
+					Metric: "metric1",
+					From:   1410346740,
+					Until:  1410346865,
+				},
+				{
+					Metric: "metric2",
+					From:   1410346740,
+					Until:  1410346865,
+				},
+			},
+			"UTC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			exp, _, err := parser.ParseExpr(tt.target)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %+v", tt.target, err)
+			}
+			loc, err := time.LoadLocation(tt.tz)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := exp.Metrics(tt.from, tt.until, loc)
+			assert.Equal(t, tt.expected, r)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/go-graphite/carbonapi/expr/interfaces"
 	"github.com/go-graphite/carbonapi/expr/types"
 	"github.com/go-graphite/carbonapi/pkg/parser"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
 )
 
 type aggregate struct{}
@@ -56,7 +57,11 @@ func (f *aggregate) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 			}
 			callback = strings.Replace(e.Target(), "Series", "", 1)
 			isAggregateFunc = false
-			xFilesFactor = -1 // xFilesFactor is not used by the ...Series functions
+			// ...Series functions use xFilesFactor of the request, as graphite-web does
+			xFilesFactor = -1
+			if xff, ok := utilctx.GetXFilesFactor(ctx); ok {
+				xFilesFactor = float64(xff)
+			}
 		}
 	} else {
 		args, err = helper.GetSeriesArg(ctx, eval, e.Arg(0), from, until, values)
@@ -13,6 +13,7 @@ import (
 	"github.com/go-graphite/carbonapi/pkg/parser"
 	th "github.com/go-graphite/carbonapi/tests"
 	"github.com/go-graphite/carbonapi/tests/compare"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
 )
 
 var (
@@ -507,6 +508,48 @@ func TestAverageSeriesAlign(t *testing.T) {
 
 }
 
+func TestSumSeriesRequestXFilesFactor(t *testing.T) {
+	exp, _, err := parser.ParseExpr("sumSeries(metric1,metric2,metric3)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[parser.MetricRequest][]*types.MetricData{
+		{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 1, math.NaN()}, 1, 1)},
+		{Metric: "metric2", From: 0, Until: 1}: {types.MakeMetricData("metric2", []float64{2, math.NaN(), math.NaN()}, 1, 1)},
+		{Metric: "metric3", From: 0, Until: 1}: {types.MakeMetricData("metric3", []float64{3, math.NaN(), math.NaN()}, 1, 1)},
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []float64
+	}{
+		{
+			name: "without xFilesFactor",
+			ctx:  context.Background(),
+			want: []float64{6, 1, math.NaN()},
+		},
+		{
+			name: "xFilesFactor of the request",
+			ctx:  utilctx.SetXFilesFactor(context.Background(), 0.5),
+			want: []float64{6, math.NaN(), math.NaN()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := th.EvaluatorFromFunc(md[0].F)
+			got, err := md[0].F.Do(tt.ctx, eval, exp, 0, 1, values)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 || !compare.NearlyEqual(got[0].Values, tt.want) {
+				t.Errorf("unexpected result: %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func BenchmarkAverageSeries(b *testing.B) {
 	target := "sum(metric*)"
 	metrics := map[parser.MetricRequest][]*types.MetricData{
@@ -74,7 +74,7 @@ func (f *aggregateLine) Do(ctx context.Context, eval interfaces.Evaluator, e par
 		r := a.CopyTag(name, a.Tags)
 
 		if keepStep {
-			r.Values = make([]float64, len(a.Values))
+			r.Values = r.NewValues(len(a.Values))
 			for i := range r.Values {
 				r.Values[i] = val
 			}
@@ -54,6 +54,7 @@ func (f *aliasQuery) Do(ctx context.Context, eval interfaces.Evaluator, e parser
 		if err != nil {
 			return nil, err
 		}
+		expr.SetTimeUnit(e.TimeUnit())
 		fetchTargets[i] = expr
 	}
 	targetValues, err := eval.Fetch(ctx, fetchTargets, from, until, values)
@@ -0,0 +1,380 @@
+package applyScript
+
+import (
+	"fmt"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// Interpreter counts execution steps, but a single step (e.x. `[0] * n`, `l + l` or `list(range(n))`) could allocate
+// an arbitrary amount of memory. So script is rewritten before compilation: operations that allocate memory
+// proportional to their operands are replaced by builtins, that account allocated bytes and abort the script
+// if it exceeds its budget before memory is allocated. Accounting is approximate, allocations bounded by the amount
+// of steps (e.x. append in a loop) are not counted.
+
+const (
+	allocsKey = "applyScript.allocs"
+	// valueSize is the size of the element of list, tuple or dict
+	valueSize = 16
+
+	allocPrefix = "_alloc_"
+	allocAdd    = allocPrefix + "add"
+	allocMul    = allocPrefix + "mul"
+	allocGrow   = allocPrefix + "grow"
+	allocMethod = allocPrefix + "method"
+)
+
+// methods that are accounted by allocMethod
+var allocMethods = map[string]bool{
+	"extend":  true,
+	"join":    true,
+	"replace": true,
+}
+
+// builtins that materialize their arguments and multiplier for the size of the argument
+var allocBuiltins = map[string]uint64{
+	"list":      valueSize,
+	"tuple":     valueSize,
+	"sorted":    valueSize,
+	"reversed":  valueSize,
+	"enumerate": 3 * valueSize,
+	"zip":       valueSize,
+	"dict":      3 * valueSize,
+}
+
+type allocs struct {
+	used uint64
+	max  uint64
+}
+
+// charge accounts size bytes of memory that is going to be allocated by the script
+func charge(thread *starlark.Thread, size uint64) error {
+	a, ok := thread.Local(allocsKey).(*allocs)
+	if !ok || a.max == 0 {
+		return nil
+	}
+	a.used += size
+	if a.used > a.max || a.used < size {
+		return fmt.Errorf("too many allocations (limit %d bytes)", a.max)
+	}
+	return nil
+}
+
+// sizeOf returns approximate size of the value that is proportional to its length
+func sizeOf(v starlark.Value) (uint64, bool) {
+	switch x := v.(type) {
+	case starlark.String:
+		return uint64(len(x)), true
+	case starlark.Bytes:
+		return uint64(len(x)), true
+	case *starlark.List, starlark.Tuple:
+		return uint64(starlark.Len(x)) * valueSize, true
+	}
+	return 0, false
+}
+
+func allocBuiltinsDict() starlark.StringDict {
+	d := starlark.StringDict{
+		allocAdd:    starlark.NewBuiltin(allocAdd, add),
+		allocMul:    starlark.NewBuiltin(allocMul, mul),
+		allocGrow:   starlark.NewBuiltin(allocGrow, grow),
+		allocMethod: starlark.NewBuiltin(allocMethod, method),
+	}
+	for name, size := range allocBuiltins {
+		d[name] = materializer(starlark.Universe[name], size)
+	}
+	return d
+}
+
+// add(x, y) is x + y
+func add(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x, y starlark.Value
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 2, &x, &y); err != nil {
+		return nil, err
+	}
+	if sx, ok := sizeOf(x); ok {
+		if sy, ok := sizeOf(y); ok {
+			if err := charge(thread, sx+sy); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return starlark.Binary(syntax.PLUS, x, y)
+}
+
+// mul(x, y) is x * y
+func mul(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x, y starlark.Value
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 2, &x, &y); err != nil {
+		return nil, err
+	}
+	seq, n := x, y
+	if _, ok := x.(starlark.Int); ok {
+		seq, n = y, x
+	}
+	if size, ok := sizeOf(seq); ok {
+		if i, ok := n.(starlark.Int); ok {
+			if count, ok := i.Int64(); ok && count > 0 {
+				hi, total := bits.Mul64(size, uint64(count))
+				if hi != 0 {
+					total = ^uint64(0)
+				}
+				if err := charge(thread, total); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return starlark.Binary(syntax.STAR, x, y)
+}
+
+// grow(y) returns y, it accounts `x += y`
+func grow(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var y starlark.Value
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &y); err != nil {
+		return nil, err
+	}
+	if size, ok := sizeOf(y); ok {
+		if err := charge(thread, size); err != nil {
+			return nil, err
+		}
+	}
+	return y, nil
+}
+
+// method(x, name, *args, **kwargs) is x.name(*args, **kwargs)
+func method(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("%s: missing arguments", b.Name())
+	}
+	x := args[0]
+	name, _ := starlark.AsString(args[1])
+	args = args[2:]
+
+	var size uint64
+	switch s, _ := x.(starlark.String); {
+	case name == "extend" && len(args) > 0:
+		if n := starlark.Len(args[0]); n > 0 {
+			size = uint64(n) * valueSize
+		}
+	case name == "join" && len(args) > 0:
+		if iterable, ok := args[0].(starlark.Iterable); ok {
+			iter := iterable.Iterate()
+			var v starlark.Value
+			n := 0
+			for iter.Next(&v) {
+				if e, ok := v.(starlark.String); ok {
+					size += uint64(len(e))
+				}
+				n++
+			}
+			iter.Done()
+			size += uint64(n * len(s))
+		}
+	case name == "replace" && len(args) > 1:
+		old, _ := starlark.AsString(args[0])
+		repl, _ := starlark.AsString(args[1])
+		count := strings.Count(string(s), old)
+		if len(args) > 2 {
+			if c, err := starlark.AsInt32(args[2]); err == nil && c >= 0 && c < count {
+				count = c
+			}
+		}
+		if len(repl) > len(old) {
+			size = uint64(len(s)) + uint64(count)*uint64(len(repl)-len(old))
+		}
+	}
+	if err := charge(thread, size); err != nil {
+		return nil, err
+	}
+
+	attrs, ok := x.(starlark.HasAttrs)
+	if !ok {
+		return nil, fmt.Errorf("%s has no .%s field or method", x.Type(), name)
+	}
+	fn, err := attrs.Attr(name)
+	if err != nil {
+		return nil, err
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("%s has no .%s field or method", x.Type(), name)
+	}
+	return starlark.Call(thread, fn, args, kwargs)
+}
+
+// materializer wraps builtin that creates a collection from its arguments
+func materializer(fn starlark.Value, elementSize uint64) *starlark.Builtin {
+	name := fn.(*starlark.Builtin).Name()
+	return starlark.NewBuiltin(name, func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		// zip produces tuples of all arguments as long as the shortest one, others materialize the first argument
+		sized := args
+		if name != "zip" && len(args) > 1 {
+			sized = args[:1]
+		}
+		known := true
+		n := -1
+		for _, a := range sized {
+			l := starlark.Len(a)
+			if l < 0 {
+				known = false
+			} else if n < 0 || l < n {
+				n = l
+			}
+		}
+		if known && n > 0 {
+			if err := charge(thread, uint64(n)*uint64(len(sized))*elementSize); err != nil {
+				return nil, err
+			}
+		}
+		res, err := starlark.Call(thread, fn, args, kwargs)
+		if err != nil || known {
+			return res, err
+		}
+		if l := starlark.Len(res); l > 0 {
+			if err := charge(thread, uint64(l)*elementSize); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	})
+}
+
+var (
+	exprType = reflect.TypeOf((*syntax.Expr)(nil)).Elem()
+	stmtType = reflect.TypeOf((*syntax.Stmt)(nil)).Elem()
+	nodeType = reflect.TypeOf((*syntax.Node)(nil)).Elem()
+)
+
+// rewriteAllocs replaces operations of the syntax tree that could allocate memory proportional to the size of their
+// operands with accounting builtins
+func rewriteAllocs(f *syntax.File) error {
+	var err error
+	syntax.Walk(f, func(n syntax.Node) bool {
+		if id, ok := n.(*syntax.Ident); ok && strings.HasPrefix(id.Name, allocPrefix) {
+			err = fmt.Errorf("%s: names with %s prefix are reserved", id.NamePos, allocPrefix)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return rewriteChildren(f)
+}
+
+func rewriteChildren(n syntax.Node) error {
+	v := reflect.ValueOf(n).Elem()
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		f := v.Field(i)
+		switch {
+		case f.Type() == exprType || f.Type() == stmtType || f.Type() == nodeType:
+			if err := rewriteField(f); err != nil {
+				return err
+			}
+		case f.Kind() == reflect.Slice && (f.Type().Elem() == exprType || f.Type().Elem() == stmtType || f.Type().Elem() == nodeType):
+			for j := 0; j < f.Len(); j++ {
+				if err := rewriteField(f.Index(j)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func rewriteField(f reflect.Value) error {
+	if f.IsNil() {
+		return nil
+	}
+	n, err := rewrite(f.Interface().(syntax.Node))
+	if err != nil {
+		return err
+	}
+	f.Set(reflect.ValueOf(n))
+	return nil
+}
+
+func rewrite(n syntax.Node) (syntax.Node, error) {
+	if err := rewriteChildren(n); err != nil {
+		return nil, err
+	}
+
+	switch x := n.(type) {
+	case *syntax.BinaryExpr:
+		switch x.Op {
+		case syntax.PLUS:
+			return call(x, allocAdd, x.X, x.Y), nil
+		case syntax.STAR:
+			return call(x, allocMul, x.X, x.Y), nil
+		}
+	case *syntax.CallExpr:
+		if dot, ok := x.Fn.(*syntax.DotExpr); ok && allocMethods[dot.Name.Name] {
+			start, _ := dot.Span()
+			name := &syntax.Literal{Token: syntax.STRING, TokenPos: start, Raw: strconv.Quote(dot.Name.Name), Value: dot.Name.Name}
+			x.Fn = &syntax.Ident{NamePos: start, Name: allocMethod}
+			x.Args = append([]syntax.Expr{dot.X, name}, x.Args...)
+		}
+	case *syntax.AssignStmt:
+		switch x.Op {
+		case syntax.PLUS_EQ:
+			x.RHS = call(x.RHS, allocGrow, x.RHS)
+		case syntax.STAR_EQ:
+			// x *= y is x = x * y, but operand of x must be evaluated once
+			lhs, ok := clonePure(x.LHS)
+			if !ok {
+				return nil, fmt.Errorf("%s: augmented assignment to an expression with calls is not supported", x.OpPos)
+			}
+			x.Op = syntax.EQ
+			x.RHS = call(x.RHS, allocMul, lhs, x.RHS)
+		}
+	}
+	return n, nil
+}
+
+func call(n syntax.Node, fn string, args ...syntax.Expr) *syntax.CallExpr {
+	start, end := n.Span()
+	return &syntax.CallExpr{
+		Fn:     &syntax.Ident{NamePos: start, Name: fn},
+		Lparen: start,
+		Args:   args,
+		Rparen: end,
+	}
+}
+
+// clonePure returns copy of the expression if its evaluation has no side effects
+func clonePure(e syntax.Expr) (syntax.Expr, bool) {
+	switch x := e.(type) {
+	case *syntax.Ident:
+		return &syntax.Ident{NamePos: x.NamePos, Name: x.Name}, true
+	case *syntax.Literal:
+		c := *x
+		return &c, true
+	case *syntax.ParenExpr:
+		return clonePure(x.X)
+	case *syntax.DotExpr:
+		xx, ok := clonePure(x.X)
+		if !ok {
+			return nil, false
+		}
+		return &syntax.DotExpr{X: xx, Dot: x.Dot, NamePos: x.NamePos, Name: &syntax.Ident{NamePos: x.Name.NamePos, Name: x.Name.Name}}, true
+	case *syntax.IndexExpr:
+		xx, ok := clonePure(x.X)
+		if !ok {
+			return nil, false
+		}
+		y, ok := clonePure(x.Y)
+		if !ok {
+			return nil, false
+		}
+		return &syntax.IndexExpr{X: xx, Lbrack: x.Lbrack, Y: y, Rbrack: x.Rbrack}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,497 @@
+package applyScript
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/lomik/zapwriter"
+	"github.com/spf13/viper"
+	starlarkmath "go.starlark.net/lib/math"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+	"go.uber.org/zap"
+
+	"github.com/go-graphite/carbonapi/expr/helper"
+	"github.com/go-graphite/carbonapi/expr/interfaces"
+	"github.com/go-graphite/carbonapi/expr/types"
+	"github.com/go-graphite/carbonapi/pkg/parser"
+)
+
+const (
+	defaultMaxSteps      = 1000000
+	defaultTimeout       = time.Second
+	defaultMaxAllocBytes = 64 * 1024 * 1024
+
+	// seriesEntrypoint is a function that script must define to work on the whole seriesList
+	seriesEntrypoint = "apply"
+	// pointEntrypoint is a name of the wrapper generated for single-expression scripts
+	pointEntrypoint = "applyPoint"
+)
+
+type applyScriptConfig struct {
+	// MaxSteps limits amount of interpreter steps per function call
+	MaxSteps uint64
+	// Timeout limits wall time of a single function call
+	Timeout time.Duration
+	// MaxAllocBytes limits approximate amount of memory allocated by a single function call
+	MaxAllocBytes uint64
+	// AllowInline allows to pass script source as a function argument, otherwise only named scripts can be used
+	AllowInline *bool
+	// Scripts contains named scripts, name is case-insensitive
+	Scripts map[string]string
+}
+
+type applyScript struct {
+	maxSteps      uint64
+	timeout       time.Duration
+	maxAllocBytes uint64
+	allowInline   bool
+	scripts       map[string]*script
+}
+
+type script struct {
+	program    *starlark.Program
+	entrypoint string
+}
+
+func GetOrder() interfaces.Order {
+	return interfaces.Any
+}
+
+func New(configFile string) []interfaces.FunctionMetadata {
+	logger := zapwriter.Logger("functionInit").With(zap.String("function", "applyScript"))
+	res := make([]interfaces.FunctionMetadata, 0)
+	f := &applyScript{
+		maxSteps:      defaultMaxSteps,
+		timeout:       defaultTimeout,
+		maxAllocBytes: defaultMaxAllocBytes,
+		scripts:       make(map[string]*script),
+	}
+	functions := []string{"applyScript"}
+	for _, n := range functions {
+		res = append(res, interfaces.FunctionMetadata{Name: n, F: f})
+	}
+
+	if configFile == "" {
+		return res
+	}
+
+	cfg := applyScriptConfig{}
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	err := v.ReadInConfig()
+	if err != nil {
+		logger.Info("failed to read config file, using default",
+			zap.Error(err),
+		)
+		return res
+	}
+	err = v.Unmarshal(&cfg)
+	if err != nil {
+		logger.Fatal("failed to parse config",
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	if cfg.MaxSteps > 0 {
+		f.maxSteps = cfg.MaxSteps
+	}
+	if cfg.Timeout > 0 {
+		f.timeout = cfg.Timeout
+	}
+	if cfg.MaxAllocBytes > 0 {
+		f.maxAllocBytes = cfg.MaxAllocBytes
+	}
+	if cfg.AllowInline != nil {
+		f.allowInline = *cfg.AllowInline
+	}
+	for name, src := range cfg.Scripts {
+		s, err := compile(name, src)
+		if err != nil {
+			logger.Fatal("failed to compile script",
+				zap.String("script", name),
+				zap.Error(err),
+			)
+			return nil
+		}
+		f.scripts[strings.ToLower(name)] = s
+	}
+
+	logger.Info("will use configuration",
+		zap.Uint64("max_steps", f.maxSteps),
+		zap.Duration("timeout", f.timeout),
+		zap.Uint64("max_alloc_bytes", f.maxAllocBytes),
+		zap.Bool("allow_inline", f.allowInline),
+		zap.Int("scripts", len(f.scripts)),
+	)
+
+	return res
+}
+
+func predeclared() starlark.StringDict {
+	p := starlark.StringDict{
+		"struct": starlark.NewBuiltin("struct", starlarkstruct.Make),
+		"math":   starlarkmath.Module,
+		"nan":    starlark.Float(math.NaN()),
+	}
+	for k, v := range allocBuiltinsDict() {
+		p[k] = v
+	}
+	return p
+}
+
+// compile prepares script for execution. Script that is a single expression is evaluated for each point
+// with `v` (value or None), `t` (timestamp), `name` and `tags` variables defined. Otherwise script must define
+// `apply(series)` function that receives list of series and returns list of series.
+func compile(name, src string) (*script, error) {
+	filename := name + ".star"
+	entrypoint := seriesEntrypoint
+	if _, err := syntax.ParseExpr(filename, src, 0); err == nil {
+		src = "def " + pointEntrypoint + "(v, t, name, tags):\n    return (" + src + ")\n"
+		entrypoint = pointEntrypoint
+	}
+
+	f, err := syntax.LegacyFileOptions().Parse(filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := rewriteAllocs(f); err != nil {
+		return nil, err
+	}
+	p := predeclared()
+	program, err := starlark.FileProgram(f, p.Has)
+	if err != nil {
+		return nil, err
+	}
+	return &script{program: program, entrypoint: entrypoint}, nil
+}
+
+// applyScript(seriesList, script)
+func (f *applyScript) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr, from, until int64, values map[parser.MetricRequest][]*types.MetricData) ([]*types.MetricData, error) {
+	if e.ArgsLen() < 2 {
+		return nil, parser.ErrMissingArgument
+	}
+
+	args, err := helper.GetSeriesArg(ctx, eval, e.Arg(0), from, until, values)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := e.GetStringArg(1)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := f.scripts[strings.ToLower(source)]
+	if !ok {
+		if !f.allowInline {
+			return nil, merry.WithMessagef(parser.ErrInvalidArg, "unknown script '%s'", source)
+		}
+		s, err = compile("inline", source)
+		if err != nil {
+			return nil, merry.WithMessagef(parser.ErrInvalidArg, "failed to compile script: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	thread := &starlark.Thread{Name: "applyScript"}
+	thread.SetMaxExecutionSteps(f.maxSteps)
+	thread.SetLocal(allocsKey, &allocs{max: f.maxAllocBytes})
+	stop := context.AfterFunc(ctx, func() {
+		thread.Cancel(ctx.Err().Error())
+	})
+	defer stop()
+
+	var results []*types.MetricData
+	if s.entrypoint == pointEntrypoint {
+		results, err = f.applyPoints(thread, s, e, args)
+	} else {
+		results, err = f.applySeries(thread, s, args)
+	}
+	if err != nil {
+		return nil, merry.WithMessagef(parser.ErrInvalidArg, "script aborted: %v", err)
+	}
+
+	return results, nil
+}
+
+func entrypoint(thread *starlark.Thread, s *script) (starlark.Callable, error) {
+	globals, err := s.program.Init(thread, predeclared())
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := globals[s.entrypoint].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("script must define %s(series) function", s.entrypoint)
+	}
+	return fn, nil
+}
+
+func (f *applyScript) applyPoints(thread *starlark.Thread, s *script, e parser.Expr, args []*types.MetricData) ([]*types.MetricData, error) {
+	fn, err := entrypoint(thread, s)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*types.MetricData, len(args))
+	for n, a := range args {
+		r := a.CopyLink()
+		r.Name = "applyScript(" + a.Name + ",'" + e.Arg(1).StringValue() + "')"
+		r.Values = r.NewValues(len(a.Values))
+
+		name := starlark.String(a.Name)
+		tags := tagsToDict(a.Tags)
+		tags.Freeze()
+		t := a.StartTime
+		for i, v := range a.Values {
+			rv, err := starlark.Call(thread, fn, starlark.Tuple{valueToStarlark(v), starlark.MakeInt64(t), name, tags}, nil)
+			if err != nil {
+				return nil, err
+			}
+			r.Values[i], err = valueFromStarlark(rv)
+			if err != nil {
+				return nil, err
+			}
+			t += a.StepTime
+		}
+		results[n] = r
+	}
+
+	return results, nil
+}
+
+func (f *applyScript) applySeries(thread *starlark.Thread, s *script, args []*types.MetricData) ([]*types.MetricData, error) {
+	fn, err := entrypoint(thread, s)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]starlark.Value, len(args))
+	for i, a := range args {
+		series[i] = seriesToStarlark(a)
+	}
+
+	rv, err := starlark.Call(thread, fn, starlark.Tuple{starlark.NewList(series)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	iterable, ok := rv.(starlark.Iterable)
+	if !ok {
+		return nil, fmt.Errorf("%s must return list of series, got %s", s.entrypoint, rv.Type())
+	}
+
+	var results []*types.MetricData
+	iter := iterable.Iterate()
+	defer iter.Done()
+	var x starlark.Value
+	for iter.Next(&x) {
+		r, err := seriesFromStarlark(x, args)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
+func valueToStarlark(v float64) starlark.Value {
+	if math.IsNaN(v) {
+		return starlark.None
+	}
+	return starlark.Float(v)
+}
+
+func valueFromStarlark(v starlark.Value) (float64, error) {
+	if v == starlark.None {
+		return math.NaN(), nil
+	}
+	f, ok := starlark.AsFloat(v)
+	if !ok {
+		return 0, fmt.Errorf("value must be a number or None, got %s", v.Type())
+	}
+	return f, nil
+}
+
+func tagsToDict(tags map[string]string) *starlark.Dict {
+	d := starlark.NewDict(len(tags))
+	for k, v := range tags {
+		_ = d.SetKey(starlark.String(k), starlark.String(v))
+	}
+	return d
+}
+
+func seriesToStarlark(a *types.MetricData) starlark.Value {
+	values := make([]starlark.Value, len(a.Values))
+	timestamps := make([]starlark.Value, len(a.Values))
+	t := a.StartTime
+	for i, v := range a.Values {
+		values[i] = valueToStarlark(v)
+		timestamps[i] = starlark.MakeInt64(t)
+		t += a.StepTime
+	}
+
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, starlark.StringDict{
+		"name":       starlark.String(a.Name),
+		"tags":       tagsToDict(a.Tags),
+		"start":      starlark.MakeInt64(a.StartTime),
+		"stop":       starlark.MakeInt64(a.StopTime),
+		"step":       starlark.MakeInt64(a.StepTime),
+		"values":     starlark.NewList(values),
+		"timestamps": starlark.NewList(timestamps),
+	})
+}
+
+// field returns attribute of a struct or a key of a dict
+func field(x starlark.Value, name string) (starlark.Value, error) {
+	switch v := x.(type) {
+	case *starlark.Dict:
+		r, found, err := v.Get(starlark.String(name))
+		if !found {
+			return nil, err
+		}
+		return r, err
+	case starlark.HasAttrs:
+		r, err := v.Attr(name)
+		if err != nil {
+			return nil, nil
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("series must be a dict or a struct, got %s", x.Type())
+}
+
+func int64Field(x starlark.Value, name string, defaultValue int64) (int64, error) {
+	v, err := field(x, name)
+	if err != nil {
+		return 0, err
+	}
+	if v == nil {
+		return defaultValue, nil
+	}
+	i, ok := v.(starlark.Int)
+	if !ok {
+		return 0, fmt.Errorf("series %s must be an int, got %s", name, v.Type())
+	}
+	r, ok := i.Int64()
+	if !ok {
+		return 0, fmt.Errorf("series %s is out of range", name)
+	}
+	return r, nil
+}
+
+func seriesFromStarlark(x starlark.Value, args []*types.MetricData) (*types.MetricData, error) {
+	nameValue, err := field(x, "name")
+	if err != nil {
+		return nil, err
+	}
+	name, ok := starlark.AsString(nameValue)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("series must have a non-empty name")
+	}
+
+	var defaultStart, defaultStep int64
+	if len(args) > 0 {
+		defaultStart = args[0].StartTime
+		defaultStep = args[0].StepTime
+	}
+	start, err := int64Field(x, "start", defaultStart)
+	if err != nil {
+		return nil, err
+	}
+	step, err := int64Field(x, "step", defaultStep)
+	if err != nil {
+		return nil, err
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("series '%s' must have a positive step", name)
+	}
+
+	valuesValue, err := field(x, "values")
+	if err != nil {
+		return nil, err
+	}
+	valuesIterable, ok := valuesValue.(starlark.Iterable)
+	if !ok {
+		return nil, fmt.Errorf("series '%s' must have a list of values", name)
+	}
+	var values []float64
+	iter := valuesIterable.Iterate()
+	defer iter.Done()
+	var v starlark.Value
+	for iter.Next(&v) {
+		f, err := valueFromStarlark(v)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, f)
+	}
+
+	tags := map[string]string{}
+	tagsValue, err := field(x, "tags")
+	if err != nil {
+		return nil, err
+	}
+	if tagsValue != nil && tagsValue != starlark.None {
+		d, ok := tagsValue.(*starlark.Dict)
+		if !ok {
+			return nil, fmt.Errorf("series '%s' tags must be a dict, got %s", name, tagsValue.Type())
+		}
+		for _, item := range d.Items() {
+			k, okK := starlark.AsString(item[0])
+			v, okV := starlark.AsString(item[1])
+			if !okK || !okV {
+				return nil, fmt.Errorf("series '%s' tags must be strings", name)
+			}
+			tags[k] = v
+		}
+	}
+	if _, ok := tags["name"]; !ok {
+		tags["name"] = name
+	}
+
+	r := types.MakeMetricData(name, values, step, start)
+	r.Tags = tags
+	return r, nil
+}
+
+func (f *applyScript) Description() map[string]types.FunctionDescription {
+	return map[string]types.FunctionDescription{
+		"applyScript": {
+			Description: "Runs a sandboxed Starlark script over the seriesList. Script is either a name of the script from function config or a script source (if allowed by config).\n\n" +
+				"Script that is a single expression is evaluated for each datapoint, with `v` (value or None), `t` (timestamp), `name` and `tags` available. The result is a new value or None.\n\n" +
+				"Otherwise script must define `apply(series)` function. Each series has `name`, `tags`, `start`, `stop`, `step`, `values` and `timestamps` attributes. Function must return list of dicts (or structs) with `name`, `values` and optional `tags`, `start` and `step`.\n\n" +
+				"Scripts are limited by amount of execution steps, time and allocated memory, script that exceeds its limits is aborted.\n\n" +
+				".. code-block:: none\n\n  &target=applyScript(server.*.requests, \"v * 2 if v != None else None\")\n  &target=applyScript(server.*.requests, \"weightedSlo\")",
+			Function: "applyScript(seriesList, script)",
+			Group:    "Transform",
+			Module:   "graphite.render.functions.custom",
+			Name:     "applyScript",
+			Params: []types.FunctionParam{
+				{
+					Name:     "seriesList",
+					Required: true,
+					Type:     types.SeriesList,
+				},
+				{
+					Name:     "script",
+					Required: true,
+					Type:     types.String,
+				},
+			},
+			SeriesChange: true, // function aggregate metrics or change series items count
+			NameChange:   true, // name changed
+			TagsChange:   true, // name tag changed
+			ValuesChange: true, // values changed
+		},
+	}
+}
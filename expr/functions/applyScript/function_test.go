@@ -0,0 +1,267 @@
+package applyScript
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-graphite/carbonapi/expr/functions/group"
+	"github.com/go-graphite/carbonapi/expr/interfaces"
+	"github.com/go-graphite/carbonapi/expr/metadata"
+	"github.com/go-graphite/carbonapi/expr/types"
+	"github.com/go-graphite/carbonapi/pkg/parser"
+	th "github.com/go-graphite/carbonapi/tests"
+)
+
+const testConfig = `
+maxSteps: 10000
+timeout: "500ms"
+maxAllocBytes: 1048576
+allowInline: true
+scripts:
+  weightedSlo: |
+    def apply(series):
+        good = series[0]
+        total = series[1]
+        values = []
+        for i in range(len(good.values)):
+            g = good.values[i]
+            t = total.values[i]
+            if g == None or t == None or t == 0:
+                values.append(None)
+            else:
+                values.append(0.9 * g / t + 0.1)
+        return [{"name": "slo", "tags": {"name": "slo", "team": "core"}, "values": values}]
+  infinite: |
+    def apply(series):
+        x = 0
+        for i in range(1000000000):
+            x += i
+        return []
+`
+
+var (
+	md []interfaces.FunctionMetadata = New("")
+)
+
+func init() {
+	for _, m := range md {
+		metadata.RegisterFunction(m.Name, m.F)
+	}
+}
+
+func newWithConfig(t *testing.T) interfaces.Function {
+	return newWithConfigString(t, testConfig)
+}
+
+func newWithConfigString(t *testing.T, config string) interfaces.Function {
+	configFile := filepath.Join(t.TempDir(), "applyScript.yaml")
+	if err := os.WriteFile(configFile, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	res := New(configFile)
+	if len(res) != 1 {
+		t.Fatalf("unexpected amount of functions: %d", len(res))
+	}
+	return res[0].F
+}
+
+func TestApplyScript(t *testing.T) {
+	now32 := int64(time.Now().Unix())
+	f := newWithConfig(t)
+
+	tests := []th.EvalTestItem{
+		{
+			Target: `applyScript(metric1,"v * 2 if v != None else None")`,
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2, math.NaN(), 4, 5}, 1, now32)},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("applyScript(metric1,'v * 2 if v != None else None')", []float64{2, 4, math.NaN(), 8, 10}, 1, now32).SetTag("name", "metric1"),
+			},
+		},
+		{
+			Target: `applyScript(metric1,"t % 2")`,
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2, 3, 4}, 1, 10)},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("applyScript(metric1,'t % 2')", []float64{0, 1, 0, 1}, 1, 10).SetTag("name", "metric1"),
+			},
+		},
+		{
+			Target: "applyScript(metric*,\"def apply(series):\n    return [{'name': 'sum', 'tags': {'host': 'a'}, 'values': [a + b for a, b in zip(series[0].values, series[1].values)], 'step': 2}]\n\")",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric*", From: 0, Until: 1}: {
+					types.MakeMetricData("metric1", []float64{1, 2, 3}, 1, now32),
+					types.MakeMetricData("metric2", []float64{3, 2, 1}, 1, now32),
+				},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("sum", []float64{4, 4, 4}, 2, now32).SetTag("host", "a"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		testName := tt.Target
+		t.Run(testName, func(t *testing.T) {
+			eval := th.EvaluatorFromFunc(f)
+			th.TestEvalExpr(t, eval, &tt)
+		})
+	}
+}
+
+func TestApplyScriptNamed(t *testing.T) {
+	now32 := int64(time.Now().Unix())
+	f := newWithConfig(t)
+
+	tt := th.EvalTestItem{
+		Target: `applyScript(group(good,total),"weightedSlo")`,
+		M: map[parser.MetricRequest][]*types.MetricData{
+			{Metric: "good", From: 0, Until: 1}:  {types.MakeMetricData("good", []float64{5, 10, math.NaN()}, 60, now32)},
+			{Metric: "total", From: 0, Until: 1}: {types.MakeMetricData("total", []float64{10, 10, 10}, 60, now32)},
+		},
+		Want: []*types.MetricData{
+			types.MakeMetricData("slo", []float64{0.55, 1, math.NaN()}, 60, now32).SetTag("team", "core"),
+		},
+	}
+
+	eval := th.EvaluatorFromFuncWithMetadata(map[string]interfaces.Function{
+		"applyScript": f,
+		"group":       group.New("")[0].F,
+	})
+	th.TestEvalExpr(t, eval, &tt)
+}
+
+func TestApplyScriptErrors(t *testing.T) {
+	now32 := int64(time.Now().Unix())
+	f := newWithConfig(t)
+
+	tests := []th.EvalTestItemWithError{
+		{
+			Target: `applyScript(metric1,"infinite")`,
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2}, 1, now32)},
+			},
+			Error: parser.ErrInvalidArg,
+		},
+		{
+			Target: `applyScript(metric1,"v +")`,
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2}, 1, now32)},
+			},
+			Error: parser.ErrInvalidArg,
+		},
+		{
+			Target: `applyScript(metric1,"'string'")`,
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2}, 1, now32)},
+			},
+			Error: parser.ErrInvalidArg,
+		},
+	}
+
+	for _, tt := range tests {
+		testName := tt.Target
+		t.Run(testName, func(t *testing.T) {
+			eval := th.EvaluatorFromFunc(f)
+			th.TestEvalExprWithError(t, eval, &tt)
+		})
+	}
+}
+
+func evalScript(f interfaces.Function, script string) error {
+	e, _, err := parser.ParseExpr("applyScript(metric1,\"" + script + "\")")
+	if err != nil {
+		return err
+	}
+	values := map[parser.MetricRequest][]*types.MetricData{
+		{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2}, 1, 0)},
+	}
+	_, err = th.EvaluatorFromFunc(f).Eval(context.Background(), e, 0, 1, values)
+	return err
+}
+
+func TestApplyScriptInlineDisabledByDefault(t *testing.T) {
+	err := evalScript(md[0].F, "v * 2")
+	if err == nil || !strings.Contains(err.Error(), "unknown script") {
+		t.Errorf("inline script should be rejected by default, got %v", err)
+	}
+}
+
+func TestApplyScriptLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		script string
+		want   string
+	}{
+		{
+			name:   "steps",
+			config: "maxSteps: 1000\nallowInline: true\n",
+			script: "def apply(series):\n    x = 0\n    for i in range(1000000000):\n        x += i\n    return []\n",
+			want:   "too many steps",
+		},
+		{
+			name:   "timeout",
+			config: "maxSteps: 100000000000\ntimeout: 50ms\nallowInline: true\n",
+			script: "def apply(series):\n    x = 0\n    for i in range(1000000000):\n        x += i\n    return []\n",
+			want:   "context deadline exceeded",
+		},
+		{
+			name:   "repetition",
+			config: "maxAllocBytes: 1048576\nallowInline: true\n",
+			script: "def apply(series):\n    l = [0] * 1000000000\n    return []\n",
+			want:   "too many allocations",
+		},
+		{
+			name:   "doubling",
+			config: "maxAllocBytes: 1048576\nallowInline: true\n",
+			script: "def apply(series):\n    l = [0]\n    for i in range(40):\n        l = l + l\n    return []\n",
+			want:   "too many allocations",
+		},
+		{
+			name:   "augmented assignment",
+			config: "maxAllocBytes: 1048576\nallowInline: true\n",
+			script: "def apply(series):\n    s = 'x'\n    for i in range(40):\n        s += s\n    return []\n",
+			want:   "too many allocations",
+		},
+		{
+			name:   "materialized range",
+			config: "maxAllocBytes: 1048576\nallowInline: true\n",
+			script: "def apply(series):\n    l = list(range(1 << 40))\n    return []\n",
+			want:   "too many allocations",
+		},
+		{
+			name:   "extend",
+			config: "maxAllocBytes: 1048576\nallowInline: true\n",
+			script: "def apply(series):\n    l = [0]\n    for i in range(40):\n        l.extend(l)\n    return []\n",
+			want:   "too many allocations",
+		},
+		{
+			name:   "reserved names",
+			config: "allowInline: true\n",
+			script: "_alloc_add(1, 2)",
+			want:   "reserved",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := evalScript(newWithConfigString(t, tt.config), tt.script)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("script should fail with %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	// limits are not hit by regular scripts
+	f := newWithConfigString(t, "maxAllocBytes: 1048576\nallowInline: true\n")
+	if err := evalScript(f, "def apply(series):\n    l = [1, 2] * 10 + [3]\n    l *= 2\n    return [{'name': ' '.join(['a', 'b']), 'values': l}]\n"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
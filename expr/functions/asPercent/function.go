@@ -46,7 +46,7 @@ func getTotal(arg []*types.MetricData, i int) float64 {
 // sum aligned series
 func sumSeries(seriesList []*types.MetricData) *types.MetricData {
 	result := &types.MetricData{}
-	result.Values = make([]float64, len(seriesList[0].Values))
+	result.Values = seriesList[0].NewValues(len(seriesList[0].Values))
 
 	for _, s := range seriesList {
 		for i := range result.Values {
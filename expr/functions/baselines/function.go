@@ -65,7 +65,7 @@ func (f *baselines) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 		if i == 0 {
 			continue
 		}
-		offs := int64(i * unit)
+		offs := int64(i) * unit
 		arg, _ := helper.GetSeriesArg(ctx, eval, e.Arg(0), from+offs, until+offs, values)
 		for _, a := range arg {
 			r := a.CopyLinkTags()
@@ -86,7 +86,7 @@ func (f *baselines) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 			newName = "baseline(" + name + ")"
 		}
 		r := args[0].CopyName(newName)
-		r.Values = make([]float64, len(args[0].Values))
+		r.Values = r.NewValues(len(args[0].Values))
 
 		tmp := make([][]float64, len(args[0].Values)) // number of points
 		lengths := make([]int, len(args[0].Values))   // number of points with data
@@ -0,0 +1,452 @@
+package calendar
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/lomik/zapwriter"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/go-graphite/carbonapi/expr/consolidations"
+	fconfig "github.com/go-graphite/carbonapi/expr/functions/config"
+	"github.com/go-graphite/carbonapi/expr/helper"
+	"github.com/go-graphite/carbonapi/expr/interfaces"
+	"github.com/go-graphite/carbonapi/expr/types"
+	"github.com/go-graphite/carbonapi/pkg/parser"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+)
+
+const dateLayout = "2006-01-02"
+
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+type calendarConfig struct {
+	// Calendars contains lists of holidays in YYYY-MM-DD format, calendar name is case-insensitive
+	Calendars map[string][]string
+}
+
+type calendar struct {
+	calendars map[string]map[string]struct{}
+}
+
+func GetOrder() interfaces.Order {
+	return interfaces.Any
+}
+
+func New(configFile string) []interfaces.FunctionMetadata {
+	logger := zapwriter.Logger("functionInit").With(zap.String("function", "calendar"))
+	res := make([]interfaces.FunctionMetadata, 0)
+	f := &calendar{
+		calendars: make(map[string]map[string]struct{}),
+	}
+	functions := []string{"filterByTimeOfDay", "filterByWeekday", "holidayMask", "aggregateByTimeOfDay"}
+	for _, n := range functions {
+		res = append(res, interfaces.FunctionMetadata{Name: n, F: f})
+	}
+
+	if configFile == "" {
+		return res
+	}
+
+	cfg := calendarConfig{}
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	err := v.ReadInConfig()
+	if err != nil {
+		logger.Info("failed to read config file, using default",
+			zap.Error(err),
+		)
+		return res
+	}
+	err = v.Unmarshal(&cfg)
+	if err != nil {
+		logger.Fatal("failed to parse config",
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	for name, dates := range cfg.Calendars {
+		days := make(map[string]struct{}, len(dates))
+		for _, d := range dates {
+			if _, err := time.Parse(dateLayout, d); err != nil {
+				logger.Fatal("failed to parse holiday date",
+					zap.String("calendar", name),
+					zap.String("date", d),
+					zap.Error(err),
+				)
+				return nil
+			}
+			days[d] = struct{}{}
+		}
+		f.calendars[strings.ToLower(name)] = days
+	}
+
+	logger.Info("will use configuration",
+		zap.Int("calendars", len(f.calendars)),
+	)
+
+	return res
+}
+
+// getTimeZone returns time zone from function argument, request or default one, in that order
+func getTimeZone(ctx context.Context, e parser.Expr, n int) (*time.Location, error) {
+	name, err := e.GetStringNamedOrPosArgDefault("tz", n, "")
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		tz, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, merry.WithMessagef(parser.ErrInvalidArg, "unknown time zone '%s'", name)
+		}
+		return tz, nil
+	}
+	if tz := utilctx.GetTimeZone(ctx); tz != nil {
+		return tz, nil
+	}
+	if fconfig.Config.DefaultTimeZone != nil {
+		return fconfig.Config.DefaultTimeZone, nil
+	}
+	return time.Local, nil
+}
+
+// parseTimeOfDay parses HH:MM and returns amount of seconds since midnight, 24:00 is allowed
+func parseTimeOfDay(s string) (int, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return 0, merry.WithMessagef(parser.ErrInvalidArg, "invalid time of day '%s', must be HH:MM", s)
+	}
+	hour, err1 := strconv.Atoi(parts[0])
+	minute, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || hour < 0 || minute < 0 || minute > 59 || hour*60+minute > 24*60 {
+		return 0, merry.WithMessagef(parser.ErrInvalidArg, "invalid time of day '%s', must be HH:MM", s)
+	}
+	return hour*3600 + minute*60, nil
+}
+
+// parseWeekdays parses comma-separated list of weekdays or ranges of them, e.g. "mon-fri" or "sat,sun"
+func parseWeekdays(s string) ([7]bool, error) {
+	var days [7]bool
+	for _, part := range strings.Split(strings.ToLower(s), ",") {
+		part = strings.TrimSpace(part)
+		bounds := strings.Split(part, "-")
+		if len(bounds) > 2 {
+			return days, merry.WithMessagef(parser.ErrInvalidArg, "invalid weekdays '%s'", s)
+		}
+		idx := make([]int, 0, 2)
+		for _, b := range bounds {
+			i := -1
+			if len(b) >= 3 {
+				for j, w := range weekdays {
+					if strings.HasPrefix(b, w) {
+						i = j
+						break
+					}
+				}
+			}
+			if i == -1 {
+				return days, merry.WithMessagef(parser.ErrInvalidArg, "invalid weekday '%s'", b)
+			}
+			idx = append(idx, i)
+		}
+		if len(idx) == 1 {
+			days[idx[0]] = true
+			continue
+		}
+		// ranges can wrap over the end of the week, e.g. fri-mon
+		for i := idx[0]; ; i = (i + 1) % 7 {
+			days[i] = true
+			if i == idx[1] {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+func (f *calendar) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr, from, until int64, values map[parser.MetricRequest][]*types.MetricData) ([]*types.MetricData, error) {
+	args, err := helper.GetSeriesArg(ctx, eval, e.Arg(0), from, until, values)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.Target() {
+	case "filterByTimeOfDay":
+		return f.filterByTimeOfDay(ctx, e, args)
+	case "filterByWeekday":
+		return f.filterByWeekday(ctx, e, args)
+	case "holidayMask":
+		return f.holidayMask(ctx, e, args)
+	case "aggregateByTimeOfDay":
+		return f.aggregateByTimeOfDay(ctx, e, args)
+	}
+	return nil, helper.ErrUnknownFunction(e.Target())
+}
+
+// mask returns copies of the series with points, for which keep returns false, set to null.
+// Series are renamed to target(name,params) and tagged with target=tagValue.
+func mask(target, params, tagValue string, args []*types.MetricData, unit int64, tz *time.Location, keep func(t time.Time) bool) []*types.MetricData {
+	results := make([]*types.MetricData, 0, len(args))
+	for _, a := range args {
+		r := a.CopyTag(target+"("+a.Name+","+params+")", helper.CopyTags(a))
+		r.Tags[target] = tagValue
+		r.Values = r.NewValues(len(a.Values))
+		for i, v := range a.Values {
+			ts := a.StartTime + int64(i)*a.StepTime
+			if keep(parser.UnitTime(ts, unit).In(tz)) {
+				r.Values[i] = v
+			} else {
+				r.Values[i] = math.NaN()
+			}
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func (f *calendar) filterByTimeOfDay(ctx context.Context, e parser.Expr, args []*types.MetricData) ([]*types.MetricData, error) {
+	startStr, err := e.GetStringNamedOrPosArgDefault("start", 1, "09:00")
+	if err != nil {
+		return nil, err
+	}
+	endStr, err := e.GetStringNamedOrPosArgDefault("end", 2, "18:00")
+	if err != nil {
+		return nil, err
+	}
+	start, err := parseTimeOfDay(startStr)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseTimeOfDay(endStr)
+	if err != nil {
+		return nil, err
+	}
+	tz, err := getTimeZone(ctx, e, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	return mask(e.Target(), "'"+startStr+"','"+endStr+"'", startStr+"-"+endStr, args, e.TimeUnit(), tz, func(t time.Time) bool {
+		sec := t.Hour()*3600 + t.Minute()*60 + t.Second()
+		if start <= end {
+			return sec >= start && sec < end
+		}
+		// window wraps over midnight, e.g. 22:00-06:00
+		return sec >= start || sec < end
+	}), nil
+}
+
+func (f *calendar) filterByWeekday(ctx context.Context, e parser.Expr, args []*types.MetricData) ([]*types.MetricData, error) {
+	daysStr, err := e.GetStringNamedOrPosArgDefault("days", 1, "mon-fri")
+	if err != nil {
+		return nil, err
+	}
+	days, err := parseWeekdays(daysStr)
+	if err != nil {
+		return nil, err
+	}
+	tz, err := getTimeZone(ctx, e, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return mask(e.Target(), "'"+daysStr+"'", daysStr, args, e.TimeUnit(), tz, func(t time.Time) bool {
+		return days[t.Weekday()]
+	}), nil
+}
+
+func (f *calendar) holidayMask(ctx context.Context, e parser.Expr, args []*types.MetricData) ([]*types.MetricData, error) {
+	name, err := e.GetStringNamedOrPosArgDefault("calendar", 1, "")
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, merry.WithMessagef(parser.ErrInvalidArg, "calendar name is required")
+	}
+	holidays, ok := f.calendars[strings.ToLower(name)]
+	if !ok {
+		return nil, merry.WithMessagef(parser.ErrInvalidArg, "unknown calendar '%s'", name)
+	}
+	tz, err := getTimeZone(ctx, e, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return mask(e.Target(), "'"+name+"'", name, args, e.TimeUnit(), tz, func(t time.Time) bool {
+		_, holiday := holidays[t.Format(dateLayout)]
+		return !holiday
+	}), nil
+}
+
+func (f *calendar) aggregateByTimeOfDay(ctx context.Context, e parser.Expr, args []*types.MetricData) ([]*types.MetricData, error) {
+	aggFuncStr, err := e.GetStringNamedOrPosArgDefault("func", 1, "average")
+	if err != nil {
+		return nil, err
+	}
+	aggFunc, ok := consolidations.ConsolidationToFunc[aggFuncStr]
+	if !ok {
+		return nil, merry.WithMessagef(consolidations.ErrInvalidConsolidationFunc, "unsupported consolidation function %s", aggFuncStr)
+	}
+	bucket, err := e.GetIntervalNamedOrPosArgDefault("bucket", 2, 1, 3600)
+	if err != nil {
+		return nil, err
+	}
+	unit := e.TimeUnit()
+	day := 86400 * unit
+	if bucket <= 0 || bucket > day {
+		return nil, merry.WithMessagef(parser.ErrInvalidArg, "bucket must be between 1s and 1d")
+	}
+	tz, err := getTimeZone(ctx, e, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	nBuckets := int((day + bucket - 1) / bucket)
+	results := make([]*types.MetricData, 0, len(args))
+	for _, a := range args {
+		bucketOf := make([]int, len(a.Values))
+		buckets := make([][]float64, nBuckets)
+		for i, v := range a.Values {
+			t := parser.UnitTime(a.StartTime+int64(i)*a.StepTime, unit).In(tz)
+			timeOfDay := int64(t.Hour()*3600+t.Minute()*60+t.Second())*unit + int64(t.Nanosecond())*unit/int64(time.Second)
+			b := int(timeOfDay / bucket)
+			bucketOf[i] = b
+			buckets[b] = append(buckets[b], v)
+		}
+		profile := make([]float64, nBuckets)
+		for b, v := range buckets {
+			if len(v) == 0 {
+				profile[b] = math.NaN()
+			} else {
+				profile[b] = aggFunc(v)
+			}
+		}
+
+		r := a.CopyTag("aggregateByTimeOfDay("+a.Name+",'"+aggFuncStr+"')", helper.CopyTags(a))
+		r.Tags["aggregateByTimeOfDay"] = aggFuncStr
+		r.Values = r.NewValues(len(a.Values))
+		for i, b := range bucketOf {
+			r.Values[i] = profile[b]
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func (f *calendar) Description() map[string]types.FunctionDescription {
+	tzParam := types.FunctionParam{
+		Name: "tz",
+		Type: types.String,
+	}
+	return map[string]types.FunctionDescription{
+		"filterByTimeOfDay": {
+			Description: "Keeps only points with local time of day in [start, end) interval, other points are set to null. If start is after end, interval wraps over midnight.\n\nLocal time is computed in `tz`, request time zone or default time zone, in that order, so DST transitions are taken into account.",
+			Function:    "filterByTimeOfDay(seriesList, start='09:00', end='18:00', tz=None)",
+			Group:       "Filter Data",
+			Module:      "graphite.render.functions.custom",
+			Name:        "filterByTimeOfDay",
+			Params: []types.FunctionParam{
+				{
+					Name:     "seriesList",
+					Required: true,
+					Type:     types.SeriesList,
+				},
+				{
+					Default: types.NewSuggestion("09:00"),
+					Name:    "start",
+					Type:    types.String,
+				},
+				{
+					Default: types.NewSuggestion("18:00"),
+					Name:    "end",
+					Type:    types.String,
+				},
+				tzParam,
+			},
+			NameChange:   true, // name changed
+			TagsChange:   true, // name tag changed
+			ValuesChange: true, // values changed
+		},
+		"filterByWeekday": {
+			Description: "Keeps only points on specified weekdays, other points are set to null. Days are comma-separated weekday names or ranges of them, e.g. 'mon-fri' or 'sat,sun'.\n\nLocal time is computed in `tz`, request time zone or default time zone, in that order.",
+			Function:    "filterByWeekday(seriesList, days='mon-fri', tz=None)",
+			Group:       "Filter Data",
+			Module:      "graphite.render.functions.custom",
+			Name:        "filterByWeekday",
+			Params: []types.FunctionParam{
+				{
+					Name:     "seriesList",
+					Required: true,
+					Type:     types.SeriesList,
+				},
+				{
+					Default: types.NewSuggestion("mon-fri"),
+					Name:    "days",
+					Type:    types.String,
+				},
+				tzParam,
+			},
+			NameChange:   true, // name changed
+			TagsChange:   true, // name tag changed
+			ValuesChange: true, // values changed
+		},
+		"holidayMask": {
+			Description: "Sets points on holidays from the calendar to null. Calendars are configured in functionsConfig.\n\nLocal time is computed in `tz`, request time zone or default time zone, in that order.",
+			Function:    "holidayMask(seriesList, calendar, tz=None)",
+			Group:       "Filter Data",
+			Module:      "graphite.render.functions.custom",
+			Name:        "holidayMask",
+			Params: []types.FunctionParam{
+				{
+					Name:     "seriesList",
+					Required: true,
+					Type:     types.SeriesList,
+				},
+				{
+					Name:     "calendar",
+					Required: true,
+					Type:     types.String,
+				},
+				tzParam,
+			},
+			NameChange:   true, // name changed
+			TagsChange:   true, // name tag changed
+			ValuesChange: true, // values changed
+		},
+		"aggregateByTimeOfDay": {
+			Description: "Builds daily profile: points are grouped by local time of day into buckets and every point is replaced by aggregate of its bucket over the whole interval.\n\nLocal time is computed in `tz`, request time zone or default time zone, in that order, so DST transitions are taken into account.",
+			Function:    "aggregateByTimeOfDay(seriesList, func='average', bucket='1h', tz=None)",
+			Group:       "Transform",
+			Module:      "graphite.render.functions.custom",
+			Name:        "aggregateByTimeOfDay",
+			Params: []types.FunctionParam{
+				{
+					Name:     "seriesList",
+					Required: true,
+					Type:     types.SeriesList,
+				},
+				{
+					Default: types.NewSuggestion("average"),
+					Name:    "func",
+					Options: types.StringsToSuggestionList(consolidations.AvailableSummarizers),
+					Type:    types.AggFunc,
+				},
+				{
+					Default: types.NewSuggestion("1h"),
+					Name:    "bucket",
+					Type:    types.Interval,
+				},
+				tzParam,
+			},
+			NameChange:   true, // name changed
+			TagsChange:   true, // name tag changed
+			ValuesChange: true, // values changed
+		},
+	}
+}
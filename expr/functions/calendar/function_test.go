@@ -0,0 +1,205 @@
+package calendar
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-graphite/carbonapi/expr/interfaces"
+	"github.com/go-graphite/carbonapi/expr/metadata"
+	"github.com/go-graphite/carbonapi/expr/types"
+	"github.com/go-graphite/carbonapi/pkg/parser"
+	th "github.com/go-graphite/carbonapi/tests"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+)
+
+const testConfig = `
+calendars:
+  US:
+    - "2016-07-04"
+    - "2016-12-25"
+`
+
+var (
+	md []interfaces.FunctionMetadata = New("")
+)
+
+func init() {
+	for _, m := range md {
+		metadata.RegisterFunction(m.Name, m.F)
+	}
+}
+
+func newWithConfig(t *testing.T) interfaces.Function {
+	configFile := filepath.Join(t.TempDir(), "calendar.yaml")
+	if err := os.WriteFile(configFile, []byte(testConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	res := New(configFile)
+	if len(res) != 4 {
+		t.Fatalf("unexpected amount of functions: %d", len(res))
+	}
+	return res[0].F
+}
+
+const (
+	// 2016-03-13 05:00 UTC, 00:00 EST, DST starts in America/New_York 2 hours later
+	dstDay int64 = 1457845200
+	// 2016-03-11 02:00 UTC, Thursday 21:00 EST
+	thursdayEvening int64 = 1457661600
+	// 2016-03-12 06:00 UTC, 01:00 EST
+	dstEve int64 = 1457762400
+	// 2016-07-04 02:00 UTC, 2016-07-03 22:00 EDT
+	independenceDayEve int64 = 1467597600
+)
+
+func TestCalendar(t *testing.T) {
+	nan := math.NaN()
+
+	tests := []th.EvalTestItem{
+		{
+			// 01:00 EST is followed by 03:00 EDT
+			Target: "filterByTimeOfDay(metric1,'03:00','05:00','America/New_York')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2, 3, 4, 5, 6}, 3600, dstDay)},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("filterByTimeOfDay(metric1,'03:00','05:00')", []float64{nan, nan, 3, 4, nan, nan}, 3600, dstDay).SetTag("filterByTimeOfDay", "03:00-05:00"),
+			},
+		},
+		{
+			Target: "filterByTimeOfDay(metric1,'23:00','01:00',tz='UTC')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2, 3, 4}, 3600, 79200)},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("filterByTimeOfDay(metric1,'23:00','01:00')", []float64{nan, 2, 3, nan}, 3600, 79200).SetTag("filterByTimeOfDay", "23:00-01:00"),
+			},
+		},
+		{
+			Target: "filterByWeekday(metric1,'sat,sun','America/New_York')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2, 3, 4}, 86400, thursdayEvening)},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("filterByWeekday(metric1,'sat,sun')", []float64{nan, nan, 3, 4}, 86400, thursdayEvening).SetTag("filterByWeekday", "sat,sun"),
+			},
+		},
+		{
+			Target: "filterByWeekday(metric1,'fri-mon','UTC')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2, 3, 4, 5}, 86400, thursdayEvening)},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("filterByWeekday(metric1,'fri-mon')", []float64{1, 2, 3, 4, nan}, 86400, thursdayEvening).SetTag("filterByWeekday", "fri-mon"),
+			},
+		},
+		{
+			// profile is built by local hour, which shifts after DST starts
+			Target: "aggregateByTimeOfDay(metric1,'avg','1h','America/New_York')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2, 3, 4, 5, 6, 7, 8}, 6*3600, dstEve)},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("aggregateByTimeOfDay(metric1,'avg')", []float64{3, 2, 3, 4, 3, 6, 7, 8}, 6*3600, dstEve).SetTag("aggregateByTimeOfDay", "avg"),
+			},
+		},
+		{
+			Target: "aggregateByTimeOfDay(metric1,'max','6h','UTC')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2, nan, 4, 5, 6, nan, 3}, 6*3600, 0)},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("aggregateByTimeOfDay(metric1,'max')", []float64{5, 6, nan, 4, 5, 6, nan, 4}, 6*3600, 0).SetTag("aggregateByTimeOfDay", "max"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		testName := tt.Target
+		t.Run(testName, func(t *testing.T) {
+			eval := th.EvaluatorFromFunc(md[0].F)
+			th.TestEvalExpr(t, eval, &tt)
+		})
+	}
+}
+
+func TestHolidayMask(t *testing.T) {
+	nan := math.NaN()
+	f := newWithConfig(t)
+
+	tt := th.EvalTestItem{
+		Target: "holidayMask(metric1,'us','America/New_York')",
+		M: map[parser.MetricRequest][]*types.MetricData{
+			{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2, 3, 4, 5}, 6*3600, independenceDayEve)},
+		},
+		Want: []*types.MetricData{
+			types.MakeMetricData("holidayMask(metric1,'us')", []float64{1, nan, nan, nan, nan}, 6*3600, independenceDayEve).SetTag("holidayMask", "us"),
+		},
+	}
+	eval := th.EvaluatorFromFunc(f)
+	th.TestEvalExpr(t, eval, &tt)
+}
+
+func TestCalendarErrors(t *testing.T) {
+	m := map[parser.MetricRequest][]*types.MetricData{
+		{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2}, 1, 0)},
+	}
+	tests := []th.EvalTestItemWithError{
+		{
+			Target: "filterByTimeOfDay(metric1,'09:00','18:00','Mars/Olympus_Mons')",
+			M:      m,
+			Error:  parser.ErrInvalidArg,
+		},
+		{
+			Target: "filterByTimeOfDay(metric1,'25:00')",
+			M:      m,
+			Error:  parser.ErrInvalidArg,
+		},
+		{
+			Target: "filterByWeekday(metric1,'mon-funday')",
+			M:      m,
+			Error:  parser.ErrInvalidArg,
+		},
+		{
+			Target: "holidayMask(metric1,'unknown')",
+			M:      m,
+			Error:  parser.ErrInvalidArg,
+		},
+		{
+			Target: "aggregateByTimeOfDay(metric1,'avg','2d')",
+			M:      m,
+			Error:  parser.ErrInvalidArg,
+		},
+	}
+
+	for _, tt := range tests {
+		testName := tt.Target
+		t.Run(testName, func(t *testing.T) {
+			eval := th.EvaluatorFromFunc(md[0].F)
+			th.TestEvalExprWithError(t, eval, &tt)
+		})
+	}
+}
+
+func TestGetTimeZone(t *testing.T) {
+	e, _, err := parser.ParseExpr("filterByTimeOfDay(metric1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	requestTZ, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tz, err := getTimeZone(utilctx.SetTimeZone(context.Background(), requestTZ), e, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tz != requestTZ {
+		t.Errorf("expected request time zone %s, got %s", requestTZ, tz)
+	}
+}
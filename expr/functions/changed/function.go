@@ -36,7 +36,7 @@ func (f *changed) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Ex
 	result := make([]*types.MetricData, len(args))
 	for i, a := range args {
 		r := a.CopyTag(e.Target()+"("+a.Name+")", a.Tags)
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 
 		prev := math.NaN()
 		for i, v := range a.Values {
@@ -78,7 +78,7 @@ func (f *compressPeriodicGaps) Do(ctx context.Context, eval interfaces.Evaluator
 			r.Name = name
 			r.StepTime = int64(interval)
 			r.StartTime = newStart
-			r.Values = make([]float64, buckets)
+			r.Values = r.NewValues(int(buckets))
 
 			for _, v := range a.Values {
 				intervalItems++
@@ -2,6 +2,7 @@ package consolidateBy
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-graphite/carbonapi/expr/consolidations"
 	"github.com/go-graphite/carbonapi/expr/helper"
@@ -65,6 +66,18 @@ func (f *consolidateBy) Do(ctx context.Context, eval interfaces.Evaluator, e par
 	return results, nil
 }
 
+// AdjustMetrics passes consolidation function to the backends
+func (f *consolidateBy) AdjustMetrics(e parser.Expr, _, _ int64, _ *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	function, err := e.GetStringArg(1)
+	if err != nil {
+		return r
+	}
+	for i := range r {
+		r[i].ConsolidationFunc = function
+	}
+	return r
+}
+
 // Description is auto-generated description, based on output of https://github.com/graphite-project/graphite-web
 func (f *consolidateBy) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
@@ -56,7 +56,7 @@ func (f *divideSeries) Do(ctx context.Context, eval interfaces.Evaluator, e pars
 			results := make([]*types.MetricData, 0, len(numerators))
 			for _, numerator := range numerators {
 				r := numerator.CopyLink()
-				r.Values = make([]float64, len(numerator.Values))
+				r.Values = r.NewValues(len(numerator.Values))
 				r.Name = fmt.Sprintf("divideSeries(%s,MISSING)", numerator.Name)
 				for i := range numerator.Values {
 					r.Values[i] = math.NaN()
@@ -89,7 +89,7 @@ func (f *divideSeries) Do(ctx context.Context, eval interfaces.Evaluator, e pars
 		numerator, denominator = helper.ConsolidateSeriesByStep(numerator, denominator)
 
 		r := numerator.CopyTag(name, numerator.Tags)
-		r.Values = make([]float64, len(numerator.Values))
+		r.Values = r.NewValues(len(numerator.Values))
 
 		for i, v := range numerator.Values {
 			// math.IsNaN(v) || math.IsNaN(denominator.Values[i]) covered by nature of math.NaN
@@ -52,7 +52,7 @@ func (f *ewma) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr,
 		name := "ewma(" + a.Name + "," + alphaStr + ")"
 
 		r := a.CopyTag(name, a.Tags)
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 
 		ewma := onlinestats.NewExpWeight(alpha)
 
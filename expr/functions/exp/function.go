@@ -36,7 +36,7 @@ func (f *exp) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr,
 	for _, a := range args {
 		r := a.CopyLink()
 		r.Name = "exp(" + a.Name + ")"
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["exp"] = "e"
 
 		for i, v := range a.Values {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"time"
 
 	"github.com/go-graphite/carbonapi/expr/consolidations"
 	"github.com/go-graphite/carbonapi/expr/helper"
@@ -71,18 +72,18 @@ func (f *exponentialMovingAverage) Do(ctx context.Context, eval interfaces.Evalu
 	case parser.EtString:
 		// When the window is a string, we already adjusted the fetch request using the preview window.
 		// No need to refetch.
-		var n32 int32
-		n32, err = e.GetIntervalArg(1, 1)
+		var n int64
+		n, err = e.GetIntervalArg(1, 1)
 		if err != nil {
 			return nil, err
 		}
 		argstr = strconv.Quote(e.Arg(1).StringValue())
-		previewSeconds = int(n32)
+		previewSeconds = int(n)
 		if previewSeconds < 0 {
 			// we only care about the absolute value
 			previewSeconds = previewSeconds * -1
 		}
-		constant = float64(2 / (float64(previewSeconds) + 1))
+		constant = float64(2 / (float64(previewSeconds)/float64(e.TimeUnit()) + 1))
 
 	default:
 		return nil, parser.ErrBadType
@@ -140,6 +141,23 @@ func (f *exponentialMovingAverage) Do(ctx context.Context, eval interfaces.Evalu
 	return results, nil
 }
 
+// AdjustMetrics moves start of the requests back by the window size, if it's an interval
+func (f *exponentialMovingAverage) AdjustMetrics(e parser.Expr, _, _ int64, _ *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	if len(e.Args()) < 2 {
+		return nil
+	}
+	if e.Arg(1).Type() == parser.EtString {
+		offs, err := e.GetIntervalArg(1, 1)
+		if err != nil {
+			return nil
+		}
+		for i := range r {
+			r[i].From -= offs
+		}
+	}
+	return r
+}
+
 func (f *exponentialMovingAverage) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
 		"exponentialMovingAverage": {
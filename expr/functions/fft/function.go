@@ -29,7 +29,7 @@ func New(configFile string) []interfaces.FunctionMetadata {
 
 func extractComponent(m *types.MetricData, values []complex128, t string, f func(x complex128) float64) *types.MetricData {
 	r := m.CopyTag("fft("+m.Name+","+t+")", m.Tags)
-	r.Values = make([]float64, len(values))
+	r.Values = r.NewValues(len(values))
 	for i, v := range values {
 		r.Values[i] = f(v)
 	}
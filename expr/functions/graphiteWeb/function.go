@@ -432,7 +432,6 @@ func (f *graphiteWeb) Do(ctx context.Context, eval interfaces.Evaluator, e parse
 			StartTime:         int64(m.Datapoints[0][1]),
 			StopTime:          int64(m.Datapoints[len(m.Datapoints)-1][1]),
 			StepTime:          stepTime,
-			Values:            make([]float64, len(m.Datapoints)),
 			XFilesFactor:      m.XFilesFactor,
 			PathExpression:    string(m.PathExpression),
 			ConsolidationFunc: m.ConsolidationFunc,
@@ -449,13 +448,17 @@ func (f *graphiteWeb) Do(ctx context.Context, eval interfaces.Evaluator, e parse
 			tags[tag] = value
 		}
 
-		for i, v := range m.Datapoints {
-			pbResp.Values[i] = v[0]
-		}
-		res = append(res, &types.MetricData{
+		r := &types.MetricData{
 			FetchResponse: pbResp,
 			Tags:          tags,
-		})
+		}
+		if err := r.MakeValues(ctx, len(m.Datapoints)); err != nil {
+			return nil, err
+		}
+		for i, v := range m.Datapoints {
+			r.Values[i] = v[0]
+		}
+		res = append(res, r)
 	}
 
 	return res, nil
@@ -93,6 +93,7 @@ func (f *groupByNode) Do(ctx context.Context, eval interfaces.Evaluator, e parse
 			err = merry.WithMessagef(parser.ErrInvalidArg, "unsupported "+target+" callback function")
 			return nil, err
 		}
+		nexpr.SetTimeUnit(e.TimeUnit())
 		// remove all stub_ prefixes we've prepended before
 		nexpr.SetRawArgs(strings.Replace(nexpr.RawArgs(), "stub_", "", 1))
 		for argIdx := range nexpr.Args() {
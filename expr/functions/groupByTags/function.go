@@ -119,6 +119,7 @@ func (f *groupByTags) Do(ctx context.Context, eval interfaces.Evaluator, e parse
 		if err != nil {
 			return nil, err
 		}
+		nexpr.SetTimeUnit(e.TimeUnit())
 
 		nvalues := map[parser.MetricRequest][]*types.MetricData{
 			{Metric: "stub", From: from, Until: until}: v,
@@ -44,14 +44,17 @@ func (f *heatMap) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Ex
 		pointsQty := len(curr.Values)
 		r := &types.MetricData{
 			FetchResponse: pb.FetchResponse{
-				Name:      "heatMap(" + curr.Name + "," + prev.Name + ")",
-				Values:    make([]float64, pointsQty),
-				StartTime: curr.StartTime,
-				StopTime:  curr.StopTime,
-				StepTime:  curr.StepTime,
+				Name:                    "heatMap(" + curr.Name + "," + prev.Name + ")",
+				StartTime:               curr.StartTime,
+				StopTime:                curr.StopTime,
+				StepTime:                curr.StepTime,
+				HighPrecisionTimestamps: curr.HighPrecisionTimestamps,
 			},
 			Tags: curr.Tags,
 		}
+		if err := r.MakeValues(ctx, pointsQty); err != nil {
+			return nil, err
+		}
 
 		for j := 0; j < pointsQty; j++ {
 			if math.IsNaN(curr.Values[j]) || math.IsNaN(prev.Values[j]) {
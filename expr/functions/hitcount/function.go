@@ -5,6 +5,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-graphite/carbonapi/expr/helper"
 	"github.com/go-graphite/carbonapi/expr/interfaces"
@@ -36,11 +37,12 @@ func (f *hitcount) Do(ctx context.Context, eval interfaces.Evaluator, e parser.E
 		return nil, parser.ErrMissingArgument
 	}
 
-	bucketSizeInt32, err := e.GetIntervalArg(1, 1)
+	interval, err := e.GetIntervalArg(1, 1)
 	if err != nil {
 		return nil, err
 	}
-	interval := int64(bucketSizeInt32)
+	// hits are counted per second
+	unit := float64(e.TimeUnit())
 
 	alignToInterval, err := e.GetBoolNamedOrPosArgDefault("alignToInterval", 2, false)
 	if err != nil {
@@ -49,7 +51,7 @@ func (f *hitcount) Do(ctx context.Context, eval interfaces.Evaluator, e parser.E
 
 	if alignToInterval {
 		// from needs to be adjusted before grabbing the series arg as it has been adjusted in the metric request
-		from = helper.AlignStartToInterval(from, until, interval)
+		from = helper.AlignStartToInterval(from, until, interval, e.TimeUnit())
 	}
 
 	args, err := helper.GetSeriesArg(ctx, eval, e.Arg(0), from, until, values)
@@ -64,7 +66,7 @@ func (f *hitcount) Do(ctx context.Context, eval interfaces.Evaluator, e parser.E
 	start := args[0].StartTime
 	stop := args[0].StopTime
 
-	// Note: the start time for the fetch request is adjusted in expr.Metrics() so that the fetched
+	// Note: the start time for the fetch request is adjusted in AdjustMetrics() so that the fetched
 	// data is already aligned by interval if this parameter is set to true
 	if alignToInterval {
 		intervalCount := (stop - start) / interval
@@ -91,14 +93,15 @@ func (f *hitcount) Do(ctx context.Context, eval interfaces.Evaluator, e parser.E
 
 		r := &types.MetricData{
 			FetchResponse: pb.FetchResponse{
-				Name:      nameBuf.String(),
-				StepTime:  interval,
-				StartTime: start,
-				StopTime:  stop,
+				Name:                    nameBuf.String(),
+				StepTime:                interval,
+				StartTime:               start,
+				StopTime:                stop,
+				HighPrecisionTimestamps: arg.HighPrecisionTimestamps,
 			},
 			Tags: helper.CopyTags(arg),
 		}
-		r.Tags["hitcount"] = strconv.FormatInt(int64(bucketSizeInt32), 10)
+		r.Tags["hitcount"] = strconv.FormatInt(interval, 10)
 
 		step := arg.StepTime
 		buckets := make([][]float64, bucketCount)
@@ -123,23 +126,25 @@ func (f *hitcount) Do(ctx context.Context, eval interfaces.Evaluator, e parser.E
 			if startBucket == endBucket {
 				// All hits go into a single bucket
 				if startBucket >= 0 {
-					buckets[startBucket] = append(buckets[startBucket], v*float64(endMod-startMod))
+					buckets[startBucket] = append(buckets[startBucket], v*float64(endMod-startMod)/unit)
 				}
 			} else {
 				// Spread the hits amongst 2 or more buckets
 				if startBucket >= 0 {
-					buckets[startBucket] = append(buckets[startBucket], v*float64(interval-startMod))
+					buckets[startBucket] = append(buckets[startBucket], v*float64(interval-startMod)/unit)
 				}
-				hitsPerBucket := v * float64(interval)
+				hitsPerBucket := v * float64(interval) / unit
 				for j := startBucket + 1; j < endBucket; j++ {
 					buckets[j] = append(buckets[j], hitsPerBucket)
 				}
 				if endMod > 0 {
-					buckets[endBucket] = append(buckets[endBucket], v*float64(endMod))
+					buckets[endBucket] = append(buckets[endBucket], v*float64(endMod)/unit)
 				}
 			}
 		}
-		r.Values = make([]float64, len(buckets))
+		if err := r.MakeValues(ctx, len(buckets)); err != nil {
+			return nil, err
+		}
 		for i, bucket := range buckets {
 			if len(bucket) != 0 {
 				var sum float64
@@ -157,6 +162,33 @@ func (f *hitcount) Do(ctx context.Context, eval interfaces.Evaluator, e parser.E
 	return results, nil
 }
 
+// AdjustMetrics aligns start of the requests when alignToInterval is set
+func (f *hitcount) AdjustMetrics(e parser.Expr, _, _ int64, _ *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	if len(e.Args()) < 2 {
+		return nil
+	}
+
+	alignToInterval, err := e.GetBoolNamedOrPosArgDefault("alignToInterval", 2, false)
+	if err != nil {
+		return nil
+	}
+	if !alignToInterval {
+		return r
+	}
+
+	interval, err := e.GetIntervalArg(1, 1)
+	if err != nil {
+		return nil
+	}
+
+	// This is done in order to replicate the behavior in Graphite web when alignToInterval is set,
+	// in which new data is fetched with the adjusted start time.
+	for i := range r {
+		r[i].From = helper.AlignStartToInterval(r[i].From, r[i].Until, interval, e.TimeUnit())
+	}
+	return r
+}
+
 // Description is auto-generated description, based on output of https://github.com/graphite-project/graphite-web
 func (f *hitcount) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
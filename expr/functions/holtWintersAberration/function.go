@@ -3,6 +3,7 @@ package holtWintersAberration
 import (
 	"context"
 	"math"
+	"time"
 
 	"github.com/go-graphite/carbonapi/expr/helper"
 	"github.com/go-graphite/carbonapi/expr/holtwinters"
@@ -39,9 +40,8 @@ func (f *holtWintersAberration) Do(ctx context.Context, eval interfaces.Evaluato
 		return nil, err
 	}
 
-	// Note: additional fetch requests are added with an adjusted start time in expr.Metrics() (in
-	// pkg/parser/parser.go) so that the appropriate data corresponding to the adjusted start time
-	// can be pre-fetched.
+	// Note: additional fetch requests are added with an adjusted start time in AdjustMetrics()
+	// so that the appropriate data corresponding to the adjusted start time can be pre-fetched.
 	adjustedStartArgs, err := helper.GetSeriesArg(ctx, eval, e.Arg(0), from-bootstrapInterval, until, values)
 	if err != nil {
 		return nil, err
@@ -91,14 +91,15 @@ func (f *holtWintersAberration) Do(ctx context.Context, eval interfaces.Evaluato
 		name := "holtWintersAberration(" + arg.Name + ")"
 		r := types.MetricData{
 			FetchResponse: pb.FetchResponse{
-				Name:              name,
-				Values:            aberration,
-				StepTime:          arg.StepTime,
-				StartTime:         arg.StartTime,
-				StopTime:          arg.StopTime,
-				PathExpression:    name,
-				ConsolidationFunc: arg.ConsolidationFunc,
-				XFilesFactor:      arg.XFilesFactor,
+				Name:                    name,
+				Values:                  aberration,
+				StepTime:                arg.StepTime,
+				StartTime:               arg.StartTime,
+				StopTime:                arg.StopTime,
+				PathExpression:          name,
+				ConsolidationFunc:       arg.ConsolidationFunc,
+				HighPrecisionTimestamps: arg.HighPrecisionTimestamps,
+				XFilesFactor:            arg.XFilesFactor,
 			},
 			Tags: helper.CopyTags(arg),
 		}
@@ -108,6 +109,23 @@ func (f *holtWintersAberration) Do(ctx context.Context, eval interfaces.Evaluato
 	return results, nil
 }
 
+// AdjustMetrics adds requests with start moved back by bootstrapInterval, original requests are kept
+func (f *holtWintersAberration) AdjustMetrics(e parser.Expr, _, _ int64, _ *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	bootstrapInterval, err := e.GetIntervalNamedOrPosArgDefault("bootstrapInterval", 2, 1, holtwinters.DefaultBootstrapInterval)
+	if err != nil {
+		return nil
+	}
+
+	for i := range r {
+		r = append(r, parser.MetricRequest{
+			Metric: r[i].Metric,
+			From:   r[i].From - bootstrapInterval,
+			Until:  r[i].Until,
+		})
+	}
+	return r
+}
+
 // Description is auto-generated description, based on output of https://github.com/graphite-project/graphite-web
 func (f *holtWintersAberration) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
@@ -6,7 +6,9 @@ package holtWintersConfidenceArea
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/go-graphite/carbonapi/expr/holtwinters"
 	"github.com/go-graphite/carbonapi/expr/interfaces"
 	"github.com/go-graphite/carbonapi/expr/types"
 	"github.com/go-graphite/carbonapi/pkg/parser"
@@ -36,6 +38,19 @@ func (f *holtWintersConfidenceArea) Do(_ context.Context, _ interfaces.Evaluator
 	return nil, UnsupportedError
 }
 
+// AdjustMetrics moves start of the requests back by bootstrapInterval, same as holtWintersConfidenceBands does
+func (f *holtWintersConfidenceArea) AdjustMetrics(e parser.Expr, _, _ int64, _ *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	bootstrapInterval, err := e.GetIntervalNamedOrPosArgDefault("bootstrapInterval", 2, 1, holtwinters.DefaultBootstrapInterval)
+	if err != nil {
+		return nil
+	}
+
+	for i := range r {
+		r[i].From -= bootstrapInterval
+	}
+	return r
+}
+
 // Description is auto-generated description, based on output of https://github.com/graphite-project/graphite-web
 func (f *holtWintersConfidenceArea) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
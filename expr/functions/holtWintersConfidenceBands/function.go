@@ -2,6 +2,7 @@ package holtWintersConfidenceBands
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-graphite/carbonapi/expr/helper"
 	"github.com/go-graphite/carbonapi/expr/holtwinters"
@@ -57,14 +58,15 @@ func (f *holtWintersConfidenceBands) Do(ctx context.Context, eval interfaces.Eva
 		name := "holtWintersConfidenceLower(" + arg.Name + ")"
 		lowerSeries := &types.MetricData{
 			FetchResponse: pb.FetchResponse{
-				Name:              name,
-				Values:            lowerBand,
-				StepTime:          arg.StepTime,
-				StartTime:         arg.StartTime + bootstrapInterval,
-				StopTime:          arg.StopTime,
-				ConsolidationFunc: arg.ConsolidationFunc,
-				XFilesFactor:      arg.XFilesFactor,
-				PathExpression:    name,
+				Name:                    name,
+				Values:                  lowerBand,
+				StepTime:                arg.StepTime,
+				StartTime:               arg.StartTime + bootstrapInterval,
+				StopTime:                arg.StopTime,
+				ConsolidationFunc:       arg.ConsolidationFunc,
+				HighPrecisionTimestamps: arg.HighPrecisionTimestamps,
+				XFilesFactor:            arg.XFilesFactor,
+				PathExpression:          name,
 			},
 			Tags: helper.CopyTags(arg),
 		}
@@ -73,14 +75,15 @@ func (f *holtWintersConfidenceBands) Do(ctx context.Context, eval interfaces.Eva
 		name = "holtWintersConfidenceUpper(" + arg.Name + ")"
 		upperSeries := &types.MetricData{
 			FetchResponse: pb.FetchResponse{
-				Name:              name,
-				Values:            upperBand,
-				StepTime:          arg.StepTime,
-				StartTime:         arg.StartTime + bootstrapInterval,
-				StopTime:          arg.StopTime,
-				ConsolidationFunc: arg.ConsolidationFunc,
-				XFilesFactor:      arg.XFilesFactor,
-				PathExpression:    name,
+				Name:                    name,
+				Values:                  upperBand,
+				StepTime:                arg.StepTime,
+				StartTime:               arg.StartTime + bootstrapInterval,
+				StopTime:                arg.StopTime,
+				ConsolidationFunc:       arg.ConsolidationFunc,
+				HighPrecisionTimestamps: arg.HighPrecisionTimestamps,
+				XFilesFactor:            arg.XFilesFactor,
+				PathExpression:          name,
 			},
 			Tags: helper.CopyTags(arg),
 		}
@@ -91,6 +94,19 @@ func (f *holtWintersConfidenceBands) Do(ctx context.Context, eval interfaces.Eva
 	return results, nil
 }
 
+// AdjustMetrics moves start of the requests back by bootstrapInterval
+func (f *holtWintersConfidenceBands) AdjustMetrics(e parser.Expr, _, _ int64, _ *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	bootstrapInterval, err := e.GetIntervalNamedOrPosArgDefault("bootstrapInterval", 2, 1, holtwinters.DefaultBootstrapInterval)
+	if err != nil {
+		return nil
+	}
+
+	for i := range r {
+		r[i].From -= bootstrapInterval
+	}
+	return r
+}
+
 // Description is auto-generated description, based on output of https://github.com/graphite-project/graphite-web
 func (f *holtWintersConfidenceBands) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
@@ -2,6 +2,7 @@ package holtWintersForecast
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-graphite/carbonapi/expr/helper"
 	"github.com/go-graphite/carbonapi/expr/holtwinters"
@@ -60,14 +61,15 @@ func (f *holtWintersForecast) Do(ctx context.Context, eval interfaces.Evaluator,
 		name := "holtWintersForecast(" + arg.Name + ")"
 		r := &types.MetricData{
 			FetchResponse: pb.FetchResponse{
-				Name:              name,
-				Values:            predictionsOfInterest,
-				StepTime:          arg.StepTime,
-				StartTime:         arg.StartTime + bootstrapInterval,
-				StopTime:          arg.StopTime,
-				PathExpression:    name,
-				XFilesFactor:      arg.XFilesFactor,
-				ConsolidationFunc: arg.ConsolidationFunc,
+				Name:                    name,
+				Values:                  predictionsOfInterest,
+				StepTime:                arg.StepTime,
+				StartTime:               arg.StartTime + bootstrapInterval,
+				StopTime:                arg.StopTime,
+				PathExpression:          name,
+				XFilesFactor:            arg.XFilesFactor,
+				ConsolidationFunc:       arg.ConsolidationFunc,
+				HighPrecisionTimestamps: arg.HighPrecisionTimestamps,
 			},
 			Tags: helper.CopyTags(arg),
 		}
@@ -77,6 +79,19 @@ func (f *holtWintersForecast) Do(ctx context.Context, eval interfaces.Evaluator,
 	return results, nil
 }
 
+// AdjustMetrics moves start of the requests back by bootstrapInterval
+func (f *holtWintersForecast) AdjustMetrics(e parser.Expr, _, _ int64, _ *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	bootstrapInterval, err := e.GetIntervalNamedOrPosArgDefault("bootstrapInterval", 1, 1, holtwinters.DefaultBootstrapInterval)
+	if err != nil {
+		return nil
+	}
+
+	for i := range r {
+		r[i].From -= bootstrapInterval
+	}
+	return r
+}
+
 // Description is auto-generated description, based on output of https://github.com/graphite-project/graphite-web
 func (f *holtWintersForecast) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
@@ -32,14 +32,8 @@ func (f *identity) Do(ctx context.Context, eval interfaces.Evaluator, e parser.E
 		return nil, err
 	}
 
-	step := int64(60)
-
-	newValues := make([]float64, (until-from-1+step)/step)
-	value := from
-	for i := 0; i < len(newValues); i++ {
-		newValues[i] = float64(value)
-		value += step
-	}
+	unit := e.TimeUnit()
+	step := 60 * unit
 
 	p := types.MetricData{
 		FetchResponse: pb.FetchResponse{
@@ -47,10 +41,18 @@ func (f *identity) Do(ctx context.Context, eval interfaces.Evaluator, e parser.E
 			StartTime: from,
 			StopTime:  until,
 			StepTime:  step,
-			Values:    newValues,
 		},
 		Tags: map[string]string{"name": name},
 	}
+	if err := p.MakeValues(ctx, int((until-from-1+step)/step)); err != nil {
+		return nil, err
+	}
+
+	value := from
+	for i := 0; i < len(p.Values); i++ {
+		p.Values[i] = float64(value) / float64(unit)
+		value += step
+	}
 
 	return []*types.MetricData{&p}, nil
 
@@ -46,7 +46,7 @@ func (f *ifft) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr,
 	results := make([]*types.MetricData, len(absSeriesList))
 	for j, a := range absSeriesList {
 		r := a.CopyLinkTags()
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		if len(phaseSeriesList) > j {
 			p := phaseSeriesList[j]
 			r.Name = "ifft(" + a.Name + "," + p.Name + ")"
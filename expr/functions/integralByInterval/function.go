@@ -40,11 +40,10 @@ func (f *integralByInterval) Do(ctx context.Context, eval interfaces.Evaluator,
 		return nil, nil
 	}
 
-	bucketSizeInt32, err := e.GetIntervalArg(1, 1)
+	bucketSize, err := e.GetIntervalArg(1, 1)
 	if err != nil {
 		return nil, err
 	}
-	bucketSize := int64(bucketSizeInt32)
 	intervalString, err := e.GetStringArg(1)
 	if err != nil {
 		return nil, err
@@ -60,7 +59,7 @@ func (f *integralByInterval) Do(ctx context.Context, eval interfaces.Evaluator,
 		result := arg.CopyLink()
 		result.Name = name
 		result.PathExpression = name
-		result.Values = make([]float64, len(arg.Values))
+		result.Values = result.NewValues(len(arg.Values))
 
 		result.Tags["integralByInterval"] = intervalString
 
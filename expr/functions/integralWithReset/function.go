@@ -53,7 +53,7 @@ func (f *integralWithReset) Do(ctx context.Context, eval interfaces.Evaluator, e
 	for i, a := range arg {
 		r := a.CopyLinkTags()
 		r.Name = "integralWithReset(" + a.Name + "," + resettingSeries.Name + ")"
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 
 		current := 0.0
 		for i, v := range a.Values {
@@ -40,7 +40,7 @@ func (f *interpolate) Do(ctx context.Context, eval interfaces.Evaluator, e parse
 		resultSeries := series.CopyLinkTags()
 		resultSeries.Name = "interpolate(" + series.Name + ")"
 
-		resultSeries.Values = make([]float64, pointsQty)
+		resultSeries.Values = resultSeries.NewValues(pointsQty)
 		copy(resultSeries.Values, series.Values)
 
 		consecutiveNulls := 0
@@ -61,7 +61,7 @@ func (f *keepLastValue) Do(ctx context.Context, eval interfaces.Evaluator, e par
 
 		r := a.CopyLinkTags()
 		r.Name = name
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 
 		prev := math.NaN()
 		missing := 0
@@ -58,7 +58,7 @@ func (f *kolmogorovSmirnovTest2) Do(ctx context.Context, eval interfaces.Evaluat
 
 	r := a1.CopyLinkTags()
 	r.Name = "kolmogorovSmirnovTest2(" + a1.Name + "," + a2.Name + "," + windowSizeStr + ")"
-	r.Values = make([]float64, len(a1.Values))
+	r.Values = r.NewValues(len(a1.Values))
 	r.StartTime = from
 	r.StopTime = until
 
@@ -50,7 +50,7 @@ func (f *linearRegression) Do(ctx context.Context, eval interfaces.Evaluator, e
 			r.Name = "linearRegression(" + a.Name + ")"
 		}
 
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.StopTime = a.GetStopTime()
 
 		// Removing absent values from original dataset
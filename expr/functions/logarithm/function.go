@@ -56,7 +56,7 @@ func (f *logarithm) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 
 		r := a.CopyLink()
 		r.Name = name
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["log"] = baseStr
 
 		for i, v := range a.Values {
@@ -39,7 +39,7 @@ func (f *logit) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr
 	for _, a := range arg {
 		r := a.CopyLink()
 		r.Name = fmt.Sprintf("logit(%s)", a.Name)
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["logit"] = "logit"
 
 		for i, v := range a.Values {
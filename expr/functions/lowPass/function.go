@@ -44,7 +44,7 @@ func (f *lowPass) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Ex
 	for j, a := range arg {
 		r := a.CopyLinkTags()
 		r.Name = "lowPass(" + a.Name + "," + cutPercentStr + ")"
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		lowCut := int((cutPercent / 200) * float64(len(a.Values)))
 		highCut := len(a.Values) - lowCut
 		for i, v := range a.Values {
@@ -40,7 +40,7 @@ func (f *minMax) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Exp
 	for _, a := range arg {
 		r := a.CopyLinkTags()
 		r.Name = fmt.Sprintf("minMax(%s)", a.Name)
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 
 		min := consolidations.MinValue(a.Values)
 		if math.IsInf(min, 1) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"math"
 	"strconv"
+	"time"
 
 	"github.com/lomik/zapwriter"
 	"github.com/spf13/viper"
@@ -108,10 +109,10 @@ func (f *moving) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Exp
 			refetch = true
 		}
 	case parser.EtString:
-		var n32 int32
-		n32, err = e.GetIntervalArg(1, 1)
+		var interval int64
+		interval, err = e.GetIntervalArg(1, 1)
 		argstr = "'" + e.Arg(1).StringValue() + "'"
-		preview = int64(math.Abs(float64(n32))) // Absolute is used in order to handle negative string intervals
+		preview = int64(math.Abs(float64(interval))) // Absolute is used in order to handle negative string intervals
 		adjustedStart -= preview
 	default:
 		err = parser.ErrBadType
@@ -186,7 +187,7 @@ func (f *moving) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Exp
 
 		if windowPoints == 0 {
 			if *f.config.ReturnNaNsIfStepMismatch {
-				r.Values = make([]float64, len(a.Values))
+				r.Values = r.NewValues(len(a.Values))
 				for i := range a.Values {
 					r.Values[i] = math.NaN()
 				}
@@ -201,7 +202,7 @@ func (f *moving) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Exp
 		if size < 0 {
 			size = 0
 		}
-		r.Values = make([]float64, size)
+		r.Values = r.NewValues(size)
 		r.StartTime = a.StartTime + preview
 		r.StopTime = r.StartTime + int64(len(r.Values))*r.StepTime
 
@@ -252,6 +253,23 @@ func (f *moving) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Exp
 	return result, nil
 }
 
+// AdjustMetrics moves start of the requests back by the window size, if it's an interval
+func (f *moving) AdjustMetrics(e parser.Expr, _, _ int64, _ *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	if len(e.Args()) < 2 {
+		return nil
+	}
+	if e.Arg(1).Type() == parser.EtString {
+		offs, err := e.GetIntervalArg(1, 1)
+		if err != nil {
+			return nil
+		}
+		for i := range r {
+			r[i].From -= offs
+		}
+	}
+	return r
+}
+
 // Description is auto-generated description, based on output of https://github.com/graphite-project/graphite-web
 func (f *moving) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
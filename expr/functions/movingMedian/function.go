@@ -4,6 +4,7 @@ import (
 	"context"
 	"math"
 	"strconv"
+	"time"
 
 	"github.com/JaderDias/movingmedian"
 	"github.com/lomik/zapwriter"
@@ -81,9 +82,9 @@ func (f *movingMedian) Do(ctx context.Context, eval interfaces.Evaluator, e pars
 		n, err = e.GetIntArg(1)
 		argstr = strconv.Itoa(n)
 	case parser.EtString:
-		var n32 int32
-		n32, err = e.GetIntervalArg(1, 1)
-		n = int(n32)
+		var interval int64
+		interval, err = e.GetIntervalArg(1, 1)
+		n = int(interval)
 		argstr = "'" + e.Arg(1).StringValue() + "'"
 		scaleByStep = true
 	default:
@@ -123,13 +124,13 @@ func (f *movingMedian) Do(ctx context.Context, eval interfaces.Evaluator, e pars
 
 		if windowSize == 0 {
 			if *f.config.ReturnNaNsIfStepMismatch {
-				r.Values = make([]float64, len(a.Values))
+				r.Values = r.NewValues(len(a.Values))
 				for i := range a.Values {
 					r.Values[i] = math.NaN()
 				}
 			}
 		} else {
-			r.Values = make([]float64, len(a.Values)-offset)
+			r.Values = r.NewValues(len(a.Values) - offset)
 			r.StartTime = (from + r.StepTime - 1) / r.StepTime * r.StepTime // align StartTime to closest >= StepTime
 			r.StopTime = r.StartTime + int64(len(r.Values))*r.StepTime
 
@@ -152,6 +153,23 @@ func (f *movingMedian) Do(ctx context.Context, eval interfaces.Evaluator, e pars
 	return result, nil
 }
 
+// AdjustMetrics moves start of the requests back by the window size, if it's an interval
+func (f *movingMedian) AdjustMetrics(e parser.Expr, _, _ int64, _ *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	if len(e.Args()) < 2 {
+		return nil
+	}
+	if e.Arg(1).Type() == parser.EtString {
+		offs, err := e.GetIntervalArg(1, 1)
+		if err != nil {
+			return nil
+		}
+		for i := range r {
+			r[i].From -= offs
+		}
+	}
+	return r
+}
+
 // Description is auto-generated description, based on output of https://github.com/graphite-project/graphite-web
 func (f *movingMedian) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
@@ -48,7 +48,7 @@ func (f *nPercentile) Do(ctx context.Context, eval interfaces.Evaluator, e parse
 	for i, a := range arg {
 		r := a.CopyLink()
 		r.Name = "nPercentile(" + a.Name + "," + percentStr + ")"
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["nPercentile"] = percentStr
 		var values []float64
 		for _, v := range a.Values {
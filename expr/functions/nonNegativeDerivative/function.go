@@ -85,7 +85,7 @@ func (f *nonNegativeDerivative) Do(ctx context.Context, eval interfaces.Evaluato
 
 		r := a.CopyLink()
 		r.Name = name
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["nonNegativeDerivative"] = "1"
 		result[i] = r
 
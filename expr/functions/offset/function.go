@@ -46,7 +46,7 @@ func (f *offset) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Exp
 	for i, a := range arg {
 		r := a.CopyLink()
 		r.Name = e.Target() + "(" + a.Name + "," + factorStr + ")"
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags[e.Target()] = factorStr
 
 		for i, v := range a.Values {
@@ -56,7 +56,7 @@ func (f *pearson) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Ex
 
 	r := a1.CopyLinkTags()
 	r.Name = "pearson(" + a1.Name + "," + a2.Name + "," + e.Arg(2).StringValue() + ")"
-	r.Values = make([]float64, len(a1.Values))
+	r.Values = r.NewValues(len(a1.Values))
 	r.StartTime = from
 	r.StopTime = r.StartTime + int64(len(r.Values))*r.StepTime
 
@@ -87,7 +87,7 @@ func (f *perSecond) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 
 		r := a.CopyLink()
 		r.Name = name
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["perSecond"] = "1"
 		result[i] = r
 
@@ -95,6 +95,7 @@ func (f *perSecond) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 			continue
 		}
 
+		step := float64(a.StepTime) / float64(e.TimeUnit())
 		prev := a.Values[0]
 		for i, v := range a.Values {
 			if i == 0 || math.IsNaN(a.Values[i]) || math.IsNaN(a.Values[i-1]) {
@@ -105,11 +106,11 @@ func (f *perSecond) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 			// TODO(civil): Figure out if we can optimize this now when we have NaNs
 			diff := v - prev
 			if diff >= 0 {
-				r.Values[i] = diff / float64(a.StepTime)
+				r.Values[i] = diff / step
 			} else if hasMax && maxValue >= v {
-				r.Values[i] = ((maxValue - prev) + (v - minValue) + 1) / float64(a.StepTime)
+				r.Values[i] = ((maxValue - prev) + (v - minValue) + 1) / step
 			} else if hasMin && minValue <= v {
-				r.Values[i] = (v - minValue) / float64(a.StepTime)
+				r.Values[i] = (v - minValue) / step
 			} else {
 				r.Values[i] = math.NaN()
 			}
@@ -52,7 +52,7 @@ func (f *polyfit) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Ex
 		return nil, err
 	}
 
-	offs, err := parser.IntervalString(offsStr, 1)
+	offs, err := parser.IntervalStringUnit(offsStr, 1, e.TimeUnit())
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +68,7 @@ func (f *polyfit) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Ex
 			r.Name = "polyfit(" + a.Name + ")"
 		}
 		// Extending slice by "offset" so our graph slides into future!
-		r.Values = make([]float64, len(a.Values)+int(offs)/int(r.StepTime))
+		r.Values = r.NewValues(len(a.Values) + int(offs)/int(r.StepTime))
 		r.StopTime = a.StopTime + int64(offs)
 
 		// Removing absent values from original dataset
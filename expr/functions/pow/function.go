@@ -47,7 +47,7 @@ func (f *pow) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr,
 	for j, a := range arg {
 		r := a.CopyLink()
 		r.Name = "pow(" + a.Name + "," + factorStr + ")"
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["pow"] = factorStr
 
 		for i, v := range a.Values {
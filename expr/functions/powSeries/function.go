@@ -45,7 +45,7 @@ func (f *powSeries) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 	}
 
 	r := series[largestSeriesIdx].CopyName("powSeries(" + e.RawArgs() + ")")
-	r.Values = make([]float64, 0)
+	r.Values = r.NewValues(overallLength)[:0]
 
 	seriesValues := make([][]float64, 0, len(series))
 	for _, s := range series {
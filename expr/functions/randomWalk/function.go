@@ -36,7 +36,7 @@ func (f *randomWalk) Do(ctx context.Context, eval interfaces.Evaluator, e parser
 	if err != nil {
 		return nil, err
 	}
-	step := int64(stepInt)
+	step := int64(stepInt) * e.TimeUnit()
 
 	size := (until - from) / step
 	until = from + step*size // Re-compute 'until' in case 'size' is a not a divisor of the range
@@ -44,7 +44,6 @@ func (f *randomWalk) Do(ctx context.Context, eval interfaces.Evaluator, e parser
 	r := types.MetricData{
 		FetchResponse: pb.FetchResponse{
 			Name:              name,
-			Values:            make([]float64, size),
 			StepTime:          step,
 			StartTime:         from,
 			StopTime:          until,
@@ -52,6 +51,9 @@ func (f *randomWalk) Do(ctx context.Context, eval interfaces.Evaluator, e parser
 		},
 		Tags: map[string]string{"name": name},
 	}
+	if err := r.MakeValues(ctx, int(size)); err != nil {
+		return nil, err
+	}
 
 	for i := 1; i < len(r.Values)-1; i++ {
 		r.Values[i+1] = r.Values[i] + (rand.Float64() - 0.5)
@@ -38,7 +38,7 @@ func (f *rangeOfSeries) Do(ctx context.Context, eval interfaces.Evaluator, e par
 
 	r := series[0].CopyLinkTags()
 	r.Name = e.Target() + "(" + e.RawArgs() + ")"
-	r.Values = make([]float64, len(series[0].Values))
+	r.Values = r.NewValues(len(series[0].Values))
 
 	commonTags := helper.GetCommonTags(series)
 
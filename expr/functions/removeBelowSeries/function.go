@@ -69,7 +69,7 @@ func (f *removeBelowSeries) Do(ctx context.Context, eval interfaces.Evaluator, e
 
 		r := a.CopyLink()
 		r.Name = e.Target() + "(" + a.Name + ", " + numberStr + ")"
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["removeBelowSeries"] = strconv.FormatFloat(threshold, 'f', -1, 64)
 
 		for i, v := range a.Values {
@@ -48,7 +48,7 @@ func (f *round) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr
 		} else {
 			r.Name = "round(" + a.Name + ")"
 		}
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 
 		for i, v := range a.Values {
 			r.Values[i] = helper.SafeRound(v, precision)
@@ -54,7 +54,7 @@ func (f *scale) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr
 		} else {
 			r.Name = "scale(" + a.Name + "," + scaleStr + "," + e.Arg(2).StringValue() + ")"
 		}
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["scale"] = scaleStr
 
 		currentTimestamp := a.StartTime
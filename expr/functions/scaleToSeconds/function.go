@@ -47,10 +47,10 @@ func (f *scaleToSeconds) Do(ctx context.Context, eval interfaces.Evaluator, e pa
 	for j, a := range arg {
 		r := a.CopyLink()
 		r.Name = "scaleToSeconds(" + a.Name + "," + secondsStr + ")"
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["scaleToSeconds"] = secondsStr
 
-		factor := seconds / float64(a.StepTime)
+		factor := seconds * float64(e.TimeUnit()) / float64(a.StepTime)
 
 		for i, v := range a.Values {
 			r.Values[i] = v * factor
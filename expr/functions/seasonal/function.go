@@ -0,0 +1,400 @@
+package seasonal
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/ansel1/merry"
+
+	"github.com/go-graphite/carbonapi/expr/helper"
+	"github.com/go-graphite/carbonapi/expr/interfaces"
+	"github.com/go-graphite/carbonapi/expr/stl"
+	"github.com/go-graphite/carbonapi/expr/types"
+	"github.com/go-graphite/carbonapi/pkg/parser"
+	pb "github.com/go-graphite/protocol/carbonapi_v3_pb"
+)
+
+type seasonal struct{}
+
+func GetOrder() interfaces.Order {
+	return interfaces.Any
+}
+
+func New(configFile string) []interfaces.FunctionMetadata {
+	res := make([]interfaces.FunctionMetadata, 0)
+	f := &seasonal{}
+	functions := []string{"stlDecompose", "seasonalZScore", "anomalies"}
+	for _, n := range functions {
+		res = append(res, interfaces.FunctionMetadata{Name: n, F: f})
+	}
+	return res
+}
+
+// getPeriodAndSeasons parses period and seasons arguments, their positions depend on the function.
+func getPeriodAndSeasons(e parser.Expr) (int64, int, error) {
+	periodPos, seasonsPos := 1, 2
+	switch e.Target() {
+	case "stlDecompose":
+		periodPos, seasonsPos = 1, 3
+	case "anomalies":
+		periodPos, seasonsPos = 3, 4
+	}
+
+	period, err := e.GetIntervalNamedOrPosArgDefault("period", periodPos, 1, stl.DefaultPeriod)
+	if err != nil {
+		return 0, 0, err
+	}
+	if period <= 0 {
+		return 0, 0, merry.WithMessagef(parser.ErrInvalidArg, "period must be positive")
+	}
+
+	seasons, err := e.GetIntNamedOrPosArgDefault("seasons", seasonsPos, stl.DefaultSeasons)
+	if err != nil {
+		return 0, 0, err
+	}
+	if seasons < 1 {
+		return 0, 0, merry.WithMessagef(parser.ErrInvalidArg, "seasons must be at least 1")
+	}
+
+	return period, seasons, nil
+}
+
+func (f *seasonal) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr, from, until int64, values map[parser.MetricRequest][]*types.MetricData) ([]*types.MetricData, error) {
+	period, seasons, err := getPeriodAndSeasons(e)
+	if err != nil {
+		return nil, err
+	}
+	bootstrapInterval := period * int64(seasons)
+
+	var (
+		component   string
+		method      string
+		sensitivity float64
+	)
+	switch e.Target() {
+	case "stlDecompose":
+		component, err = e.GetStringNamedOrPosArgDefault("component", 2, "trend")
+		if err != nil {
+			return nil, err
+		}
+		if component != "trend" && component != "seasonal" && component != "residual" {
+			return nil, merry.WithMessagef(parser.ErrInvalidArg, "unknown component '%s', must be one of trend, seasonal or residual", component)
+		}
+	case "anomalies":
+		method, err = e.GetStringNamedOrPosArgDefault("method", 1, "stl")
+		if err != nil {
+			return nil, err
+		}
+		if method != "stl" && method != "zscore" {
+			return nil, merry.WithMessagef(parser.ErrInvalidArg, "unknown method '%s', must be one of stl or zscore", method)
+		}
+		sensitivity, err = e.GetFloatNamedOrPosArgDefault("sensitivity", 2, 3)
+		if err != nil {
+			return nil, err
+		}
+		if sensitivity <= 0 {
+			return nil, merry.WithMessagef(parser.ErrInvalidArg, "sensitivity must be positive")
+		}
+	}
+
+	// Note: fetch requests are made with an adjusted start time in AdjustMetrics()
+	// so that the data for the bootstrap interval is pre-fetched.
+	args, err := helper.GetSeriesArg(ctx, eval, e.Arg(0), from-bootstrapInterval, until, values)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*types.MetricData, 0, len(args))
+	for _, arg := range args {
+		if arg.StepTime <= 0 || len(arg.Values) == 0 {
+			continue
+		}
+		np := stl.PeriodPoints(period, arg.StepTime)
+		// points before from are the bootstrap, backend could return series that starts later than requested
+		windowPoints := 0
+		if arg.StartTime < from {
+			windowPoints = int((from - arg.StartTime + arg.StepTime - 1) / arg.StepTime)
+		}
+		if windowPoints > len(arg.Values) {
+			windowPoints = len(arg.Values)
+		}
+
+		var (
+			computed []float64
+			name     string
+			tagValue string
+		)
+		switch e.Target() {
+		case "stlDecompose":
+			d := stl.Decompose(arg.Values, np)
+			switch component {
+			case "trend":
+				computed = d.Trend
+			case "seasonal":
+				computed = d.Seasonal
+			default:
+				computed = d.Residual
+			}
+			name = "stlDecompose(" + arg.Name + ",'" + component + "')"
+			tagValue = component
+		case "seasonalZScore":
+			computed = seasonalZScore(arg.Values, np, seasons)
+			name = "seasonalZScore(" + arg.Name + ")"
+			tagValue = "1"
+		case "anomalies":
+			var score []float64
+			if method == "stl" {
+				score = stlScore(arg.Values, stl.Decompose(arg.Values, np).Residual)
+			} else {
+				score = seasonalZScore(arg.Values, np, seasons)
+			}
+			computed = make([]float64, len(score))
+			for i, z := range score {
+				if math.Abs(z) > sensitivity {
+					computed[i] = 1
+				}
+			}
+			name = "anomalies(" + arg.Name + ",'" + method + "'," + strconv.FormatFloat(sensitivity, 'g', -1, 64) + ")"
+			tagValue = method
+		}
+
+		r := &types.MetricData{
+			FetchResponse: pb.FetchResponse{
+				Name:                    name,
+				Values:                  computed[windowPoints:],
+				StepTime:                arg.StepTime,
+				StartTime:               arg.StartTime + int64(windowPoints)*arg.StepTime,
+				StopTime:                arg.StopTime,
+				PathExpression:          name,
+				ConsolidationFunc:       arg.ConsolidationFunc,
+				HighPrecisionTimestamps: arg.HighPrecisionTimestamps,
+				XFilesFactor:            arg.XFilesFactor,
+			},
+			Tags: helper.CopyTags(arg),
+		}
+		r.Tags[e.Target()] = tagValue
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// stlEpsilon is the scale of residuals, relative to the values of series, that is left by rounding errors of STL
+// decomposition, e.g. for constant series
+const stlEpsilon = 1e-9
+
+// stlScore scales residual of STL decomposition of values by its MAD. MAD is 0 if more than a half of residuals are
+// the same, standard deviation is used in that case, and score is 0 if residuals don't vary at all.
+func stlScore(values, residual []float64) []float64 {
+	var magnitude float64
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			magnitude = math.Max(magnitude, math.Abs(v))
+		}
+	}
+	negligible := func(scale float64) bool {
+		return math.IsNaN(scale) || scale <= stlEpsilon*magnitude
+	}
+
+	scale := stl.MAD(residual)
+	if negligible(scale) {
+		var sum, sumSq float64
+		n := 0
+		for _, r := range residual {
+			if !math.IsNaN(r) {
+				sum += r
+				sumSq += r * r
+				n++
+			}
+		}
+		scale = math.NaN()
+		if n > 0 {
+			mean := sum / float64(n)
+			scale = math.Sqrt(math.Max(sumSq/float64(n)-mean*mean, 0))
+		}
+	}
+
+	score := make([]float64, len(residual))
+	for i, r := range residual {
+		if math.IsNaN(r) {
+			score[i] = math.NaN()
+		} else if !negligible(scale) {
+			score[i] = r / scale
+		}
+	}
+	return score
+}
+
+// seasonalZScore compares every point with points at the same phase of previous seasons
+func seasonalZScore(values []float64, np, seasons int) []float64 {
+	res := make([]float64, len(values))
+	for i, v := range values {
+		res[i] = math.NaN()
+		if math.IsNaN(v) {
+			continue
+		}
+		var sum, sumSq float64
+		n := 0
+		for k := 1; k <= seasons; k++ {
+			j := i - k*np
+			if j < 0 {
+				break
+			}
+			if math.IsNaN(values[j]) {
+				continue
+			}
+			sum += values[j]
+			sumSq += values[j] * values[j]
+			n++
+		}
+		if n < 2 {
+			continue
+		}
+		mean := sum / float64(n)
+		stddev := math.Sqrt(math.Max(sumSq/float64(n)-mean*mean, 0))
+		if stddev == 0 {
+			continue
+		}
+		res[i] = (v - mean) / stddev
+	}
+	return res
+}
+
+// AdjustMetrics moves start of the requests back by the bootstrap interval (period * seasons)
+func (f *seasonal) AdjustMetrics(e parser.Expr, _, _ int64, _ *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	period, seasons, err := getPeriodAndSeasons(e)
+	if err != nil {
+		return nil
+	}
+
+	for i := range r {
+		r[i].From -= period * int64(seasons)
+	}
+	return r
+}
+
+func (f *seasonal) Description() map[string]types.FunctionDescription {
+	return map[string]types.FunctionDescription{
+		"stlDecompose": {
+			Description: "Decomposes each series into trend, seasonal and residual components using STL (Seasonal-Trend decomposition using Loess) and returns the selected component.\n\n`seasons` previous periods are prefetched to bootstrap the decomposition.",
+			Function:    "stlDecompose(seriesList, period='1d', component='trend', seasons=4)",
+			Group:       "Calculate",
+			Module:      "graphite.render.functions.custom",
+			Name:        "stlDecompose",
+			Params: []types.FunctionParam{
+				{
+					Name:     "seriesList",
+					Required: true,
+					Type:     types.SeriesList,
+				},
+				{
+					Default: types.NewSuggestion("1d"),
+					Name:    "period",
+					Suggestions: types.NewSuggestions(
+						"1h",
+						"1d",
+						"7d",
+					),
+					Type: types.Interval,
+				},
+				{
+					Default: types.NewSuggestion("trend"),
+					Name:    "component",
+					Options: types.StringsToSuggestionList([]string{
+						"trend",
+						"seasonal",
+						"residual",
+					}),
+					Type: types.String,
+				},
+				{
+					Default: types.NewSuggestion(stl.DefaultSeasons),
+					Name:    "seasons",
+					Type:    types.Integer,
+				},
+			},
+			NameChange:   true, // name changed
+			TagsChange:   true, // name tag changed
+			ValuesChange: true, // values changed
+		},
+		"seasonalZScore": {
+			Description: "Computes z-score of each point against the points at the same time of `seasons` previous periods.\n\nPoints without enough history or with zero deviation are null.",
+			Function:    "seasonalZScore(seriesList, period='1d', seasons=4)",
+			Group:       "Calculate",
+			Module:      "graphite.render.functions.custom",
+			Name:        "seasonalZScore",
+			Params: []types.FunctionParam{
+				{
+					Name:     "seriesList",
+					Required: true,
+					Type:     types.SeriesList,
+				},
+				{
+					Default: types.NewSuggestion("1d"),
+					Name:    "period",
+					Suggestions: types.NewSuggestions(
+						"1h",
+						"1d",
+						"7d",
+					),
+					Type: types.Interval,
+				},
+				{
+					Default: types.NewSuggestion(stl.DefaultSeasons),
+					Name:    "seasons",
+					Type:    types.Integer,
+				},
+			},
+			NameChange:   true, // name changed
+			TagsChange:   true, // name tag changed
+			ValuesChange: true, // values changed
+		},
+		"anomalies": {
+			Description: "Emits 1 for anomalous points and 0 otherwise.\n\nWith `stl` method a point is anomalous if its STL residual is more than `sensitivity` robust standard deviations (scaled MAD, or standard deviation if most residuals are the same) away, constant series have no anomalies. With `zscore` method a point is anomalous if its seasonal z-score is above `sensitivity`.",
+			Function:    "anomalies(seriesList, method='stl', sensitivity=3, period='1d', seasons=4)",
+			Group:       "Calculate",
+			Module:      "graphite.render.functions.custom",
+			Name:        "anomalies",
+			Params: []types.FunctionParam{
+				{
+					Name:     "seriesList",
+					Required: true,
+					Type:     types.SeriesList,
+				},
+				{
+					Default: types.NewSuggestion("stl"),
+					Name:    "method",
+					Options: types.StringsToSuggestionList([]string{
+						"stl",
+						"zscore",
+					}),
+					Type: types.String,
+				},
+				{
+					Default: types.NewSuggestion(3),
+					Name:    "sensitivity",
+					Type:    types.Float,
+				},
+				{
+					Default: types.NewSuggestion("1d"),
+					Name:    "period",
+					Suggestions: types.NewSuggestions(
+						"1h",
+						"1d",
+						"7d",
+					),
+					Type: types.Interval,
+				},
+				{
+					Default: types.NewSuggestion(stl.DefaultSeasons),
+					Name:    "seasons",
+					Type:    types.Integer,
+				},
+			},
+			NameChange:   true, // name changed
+			TagsChange:   true, // name tag changed
+			ValuesChange: true, // values changed
+		},
+	}
+}
@@ -0,0 +1,251 @@
+package seasonal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-graphite/carbonapi/expr/interfaces"
+	"github.com/go-graphite/carbonapi/expr/metadata"
+	"github.com/go-graphite/carbonapi/expr/types"
+	"github.com/go-graphite/carbonapi/pkg/parser"
+	th "github.com/go-graphite/carbonapi/tests"
+	"github.com/go-graphite/carbonapi/tests/compare"
+)
+
+var (
+	md []interfaces.FunctionMetadata = New("")
+)
+
+func init() {
+	for _, m := range md {
+		metadata.RegisterFunction(m.Name, m.F)
+	}
+}
+
+const (
+	startTime int64 = 2678400
+	step      int64 = 600
+	points    int64 = 12
+	hour      int64 = 3600
+)
+
+var pattern = []float64{0, 1, 2, 3, 2, 1}
+
+// generateSeasonalRange returns hourly seasonal values (6 points per period), offsets are added per season
+func generateSeasonalRange(seasons int, offsets func(season, phase int) float64) []float64 {
+	var valuesList []float64
+	for s := 0; s < seasons; s++ {
+		for p := range pattern {
+			valuesList = append(valuesList, 10+pattern[p]+offsets(s, p))
+		}
+	}
+	return valuesList
+}
+
+func TestSeasonal(t *testing.T) {
+	periodic := func(season, phase int) float64 { return 0 }
+	constant := func(season, phase int) float64 { return -pattern[phase] }
+	alternating := func(season, phase int) float64 {
+		if season == 4 && phase == 2 {
+			return 5
+		}
+		return float64(season % 2)
+	}
+	noisy := func(season, phase int) float64 {
+		i := season*len(pattern) + phase
+		if i == 30 {
+			return 5 + float64((i*7)%5)/10
+		}
+		return float64((i*7)%5) / 10
+	}
+	nan := math.NaN()
+
+	tests := []th.EvalTestItemWithRange{
+		{
+			Target: "stlDecompose(metric1,'1h')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: startTime - 4*hour, Until: startTime + step*points}: {
+					types.MakeMetricData("metric1", generateSeasonalRange(6, periodic), step, startTime-4*hour),
+				},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("stlDecompose(metric1,'trend')", []float64{11.5, 11.5, 11.5, 11.5, 11.5, 11.5, 11.5, 11.5, 11.5, 11.5, 11.5, 11.5}, step, startTime).SetTag("stlDecompose", "trend"),
+			},
+			From:  startTime,
+			Until: startTime + step*points,
+		},
+		{
+			Target: "stlDecompose(metric1,'1h','seasonal',2)",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: startTime - 2*hour, Until: startTime + step*points}: {
+					types.MakeMetricData("metric1", generateSeasonalRange(4, periodic), step, startTime-2*hour),
+				},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("stlDecompose(metric1,'seasonal')", []float64{-1.5, -0.5, 0.5, 1.5, 0.5, -0.5, -1.5, -0.5, 0.5, 1.5, 0.5, -0.5}, step, startTime).SetTag("stlDecompose", "seasonal"),
+			},
+			From:  startTime,
+			Until: startTime + step*points,
+		},
+		{
+			Target: "stlDecompose(metric1,period='1h',component='residual')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: startTime - 4*hour, Until: startTime + step*points}: {
+					types.MakeMetricData("metric1", generateSeasonalRange(6, periodic), step, startTime-4*hour),
+				},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("stlDecompose(metric1,'residual')", []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, step, startTime).SetTag("stlDecompose", "residual"),
+			},
+			From:  startTime,
+			Until: startTime + step*points,
+		},
+		{
+			Target: "seasonalZScore(metric*,'1h')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric*", From: startTime - 4*hour, Until: startTime + step*points}: {
+					types.MakeMetricData("metric1", generateSeasonalRange(6, alternating), step, startTime-4*hour),
+					types.MakeMetricData("metric2", generateSeasonalRange(6, periodic), step, startTime-4*hour),
+				},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("seasonalZScore(metric1)", []float64{-1, -1, 9, -1, -1, -1, 1, 1, -0.39056673294247163, 1, 1, 1}, step, startTime).SetTag("seasonalZScore", "1"),
+				types.MakeMetricData("seasonalZScore(metric2)", []float64{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan, nan, nan}, step, startTime).SetTag("seasonalZScore", "1"),
+			},
+			From:  startTime,
+			Until: startTime + step*points,
+		},
+		{
+			Target: "anomalies(metric1,'zscore',3,'1h')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: startTime - 4*hour, Until: startTime + step*points}: {
+					types.MakeMetricData("metric1", generateSeasonalRange(6, alternating), step, startTime-4*hour),
+				},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("anomalies(metric1,'zscore',3)", []float64{0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0}, step, startTime).SetTag("anomalies", "zscore"),
+			},
+			From:  startTime,
+			Until: startTime + step*points,
+		},
+		{
+			Target: "anomalies(metric1,period='1h')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: startTime - 4*hour, Until: startTime + step*points}: {
+					types.MakeMetricData("metric1", generateSeasonalRange(6, noisy), step, startTime-4*hour),
+				},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("anomalies(metric1,'stl',3)", []float64{0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0}, step, startTime).SetTag("anomalies", "stl"),
+			},
+			From:  startTime,
+			Until: startTime + step*points,
+		},
+		{
+			// residuals of constant series are all the same, so there is no scale and no anomalies
+			Target: "anomalies(metric1,period='1h')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: startTime - 4*hour, Until: startTime + step*points}: {
+					types.MakeMetricData("metric1", generateSeasonalRange(6, constant), step, startTime-4*hour),
+				},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("anomalies(metric1,'stl',3)", []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, step, startTime).SetTag("anomalies", "stl"),
+			},
+			From:  startTime,
+			Until: startTime + step*points,
+		},
+		{
+			// backend has less data than requested, bootstrap is shorter
+			Target: "stlDecompose(metric1,'1h')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: startTime - 4*hour, Until: startTime + step*points}: {
+					types.MakeMetricData("metric1", generateSeasonalRange(5, periodic), step, startTime-3*hour),
+				},
+			},
+			Want: []*types.MetricData{
+				types.MakeMetricData("stlDecompose(metric1,'trend')", []float64{11.5, 11.5, 11.5, 11.5, 11.5, 11.5, 11.5, 11.5, 11.5, 11.5, 11.5, 11.5}, step, startTime).SetTag("stlDecompose", "trend"),
+			},
+			From:  startTime,
+			Until: startTime + step*points,
+		},
+		{
+			Target: "stlDecompose(metric1,'1h')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: startTime - 4*hour, Until: startTime + step*points}: {
+					types.MakeMetricData("metric1", []float64{}, 0, startTime),
+				},
+			},
+			Want:  []*types.MetricData{},
+			From:  startTime,
+			Until: startTime + step*points,
+		},
+	}
+
+	for _, tt := range tests {
+		testName := tt.Target
+		t.Run(testName, func(t *testing.T) {
+			eval := th.EvaluatorFromFunc(md[0].F)
+			th.TestEvalExprWithRange(t, eval, &tt)
+		})
+	}
+}
+
+func TestSTLScore(t *testing.T) {
+	nan := math.NaN()
+	values := []float64{10, 10, 10, nan, 10, 10, 10, 14}
+
+	// MAD is 0, standard deviation is used instead
+	got := stlScore(values, []float64{0, 0, 0, nan, 0, 0, 0, 4})
+	want := []float64{0, 0, 0, nan, 0, 0, 0, 2.857738033247041}
+	if !compare.NearlyEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// rounding errors of decomposition of constant series
+	got = stlScore(values[:3], []float64{-7.2e-16, 5.7e-16, 3e-15})
+	want = []float64{0, 0, 0}
+	if !compare.NearlyEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	got = stlScore(values[:4], []float64{1, 1, nan, 1})
+	want = []float64{0, 0, nan, 0}
+	if !compare.NearlyEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSeasonalErrors(t *testing.T) {
+	tests := []th.EvalTestItemWithError{
+		{
+			Target: "stlDecompose(metric1,'1h','unknown')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: -4 * hour, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2}, 1, 0)},
+			},
+			Error: parser.ErrInvalidArg,
+		},
+		{
+			Target: "anomalies(metric1,'unknown')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: -4 * 86400, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2}, 1, 0)},
+			},
+			Error: parser.ErrInvalidArg,
+		},
+		{
+			Target: "seasonalZScore(metric1,'1h',0)",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: 0, Until: 1}: {types.MakeMetricData("metric1", []float64{1, 2}, 1, 0)},
+			},
+			Error: parser.ErrInvalidArg,
+		},
+	}
+
+	for _, tt := range tests {
+		testName := tt.Target
+		t.Run(testName, func(t *testing.T) {
+			eval := th.EvaluatorFromFunc(md[0].F)
+			th.TestEvalExprWithError(t, eval, &tt)
+		})
+	}
+}
@@ -103,7 +103,7 @@ func (f *seriesList) Do(ctx context.Context, eval interfaces.Evaluator, e parser
 		for n, s := range single {
 			r := s.CopyLinkTags()
 			r.Name = functionName + "(" + s.Name + "," + s.Name + ")"
-			r.Values = make([]float64, len(s.Values))
+			r.Values = r.NewValues(len(s.Values))
 			for i, v := range s.Values {
 				if math.IsNaN(v) {
 					r.Values[i] = math.NaN()
@@ -166,7 +166,7 @@ func (f *seriesList) Do(ctx context.Context, eval interfaces.Evaluator, e parser
 			denomName = strconv.FormatFloat(defaultValue, 'f', -1, 64)
 		}
 		r.Name = functionName + "(" + numerator.Name + "," + denomName + ")"
-		r.Values = make([]float64, len(numerator.Values))
+		r.Values = r.NewValues(len(numerator.Values))
 
 		for i, v := range numerator.Values {
 			denomIsAbsent := pairFound && math.IsNaN(denominator.Values[i])
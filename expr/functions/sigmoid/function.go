@@ -37,7 +37,7 @@ func (f *sigmoid) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Ex
 	for _, a := range arg {
 		r := a.CopyLink()
 		r.Name = "sigmoid(" + a.Name + ")"
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["sigmoid"] = "sigmoid"
 
 		for i, v := range a.Values {
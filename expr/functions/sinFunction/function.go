@@ -48,25 +48,27 @@ func (f *sinFunction) Do(ctx context.Context, eval interfaces.Evaluator, e parse
 			return nil, err
 		}
 	}
-	step := int64(stepInt)
-
-	newValues := make([]float64, (until-from-1+step)/step)
-	value := from
-	for i := 0; i < len(newValues); i++ {
-		newValues[i] = math.Sin(float64(value)) * amplitude
-		value += step
-	}
+	unit := e.TimeUnit()
+	step := int64(stepInt) * unit
 
 	r := types.MetricData{
 		FetchResponse: pb.FetchResponse{
 			Name:      name,
-			Values:    newValues,
 			StepTime:  step,
 			StartTime: from,
 			StopTime:  until,
 		},
 		Tags: map[string]string{"name": name},
 	}
+	if err := r.MakeValues(ctx, int((until-from-1+step)/step)); err != nil {
+		return nil, err
+	}
+
+	value := from
+	for i := 0; i < len(r.Values); i++ {
+		r.Values[i] = math.Sin(float64(value)/float64(unit)) * amplitude
+		value += step
+	}
 
 	return []*types.MetricData{&r}, nil
 }
@@ -29,7 +29,7 @@ func New(string) []interfaces.FunctionMetadata {
 func (f *slo) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr, from, until int64, values map[parser.MetricRequest][]*types.MetricData) ([]*types.MetricData, error) {
 	var (
 		argsExtended, argsWindowed []*types.MetricData
-		bucketSize32               int32
+		bucketSize                 int64
 		windowSize                 int64
 		delta                      int64
 		err                        error
@@ -41,11 +41,10 @@ func (f *slo) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr,
 		return nil, err
 	}
 
-	bucketSize32, err = e.GetIntervalArg(1, 1)
+	bucketSize, err = e.GetIntervalArg(1, 1)
 	if err != nil {
 		return nil, err
 	}
-	bucketSize := int64(bucketSize32)
 	intervalStringValue := e.Arg(1).StringValue()
 
 	// there is an opportunity that requested data points' window is smaller than slo interval
@@ -3,6 +3,7 @@ package smartSummarize
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-graphite/carbonapi/expr/consolidations"
 	"github.com/go-graphite/carbonapi/expr/helper"
@@ -41,7 +42,7 @@ func (f *smartSummarize) Do(ctx context.Context, eval interfaces.Evaluator, e pa
 	}
 
 	if alignToInterval != "" {
-		// Note: the start time for the fetch request is adjusted in expr.Metrics() so that the fetched
+		// Note: the start time for the fetch request is adjusted in AdjustMetrics() so that the fetched
 		// data is already aligned by interval if this parameter specifics an interval to align to
 		newStart, err := parser.StartAlignTo(from, alignToInterval)
 		if err != nil {
@@ -58,14 +59,13 @@ func (f *smartSummarize) Do(ctx context.Context, eval interfaces.Evaluator, e pa
 		return []*types.MetricData{}, nil
 	}
 
-	bucketSizeInt32, err := e.GetIntervalArg(1, 1)
+	bucketSize, err := e.GetIntervalArg(1, 1)
 	if err != nil {
 		return nil, err
 	}
-	if bucketSizeInt32 <= 0 {
+	if bucketSize <= 0 {
 		return nil, parser.ErrInvalidInterval
 	}
-	bucketSize := int64(bucketSizeInt32)
 	bucketSizeStr := e.Arg(1).StringValue()
 
 	summarizeFunction, err := e.GetStringNamedOrPosArgDefault("func", 2, "sum")
@@ -88,17 +88,24 @@ func (f *smartSummarize) Do(ctx context.Context, eval interfaces.Evaluator, e pa
 
 		r := types.MetricData{
 			FetchResponse: pb.FetchResponse{
-				Name:              name,
-				StepTime:          bucketSize,
-				StartTime:         arg.StartTime,
-				StopTime:          arg.StopTime,
-				ConsolidationFunc: arg.ConsolidationFunc,
+				Name:                    name,
+				StepTime:                bucketSize,
+				StartTime:               arg.StartTime,
+				StopTime:                arg.StopTime,
+				ConsolidationFunc:       arg.ConsolidationFunc,
+				HighPrecisionTimestamps: arg.HighPrecisionTimestamps,
 			},
 			Tags: helper.CopyTags(arg),
 		}
-		r.Tags["smartSummarize"] = fmt.Sprintf("%d", bucketSizeInt32)
+		r.Tags["smartSummarize"] = fmt.Sprintf("%d", bucketSize)
 		r.Tags["smartSummarizeFunction"] = summarizeFunction
 
+		// values are appended bucket by bucket
+		if err := r.MakeValues(ctx, int((arg.StopTime-arg.StartTime+bucketSize-1)/bucketSize)); err != nil {
+			return nil, err
+		}
+		r.Values = r.Values[:0]
+
 		ts := arg.StartTime
 		for ts < arg.StopTime {
 			bucketUpperBound := ts + bucketSize
@@ -119,6 +126,29 @@ func (f *smartSummarize) Do(ctx context.Context, eval interfaces.Evaluator, e pa
 	return results, nil
 }
 
+// AdjustMetrics aligns start of the requests when alignTo is set
+func (f *smartSummarize) AdjustMetrics(e parser.Expr, _, _ int64, _ *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	if len(e.Args()) < 2 {
+		return nil
+	}
+
+	alignToInterval, err := e.GetStringNamedOrPosArgDefault("alignTo", 3, "")
+	if err != nil {
+		return nil
+	}
+
+	if alignToInterval != "" {
+		for i := range r {
+			newStart, err := parser.StartAlignTo(r[i].From, alignToInterval)
+			if err != nil {
+				return nil
+			}
+			r[i].From = newStart
+		}
+	}
+	return r
+}
+
 // Description is auto-generated description, based on output of https://github.com/graphite-project/graphite-web
 func (f *smartSummarize) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
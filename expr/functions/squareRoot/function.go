@@ -37,7 +37,7 @@ func (f *squareRoot) Do(ctx context.Context, eval interfaces.Evaluator, e parser
 	for _, a := range arg {
 		r := a.CopyLink()
 		r.Name = "squareRoot(" + a.Name + ")"
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["squareRoot"] = "1"
 
 		for i, v := range a.Values {
@@ -56,7 +56,7 @@ func (f *stdev) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr
 
 		r := a.CopyLink()
 		r.Name = "stdev(" + a.Name + "," + pointsStr + ")"
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["stdev"] = fmt.Sprintf("%d", points)
 
 		for i, v := range a.Values {
@@ -40,11 +40,10 @@ func (f *summarize) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 		return nil, nil
 	}
 
-	bucketSizeInt32, err := e.GetIntervalArg(1, 1)
+	bucketSize, err := e.GetIntervalArg(1, 1)
 	if err != nil {
 		return nil, err
 	}
-	bucketSize := int64(bucketSizeInt32)
 
 	summarizeFunction, err := e.GetStringNamedOrPosArgDefault("func", 2, "sum")
 	if err != nil {
@@ -70,7 +69,7 @@ func (f *summarize) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 	newStart := args[0].StartTime
 	newStop := args[0].StopTime
 	if !alignToFrom {
-		newStart, newStop = helper.AlignToBucketSize(newStart, newStop, bucketSize)
+		newStart, newStop = helper.AlignToBucketSize(newStart, newStop, bucketSize, e.TimeUnit())
 		newStop += bucketSize
 	}
 
@@ -96,19 +95,26 @@ func (f *summarize) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 
 		r := types.MetricData{
 			FetchResponse: pb.FetchResponse{
-				Name:              name,
-				StepTime:          bucketSize,
-				StartTime:         newStart,
-				StopTime:          newStop,
-				XFilesFactor:      arg.XFilesFactor,
-				PathExpression:    name,
-				ConsolidationFunc: arg.ConsolidationFunc,
+				Name:                    name,
+				StepTime:                bucketSize,
+				StartTime:               newStart,
+				StopTime:                newStop,
+				XFilesFactor:            arg.XFilesFactor,
+				PathExpression:          name,
+				ConsolidationFunc:       arg.ConsolidationFunc,
+				HighPrecisionTimestamps: arg.HighPrecisionTimestamps,
 			},
 			Tags: helper.CopyTags(arg),
 		}
 		r.Tags["summarize"] = e.Arg(1).StringValue()
 		r.Tags["summarizeFunction"] = summarizeFunction
 
+		// values are appended bucket by bucket
+		if err := r.MakeValues(ctx, int((newStop-newStart+bucketSize-1)/bucketSize)); err != nil {
+			return nil, err
+		}
+		r.Values = r.Values[:0]
+
 		ts := newStart
 		var bucketStart int64 = 0
 		for ts < newStop {
@@ -1,6 +1,7 @@
 package summarize
 
 import (
+	"context"
 	"math"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/go-graphite/carbonapi/expr/types"
 	"github.com/go-graphite/carbonapi/pkg/parser"
 	th "github.com/go-graphite/carbonapi/tests"
+	"github.com/go-graphite/carbonapi/tests/compare"
 )
 
 var (
@@ -232,6 +234,34 @@ func TestEvalSummarize(t *testing.T) {
 	}
 }
 
+func TestEvalSummarizeXFilesFactor(t *testing.T) {
+	_, _, now32 := th.InitTestSummarize()
+
+	tests := []th.SummarizeEvalTestItem{
+		{
+			Target: "summarize(metric1,'4s','sum')",
+			M: map[parser.MetricRequest][]*types.MetricData{
+				{Metric: "metric1", From: now32, Until: now32 + 8}: {types.MakeMetricData("metric1", []float64{
+					1, math.NaN(), math.NaN(), math.NaN(),
+					2, 2, math.NaN(), math.NaN(),
+				}, 1, now32).SetXFilesFactor(0.5)},
+			},
+			Want:  []float64{math.NaN(), 4, math.NaN()},
+			From:  now32,
+			Until: now32 + 8,
+			Name:  "summarize(metric1,'4s','sum')",
+			Step:  4,
+			Start: now32,
+			Stop:  now32 + 12,
+		},
+	}
+
+	for _, tt := range tests {
+		eval := th.EvaluatorFromFunc(md[0].F)
+		th.TestSummarizeEvalExpr(t, eval, &tt)
+	}
+}
+
 func TestEvalSummarize1Minute(t *testing.T) {
 	tests := []th.SummarizeEvalTestItem{
 		{
@@ -372,6 +402,37 @@ func TestEvalSummarize1Minute(t *testing.T) {
 	}
 }
 
+func TestEvalSummarizeHighPrecision(t *testing.T) {
+	// timestamps and steps are in milliseconds: 10 points 100ms apart summarized into 500ms buckets
+	exp, _, err := parser.ParseExpr("summarize(metric1,'500ms','sum')")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp.SetTimeUnit(parser.Milliseconds)
+
+	metric := types.MakeMetricData("metric1", []float64{1, 1, 1, 1, 1, 2, 2, 2, 2, 2}, 100, 1000)
+	metric.HighPrecisionTimestamps = true
+	values := map[parser.MetricRequest][]*types.MetricData{
+		{Metric: "metric1", From: 1000, Until: 2000}: {metric},
+	}
+
+	eval := th.EvaluatorFromFunc(md[0].F)
+	result, err := eval.Eval(context.Background(), exp, 1000, 2000, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 series, got %d", len(result))
+	}
+	r := result[0]
+	if r.StepTime != 500 || r.StartTime != 1000 || r.StopTime != 2500 || !r.HighPrecisionTimestamps {
+		t.Errorf("unexpected time range: start=%d stop=%d step=%d highPrecision=%v", r.StartTime, r.StopTime, r.StepTime, r.HighPrecisionTimestamps)
+	}
+	if !compare.NearlyEqual(r.Values, []float64{5, 10, math.NaN()}) {
+		t.Errorf("unexpected values: %v", r.Values)
+	}
+}
+
 func generateValues(start, stop, step int64) (values []float64) {
 	for i := start; i < stop; i += step {
 		values = append(values, float64(i))
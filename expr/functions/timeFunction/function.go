@@ -39,30 +39,32 @@ func (f *timeFunction) Do(ctx context.Context, eval interfaces.Evaluator, e pars
 	if stepInt <= 0 {
 		return nil, errors.New("step can't be less than 0")
 	}
-	step := int64(stepInt)
+	unit := e.TimeUnit()
+	step := int64(stepInt) * unit
 
 	// emulate the behavior of this Python code:
 	//   while when < requestContext["endTime"]:
 	//     newValues.append(time.mktime(when.timetuple()))
 	//     when += delta
 
-	newValues := make([]float64, (until-from-1+step)/step)
-	value := from
-	for i := 0; i < len(newValues); i++ {
-		newValues[i] = float64(value)
-		value += step
-	}
-
 	p := types.MetricData{
 		FetchResponse: pb.FetchResponse{
 			Name:      name,
 			StartTime: from,
 			StopTime:  until,
 			StepTime:  step,
-			Values:    newValues,
 		},
 		Tags: map[string]string{"name": name},
 	}
+	if err := p.MakeValues(ctx, int((until-from-1+step)/step)); err != nil {
+		return nil, err
+	}
+
+	value := from
+	for i := 0; i < len(p.Values); i++ {
+		p.Values[i] = float64(value) / float64(unit)
+		value += step
+	}
 
 	return []*types.MetricData{&p}, nil
 }
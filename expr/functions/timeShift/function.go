@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/lomik/zapwriter"
 	"github.com/spf13/viper"
@@ -89,7 +90,7 @@ func (f *timeShift) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 		return nil, err
 	}
 
-	// Note: The fetch request is adjusted in expr.Metrics() to include both a request using the original
+	// Note: The fetch request is adjusted in AdjustMetrics() to include both a request using the original
 	// start and stop time, and one that has the start and stop time adjusted based on the offset and alignDST, if relevant.
 	// This helps prevent having to re-fetch the data with the adjusted start and stop time from within this function.
 	arg, err := helper.GetSeriesArg(ctx, eval, e.Arg(0), from, until, values)
@@ -101,10 +102,10 @@ func (f *timeShift) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 		return nil, err
 	}
 
-	newFrom := from + int64(offs)
-	newUntil := until + int64(offs)
+	newFrom := from + offs
+	newUntil := until + offs
 	if alignDST {
-		newFrom, newUntil, err = parser.AlignDST(from, until, offs, fconfig.Config.DefaultTimeZone)
+		newFrom, newUntil, err = parser.AlignDST(from, until, offs, e.TimeUnit(), fconfig.Config.DefaultTimeZone)
 		if err != nil {
 			return nil, err
 		}
@@ -143,6 +144,40 @@ func (f *timeShift) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 	return results, nil
 }
 
+// AdjustMetrics adds requests for the shifted interval, so data for it is pre-fetched together with the original one
+func (f *timeShift) AdjustMetrics(e parser.Expr, from, until int64, tz *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	offs, err := e.GetIntervalArg(1, -1)
+	if err != nil {
+		return nil
+	}
+
+	alignDST, err := e.GetBoolArgDefault(3, false)
+	if err != nil {
+		return nil
+	}
+
+	var r2 []parser.MetricRequest
+	for i := range r {
+		newFrom := r[i].From + offs
+		newUntil := r[i].Until + offs
+		if alignDST {
+			newFrom, newUntil, err = parser.AlignDST(from, until, offs, e.TimeUnit(), tz)
+			if err != nil {
+				return nil
+			}
+		}
+
+		// Append a request with modified start and end time
+		r2 = append(r2, parser.MetricRequest{
+			Metric: r[i].Metric,
+			From:   newFrom,
+			Until:  newUntil,
+		})
+	}
+
+	return append(r, r2...)
+}
+
 // Description is auto-generated description, based on output of https://github.com/graphite-project/graphite-web
 func (f *timeShift) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
@@ -32,11 +32,10 @@ func (f *timeSlice) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 		return nil, parser.ErrMissingArgument
 	}
 
-	start32, err := e.GetIntervalArg(1, 1)
+	start, err := e.GetIntervalArg(1, 1)
 	if err != nil {
 		return nil, err
 	}
-	start := int64(start32)
 
 	end, err := e.GetIntervalNamedOrPosArgDefault("endSliceAt", 2, 1, 0)
 	if err != nil {
@@ -56,7 +55,7 @@ func (f *timeSlice) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 	for n, a := range arg {
 		r := a.CopyLink()
 		r.Name = "timeSlice(" + a.Name + "," + startStr + "," + endStr + ")"
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["timeSliceStart"] = startStr
 		r.Tags["timeSliceEnd"] = endStr
 
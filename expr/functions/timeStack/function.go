@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/go-graphite/carbonapi/expr/helper"
 	"github.com/go-graphite/carbonapi/expr/interfaces"
@@ -70,6 +71,37 @@ func (f *timeStack) Do(ctx context.Context, eval interfaces.Evaluator, e parser.
 	return results, nil
 }
 
+// AdjustMetrics replaces requests with the ones for each of the shifted intervals
+func (f *timeStack) AdjustMetrics(e parser.Expr, _, _ int64, _ *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	offs, err := e.GetIntervalArg(1, -1)
+	if err != nil {
+		return nil
+	}
+
+	start, err := e.GetIntArg(2)
+	if err != nil {
+		return nil
+	}
+
+	end, err := e.GetIntArg(3)
+	if err != nil {
+		return nil
+	}
+
+	var r2 []parser.MetricRequest
+	for _, v := range r {
+		for i := int64(start); i < int64(end); i++ {
+			r2 = append(r2, parser.MetricRequest{
+				Metric: v.Metric,
+				From:   v.From + i*int64(offs),
+				Until:  v.Until + i*int64(offs),
+			})
+		}
+	}
+
+	return r2
+}
+
 // Description is auto-generated description, based on output of https://github.com/graphite-project/graphite-web
 func (f *timeStack) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
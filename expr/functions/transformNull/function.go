@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"time"
 
 	pbv3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
 
@@ -89,7 +90,7 @@ func (f *transformNull) Do(ctx context.Context, eval interfaces.Evaluator, e par
 
 		r := a.CopyLink()
 		r.Name = name
-		r.Values = make([]float64, len(a.Values))
+		r.Values = r.NewValues(len(a.Values))
 		r.Tags["transformNull"] = defvStr
 
 		for i, v := range a.Values {
@@ -126,6 +127,15 @@ func (f *transformNull) Do(ctx context.Context, eval interfaces.Evaluator, e par
 	return results, nil
 }
 
+// AdjustMetrics adds requests for referenceSeries
+func (f *transformNull) AdjustMetrics(e parser.Expr, from, until int64, tz *time.Location, r []parser.MetricRequest) []parser.MetricRequest {
+	referenceSeriesExpr := e.GetNamedArg("referenceSeries")
+	if !referenceSeriesExpr.IsInterfaceNil() {
+		r = append(r, referenceSeriesExpr.Metrics(from, until, tz)...)
+	}
+	return r
+}
+
 // Description is auto-generated description, based on output of https://github.com/graphite-project/graphite-web
 func (f *transformNull) Description() map[string]types.FunctionDescription {
 	return map[string]types.FunctionDescription{
@@ -57,9 +57,9 @@ func (f *tukey) Do(ctx context.Context, eval interfaces.Evaluator, e parser.Expr
 		case parser.EtConst:
 			beginInterval, err = e.GetIntArg(3)
 		case parser.EtString:
-			var i32 int32
-			i32, err = e.GetIntervalArg(3, 1)
-			beginInterval = int(i32)
+			var interval int64
+			interval, err = e.GetIntervalArg(3, 1)
+			beginInterval = int(interval)
 			beginInterval /= int(arg[0].StepTime)
 			// TODO(nnuss): make sure the arrays are all the same 'size'
 		default:
@@ -98,8 +98,7 @@ func ScaleToCommonStep(args []*types.MetricData, commonStep int64) []*types.Metr
 		if arg.StepTime == commonStep {
 			if minStart < arg.StartTime {
 				valCnt := (arg.StartTime - minStart) / arg.StepTime
-				newVals := genNaNs(int(valCnt))
-				arg.Values = append(newVals, arg.Values...)
+				arg.Values = padValues(arg, int(valCnt), 0)
 			}
 			arg.StartTime = minStart
 
@@ -114,20 +113,18 @@ func ScaleToCommonStep(args []*types.MetricData, commonStep int64) []*types.Metr
 			if arg.StartTime > minStart {
 				// Fill with NaNs from newStart to arg.StartTime
 				valCnt := (arg.StartTime - minStart) / arg.StepTime
-				nans := genNaNs(int(valCnt))
-				arg.Values = append(nans, arg.Values...)
+				arg.Values = padValues(arg, int(valCnt), 0)
 				arg.StartTime = minStart
 			}
 
 			newValsLen := 1 + int64(len(arg.Values)-1)/stepFactor
 			newStop := arg.StartTime + newValsLen*commonStep
-			newVals := make([]float64, 0, newValsLen)
+			newVals := arg.NewValues(int(newValsLen))[:0]
 
 			if len(arg.Values) != int(stepFactor*newValsLen) {
 				// Fill the last step with NaNs from newStart to (newStart + commonStep - arg.StepTime)
 				valCnt := int(stepFactor*newValsLen) - len(arg.Values)
-				nans := genNaNs(valCnt)
-				arg.Values = append(arg.Values, nans...)
+				arg.Values = padValues(arg, 0, valCnt)
 			}
 			arg.StopTime = newStop
 			for i := 0; i < len(arg.Values); i += int(stepFactor) {
@@ -146,8 +143,7 @@ func ScaleToCommonStep(args []*types.MetricData, commonStep int64) []*types.Metr
 	for _, arg := range args {
 		if maxVals > len(arg.Values) {
 			valCnt := maxVals - len(arg.Values)
-			newVals := genNaNs(valCnt)
-			arg.Values = append(arg.Values, newVals...)
+			arg.Values = padValues(arg, 0, valCnt)
 		}
 		arg.RecalcStopTime()
 	}
@@ -214,11 +210,12 @@ func GetBuckets(start, stop, bucketSize int64) int64 {
 	return int64(math.Ceil(float64(stop-start) / float64(bucketSize)))
 }
 
-// AlignStartToInterval aligns start of serie to interval
-func AlignStartToInterval(start, stop, bucketSize int64) int64 {
+// AlignStartToInterval aligns start of serie to interval. Timestamps and interval are set in time units (see
+// parser.Expr.TimeUnit).
+func AlignStartToInterval(start, stop, bucketSize, unit int64) int64 {
 	for _, v := range []int64{86400, 3600, 60} {
-		if bucketSize >= v {
-			start -= start % v
+		if bucketSize >= v*unit {
+			start -= start % (v * unit)
 			break
 		}
 	}
@@ -226,10 +223,12 @@ func AlignStartToInterval(start, stop, bucketSize int64) int64 {
 	return start
 }
 
-// AlignToBucketSize aligns start and stop of serie to specified bucket (step) size
-func AlignToBucketSize(start, stop, bucketSize int64) (int64, int64) {
-	start = time.Unix(start, 0).Truncate(time.Duration(bucketSize) * time.Second).Unix()
-	newStop := time.Unix(stop, 0).Truncate(time.Duration(bucketSize) * time.Second).Unix()
+// AlignToBucketSize aligns start and stop of serie to specified bucket (step) size. Timestamps and bucket size are set
+// in time units (see parser.Expr.TimeUnit).
+func AlignToBucketSize(start, stop, bucketSize, unit int64) (int64, int64) {
+	bucket := time.Duration(bucketSize) * time.Second / time.Duration(unit)
+	start = fromUnitTime(parser.UnitTime(start, unit).Truncate(bucket), unit)
+	newStop := fromUnitTime(parser.UnitTime(stop, unit).Truncate(bucket), unit)
 
 	// check if a partial bucket is needed
 	if stop != newStop {
@@ -249,7 +248,7 @@ func AlignSeries(args []*types.MetricData) []*types.MetricData {
 			for _, arg := range args {
 				if arg.StepTime > minStepTime {
 					valsCnt := int(math.Ceil(float64(arg.StopTime-arg.StartTime) / float64(minStepTime)))
-					newVals := make([]float64, valsCnt)
+					newVals := arg.NewValues(valsCnt)
 					ts := arg.StartTime
 					nextTs := arg.StartTime + arg.StepTime
 					i := 0
@@ -279,16 +278,14 @@ func AlignSeries(args []*types.MetricData) []*types.MetricData {
 	for _, arg := range args {
 		if minStart < arg.StartTime {
 			valCnt := (arg.StartTime - minStart) / arg.StepTime
-			newVals := genNaNs(int(valCnt))
-			arg.Values = append(newVals, arg.Values...)
+			arg.Values = padValues(arg, int(valCnt), 0)
 		}
 
 		arg.StartTime = minStart
 
 		if maxStop > arg.StopTime {
 			valCnt := (maxStop - arg.StopTime) / arg.StepTime
-			newVals := genNaNs(int(valCnt))
-			arg.Values = append(arg.Values, newVals...)
+			arg.Values = padValues(arg, 0, int(valCnt))
 			arg.StopTime = maxStop
 		}
 
@@ -310,7 +307,7 @@ func ScaleSeries(args []*types.MetricData) []*types.MetricData {
 			for _, arg := range args {
 				if arg.StepTime > commonStep {
 					valsCnt := int(math.Ceil(float64(arg.StopTime-arg.StartTime) / float64(commonStep)))
-					newVals := make([]float64, valsCnt)
+					newVals := arg.NewValues(valsCnt)
 					ts := arg.StartTime
 					nextTs := arg.StartTime + arg.StepTime
 					i := 0
@@ -348,15 +345,13 @@ func ScaleSeries(args []*types.MetricData) []*types.MetricData {
 		for _, arg := range args {
 			if minStart < arg.StartTime {
 				valCnt := (arg.StartTime - minStart) / arg.StepTime
-				newVals := genNaNs(int(valCnt))
-				arg.Values = append(newVals, arg.Values...)
+				arg.Values = padValues(arg, int(valCnt), 0)
 				arg.StartTime = minStart
 			}
 
 			if maxStop > arg.StopTime {
 				valCnt := (maxStop - arg.StopTime) / arg.StepTime
-				newVals := genNaNs(int(valCnt))
-				arg.Values = append(arg.Values, newVals...)
+				arg.Values = padValues(arg, 0, int(valCnt))
 				arg.StopTime = maxStop
 			}
 
@@ -368,8 +363,7 @@ func ScaleSeries(args []*types.MetricData) []*types.MetricData {
 		for _, arg := range args {
 			if maxVals > len(arg.Values) {
 				valCnt := maxVals - len(arg.Values)
-				newVals := genNaNs(valCnt)
-				arg.Values = append(arg.Values, newVals...)
+				arg.Values = padValues(arg, 0, valCnt)
 			}
 			arg.RecalcStopTime()
 		}
@@ -394,12 +388,22 @@ func ConsolidateSeriesByStep(numerator, denominator *types.MetricData) (*types.M
 	return numerator, denominator
 }
 
-func genNaNs(length int) []float64 {
-	nans := make([]float64, length)
-	for i := range nans {
-		nans[i] = math.NaN()
+// fromUnitTime converts time to timestamp in time units, it's reverse of parser.UnitTime
+func fromUnitTime(t time.Time, unit int64) int64 {
+	return t.Unix()*unit + int64(t.Nanosecond())*unit/int64(time.Second)
+}
+
+// padValues returns values of the series with before NaNs prepended and after NaNs appended, new values are charged in
+// memory accountant of the series
+func padValues(arg *types.MetricData, before, after int) []float64 {
+	values := arg.NewValues(before + len(arg.Values) + after)
+	for i := 0; i < before; i++ {
+		values[i] = math.NaN()
 	}
-	return nans
+	for i := before + copy(values[before:], arg.Values); i < len(values); i++ {
+		values[i] = math.NaN()
+	}
+	return values
 }
 
 func Divmod(numerator, denominator int64) (quotient, remainder int64) {
@@ -138,7 +138,9 @@ func ForEachSeriesDo(ctx context.Context, eval interfaces.Evaluator, e parser.Ex
 
 	for _, a := range arg {
 		r := a.CopyName(e.Target() + "(" + a.Name + ")")
-		r.Values = make([]float64, len(a.Values))
+		if err := r.MakeValues(ctx, len(a.Values)); err != nil {
+			return nil, err
+		}
 		results = append(results, function(a, r))
 	}
 	return results, nil
@@ -160,7 +162,7 @@ func AggregateSeries(e parser.Expr, args []*types.MetricData, function Aggregate
 	args = ScaleSeries(args)
 	length := len(args[0].Values)
 	r := args[0].CopyNameArg(e.Target()+"("+e.RawArgs()+")", e.Target(), GetCommonTags(args), extractTagsFromArgs)
-	r.Values = make([]float64, length)
+	r.Values = r.NewValues(length)
 
 	if _, ok := r.Tags["name"]; !ok {
 		r.Tags["name"] = r.Name
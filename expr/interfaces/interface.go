@@ -50,3 +50,8 @@ type RewriteFunction interface {
 	Do(ctx context.Context, evaluator Evaluator, e parser.Expr, from, until int64, values map[parser.MetricRequest][]*types.MetricData) (bool, []string, error)
 	Description() map[string]types.FunctionDescription
 }
+
+// MetricsAdjuster is an optional interface for Function. Functions that need to fetch their arguments for another
+// interval (e.g. bootstrap data before from) or with other parameters should implement it instead of
+// hard-coding that in the parser.
+type MetricsAdjuster = parser.MetricsAdjuster
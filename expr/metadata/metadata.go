@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-graphite/carbonapi/expr/interfaces"
 	"github.com/go-graphite/carbonapi/expr/types"
+	"github.com/go-graphite/carbonapi/pkg/parser"
 	"github.com/lomik/zapwriter"
 	"go.uber.org/zap"
 )
@@ -124,3 +125,16 @@ var FunctionMD = Metadata{
 	FunctionsFilenames:        make(map[string][]string),
 	RewriteFunctionsFilenames: make(map[string][]string),
 }
+
+func init() {
+	parser.SetMetricsAdjusterLookup(func(name string) parser.MetricsAdjuster {
+		FunctionMD.RLock()
+		f, ok := FunctionMD.Functions[name]
+		FunctionMD.RUnlock()
+		if !ok {
+			return nil
+		}
+		adjuster, _ := f.(parser.MetricsAdjuster)
+		return adjuster
+	})
+}
@@ -0,0 +1,170 @@
+package expr
+
+import (
+	"context"
+
+	pb "github.com/go-graphite/protocol/carbonapi_v3_pb"
+
+	fconfig "github.com/go-graphite/carbonapi/expr/functions/config"
+	"github.com/go-graphite/carbonapi/expr/interfaces"
+	"github.com/go-graphite/carbonapi/expr/metadata"
+	"github.com/go-graphite/carbonapi/pkg/parser"
+	zipper "github.com/go-graphite/carbonapi/zipper/interfaces"
+)
+
+// pushdown is a subtree of expression that is evaluated by a backend
+type pushdown struct {
+	expr           parser.Expr
+	pathExpression string
+	functions      []*pb.FilteringFunction
+	// levels[i] is a request for subtree with first i functions applied, levels[0] is a request for pathExpression
+	levels []parser.MetricRequest
+}
+
+func (p *pushdown) fetchRequest(maxDataPoints int64) pb.FetchRequest {
+	return pb.FetchRequest{
+		Name:            p.pathExpression,
+		PathExpression:  p.pathExpression,
+		StartTime:       p.levels[0].From,
+		StopTime:        p.levels[0].Until,
+		MaxDataPoints:   maxDataPoints,
+		FilterFunctions: p.functions,
+	}
+}
+
+// level returns request under which series should be stored, depending on how many functions backend applied to them
+func (p *pushdown) level(appliedFunctions []string) parser.MetricRequest {
+	if len(appliedFunctions) >= len(p.levels) {
+		return p.levels[len(p.levels)-1]
+	}
+	return p.levels[len(appliedFunctions)]
+}
+
+// findPushdowns returns subtrees of exprs that backends could evaluate, by path expression. Subtree is a chain of
+// functions over a single path expression, e.g. sumSeries(groupByNode(a.*.b, 1)). Path expression is pushed down only
+// if all its occurrences are in the same subtree, so the raw series are not needed.
+func findPushdowns(ctx context.Context, pd zipper.FunctionPushdown, exprs []parser.Expr, from, until int64) map[string]*pushdown {
+	supported := make(map[string]map[string]struct{})
+	candidates := make(map[string][]*pushdown)
+
+	var walk func(e parser.Expr)
+	walk = func(e parser.Expr) {
+		if !e.IsFunc() {
+			return
+		}
+		if chain, pathExpression, ok := pushdownChain(e); ok {
+			functions, ok := supported[pathExpression]
+			if !ok {
+				functions = pd.PushdownFunctions(ctx, pathExpression)
+				supported[pathExpression] = functions
+			}
+			n := 0
+			for n < len(chain) && canPushdown(chain[n], functions) {
+				n++
+			}
+			if n > 0 {
+				candidates[pathExpression] = append(candidates[pathExpression], newPushdown(chain[:n], pathExpression, from, until))
+				return
+			}
+		}
+		for _, arg := range e.Args() {
+			walk(arg)
+		}
+	}
+	for _, exp := range exprs {
+		walk(exp)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	occurrences := make(map[string]int)
+	for _, exp := range exprs {
+		for _, m := range exp.Metrics(from, until, fconfig.Config.DefaultTimeZone) {
+			if _, ok := candidates[m.Metric]; !ok {
+				continue
+			}
+			if m.From != from || m.Until != until || m.ConsolidationFunc != "" {
+				// series are requested for other interval, backend can't do that for us
+				occurrences[m.Metric] = -1
+			} else if occurrences[m.Metric] >= 0 {
+				occurrences[m.Metric]++
+			}
+		}
+	}
+
+	pushdowns := make(map[string]*pushdown)
+	for pathExpression, c := range candidates {
+		if occurrences[pathExpression] != len(c) {
+			continue
+		}
+		same := true
+		for _, p := range c[1:] {
+			if p.levels[len(p.levels)-1] != c[0].levels[len(c[0].levels)-1] {
+				same = false
+				break
+			}
+		}
+		if same {
+			pushdowns[pathExpression] = c[0]
+		}
+	}
+	return pushdowns
+}
+
+// pushdownChain returns functions of e from the innermost one, if e is a chain of functions over a single path
+// expression with only constant arguments
+func pushdownChain(e parser.Expr) ([]parser.Expr, string, bool) {
+	if !e.IsFunc() || e.ArgsLen() == 0 || len(e.NamedArgs()) > 0 {
+		return nil, "", false
+	}
+	for _, arg := range e.Args()[1:] {
+		if !arg.IsConst() && !arg.IsString() && !arg.IsBool() {
+			return nil, "", false
+		}
+	}
+
+	first := e.Arg(0)
+	if first.IsName() {
+		return []parser.Expr{e}, first.Target(), true
+	}
+	chain, pathExpression, ok := pushdownChain(first)
+	if !ok {
+		return nil, "", false
+	}
+	return append(chain, e), pathExpression, true
+}
+
+// canPushdown checks if function is supported by backend and doesn't need its arguments for another interval
+func canPushdown(e parser.Expr, functions map[string]struct{}) bool {
+	if _, ok := functions[e.Target()]; !ok {
+		return false
+	}
+	metadata.FunctionMD.RLock()
+	f, ok := metadata.FunctionMD.Functions[e.Target()]
+	metadata.FunctionMD.RUnlock()
+	if !ok {
+		return false
+	}
+	_, adjuster := f.(interfaces.MetricsAdjuster)
+	return !adjuster
+}
+
+func newPushdown(chain []parser.Expr, pathExpression string, from, until int64) *pushdown {
+	p := &pushdown{
+		expr:           chain[len(chain)-1],
+		pathExpression: pathExpression,
+		functions:      make([]*pb.FilteringFunction, 0, len(chain)),
+		levels:         make([]parser.MetricRequest, 0, len(chain)+1),
+	}
+	p.levels = append(p.levels, parser.MetricRequest{Metric: pathExpression, From: from, Until: until})
+	for _, e := range chain {
+		f := &pb.FilteringFunction{Name: e.Target()}
+		for _, arg := range e.Args()[1:] {
+			f.Arguments = append(f.Arguments, arg.ToString())
+		}
+		p.functions = append(p.functions, f)
+		p.levels = append(p.levels, parser.MetricRequest{Metric: e.ToString(), From: from, Until: until})
+	}
+	return p
+}
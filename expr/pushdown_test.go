@@ -0,0 +1,142 @@
+package expr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ansel1/merry"
+	pb "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-graphite/carbonapi/expr/types"
+	"github.com/go-graphite/carbonapi/pkg/parser"
+	th "github.com/go-graphite/carbonapi/tests"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
+)
+
+// pushdownZipper applies sumSeries on the backend side, unless ignoreFunctions is set
+type pushdownZipper struct {
+	th.TestZipper
+	functions       map[string]struct{}
+	ignoreFunctions bool
+	requests        []pb.FetchRequest
+}
+
+func (zp *pushdownZipper) PushdownFunctions(ctx context.Context, pathExpression string) map[string]struct{} {
+	return zp.functions
+}
+
+func (zp *pushdownZipper) Render(ctx context.Context, request pb.MultiFetchRequest) ([]*types.MetricData, *zipperTypes.Stats, merry.Error) {
+	zp.requests = append(zp.requests, request.Metrics...)
+	var resp []*types.MetricData
+	for _, r := range request.Metrics {
+		if len(r.FilterFunctions) == 1 && r.FilterFunctions[0].Name == "sumSeries" && !zp.ignoreFunctions {
+			m := types.MakeMetricData("sumSeries("+r.PathExpression+")", []float64{100, 200}, 1, r.StartTime)
+			m.PathExpression = r.PathExpression
+			m.AppliedFunctions = []string{"sumSeries"}
+			resp = append(resp, m)
+			continue
+		}
+		for _, m := range zp.M[parser.MetricRequest{Metric: r.PathExpression, From: r.StartTime, Until: r.StopTime}] {
+			m.PathExpression = r.PathExpression
+			m.RequestStartTime = r.StartTime
+			m.RequestStopTime = r.StopTime
+			resp = append(resp, m)
+		}
+	}
+	return resp, nil, nil
+}
+
+func TestFetchPushdown(t *testing.T) {
+	var from, until int64 = 0, 2
+	tests := []struct {
+		target          string
+		functions       []string
+		ignoreFunctions bool
+		filtered        bool
+		pushed          []string
+		wantValues      []float64
+	}{
+		{
+			target:     "sumSeries(a.*)",
+			functions:  []string{"sumSeries"},
+			filtered:   true,
+			pushed:     []string{"sumSeries(a.*)"},
+			wantValues: []float64{100, 200},
+		},
+		{
+			target:     "scale(sumSeries(a.*),2)",
+			functions:  []string{"sumSeries"},
+			filtered:   true,
+			pushed:     []string{"sumSeries(a.*)"},
+			wantValues: []float64{200, 400},
+		},
+		{
+			// backend announced function, but didn't apply it
+			target:          "sumSeries(a.*)",
+			functions:       []string{"sumSeries"},
+			ignoreFunctions: true,
+			filtered:        true,
+			wantValues:      []float64{3, 5},
+		},
+		{
+			// raw series are needed anyway
+			target:     "sumSeries(sumSeries(a.*),a.*)",
+			functions:  []string{"sumSeries"},
+			wantValues: []float64{6, 10},
+		},
+		{
+			// series are requested for another interval
+			target:    "timeShift(sumSeries(a.*),'-1s')",
+			functions: []string{"sumSeries"},
+		},
+		{
+			target:     "sumSeries(a.*)",
+			wantValues: []float64{3, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			zp := &pushdownZipper{
+				TestZipper: th.NewTestZipper(map[parser.MetricRequest][]*types.MetricData{
+					{Metric: "a.*", From: from, Until: until}: {
+						types.MakeMetricData("a.b", []float64{1, 2}, 1, from),
+						types.MakeMetricData("a.c", []float64{2, 3}, 1, from),
+					},
+					{Metric: "a.b", From: from, Until: until}: {
+						types.MakeMetricData("a.b", []float64{1, 2}, 1, from),
+					},
+					{Metric: "a.*", From: from - 1, Until: until - 1}: {
+						types.MakeMetricData("a.b", []float64{1, 2}, 1, from-1),
+						types.MakeMetricData("a.c", []float64{2, 3}, 1, from-1),
+					},
+				}),
+				functions:       make(map[string]struct{}),
+				ignoreFunctions: tt.ignoreFunctions,
+			}
+			for _, f := range tt.functions {
+				zp.functions[f] = struct{}{}
+			}
+			eval, err := NewEvaluator(nil, zp, true)
+			assert.NoError(t, err)
+
+			exp, _, err := parser.ParseExpr(tt.target)
+			assert.NoError(t, err)
+			pushedDown := &utilctx.PushedDown{}
+			ctx := utilctx.SetPushedDown(context.Background(), pushedDown)
+
+			res, err := FetchAndEvalExp(ctx, eval, exp, from, until, make(map[parser.MetricRequest][]*types.MetricData))
+			assert.NoError(t, err)
+			if tt.wantValues != nil && assert.Len(t, res, 1) {
+				assert.Equal(t, tt.wantValues, res[0].Values)
+			}
+			assert.Equal(t, tt.pushed, pushedDown.List())
+
+			for _, r := range zp.requests {
+				assert.Equal(t, tt.filtered, len(r.FilterFunctions) > 0, r.PathExpression)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package stl
+
+// STL: A Seasonal-Trend Decomposition Procedure Based on Loess (Cleveland et al., 1990)
+// Implementation follows the original paper with degree 1 loess for all smoothers.
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	DefaultPeriod  = 86400 // Seconds in 1 day
+	DefaultSeasons = 4     // Amount of previous periods that are prefetched to bootstrap the model
+
+	seasonalSpan     = 7 // Span of the cycle-subseries smoother, in periods
+	innerIterations  = 2
+	outerIterations  = 1
+	robustnessFactor = 6
+)
+
+// Result is the decomposition of a series. Residual is NaN where the input was NaN.
+type Result struct {
+	Trend    []float64
+	Seasonal []float64
+	Residual []float64
+}
+
+// PeriodPoints converts period in seconds to the amount of points, forcing it to be at least 2
+func PeriodPoints(period, step int64) int {
+	if step <= 0 {
+		return 2
+	}
+	np := int(period / step)
+	if np < 2 {
+		np = 2
+	}
+	return np
+}
+
+// Decompose splits series into trend, seasonal and residual components. np is amount of points per period.
+// Missing values are linearly interpolated for the decomposition.
+func Decompose(series []float64, np int) Result {
+	n := len(series)
+	res := Result{
+		Trend:    make([]float64, n),
+		Seasonal: make([]float64, n),
+		Residual: make([]float64, n),
+	}
+
+	y, ok := interpolate(series)
+	if !ok {
+		for i := range series {
+			res.Trend[i] = math.NaN()
+			res.Seasonal[i] = math.NaN()
+			res.Residual[i] = math.NaN()
+		}
+		return res
+	}
+
+	if np < 2 {
+		np = 2
+	}
+	nl := nextOdd(np)
+	nt := nextOdd(int(math.Ceil(1.5 * float64(np) / (1 - 1.5/seasonalSpan))))
+
+	trend := res.Trend
+	seasonal := res.Seasonal
+	rw := make([]float64, n)
+	for i := range rw {
+		rw[i] = 1
+	}
+
+	detrended := make([]float64, n)
+	deseasonalized := make([]float64, n)
+	for o := 0; o <= outerIterations; o++ {
+		for k := 0; k < innerIterations; k++ {
+			for i := range y {
+				detrended[i] = y[i] - trend[i]
+			}
+			c := cycleSubseries(detrended, rw, np)
+			l := movingAverage(movingAverage(movingAverage(c, np), np), 3)
+			l = smooth(l, nil, nl)
+			for i := range y {
+				seasonal[i] = c[np+i] - l[i]
+				deseasonalized[i] = y[i] - seasonal[i]
+			}
+			copy(trend, smooth(deseasonalized, rw, nt))
+		}
+
+		if o == outerIterations {
+			break
+		}
+		robustnessWeights(y, trend, seasonal, rw)
+	}
+
+	for i, v := range series {
+		if math.IsNaN(v) {
+			res.Residual[i] = math.NaN()
+		} else {
+			res.Residual[i] = v - trend[i] - seasonal[i]
+		}
+	}
+
+	return res
+}
+
+// MAD returns scaled median absolute deviation (consistent estimator of standard deviation) of non-NaN values
+func MAD(values []float64) float64 {
+	v := make([]float64, 0, len(values))
+	for _, x := range values {
+		if !math.IsNaN(x) {
+			v = append(v, x)
+		}
+	}
+	if len(v) == 0 {
+		return math.NaN()
+	}
+	m := median(v)
+	for i := range v {
+		v[i] = math.Abs(v[i] - m)
+	}
+	return 1.4826 * median(v)
+}
+
+// cycleSubseries smooths each cycle-subseries and extends it by one period on both sides,
+// so the result has len(y)+2*np points
+func cycleSubseries(y, rw []float64, np int) []float64 {
+	n := len(y)
+	c := make([]float64, n+2*np)
+	sub := make([]float64, 0, n/np+1)
+	subW := make([]float64, 0, n/np+1)
+	w := make([]float64, seasonalSpan)
+	for k := 0; k < np; k++ {
+		sub = sub[:0]
+		subW = subW[:0]
+		for i := k; i < n; i += np {
+			sub = append(sub, y[i])
+			subW = append(subW, rw[i])
+		}
+		m := len(sub)
+		for j := -1; j <= m; j++ {
+			idx := (j+1)*np + k
+			if idx >= len(c) {
+				break
+			}
+			c[idx] = loess(sub, subW, seasonalSpan, float64(j), w)
+		}
+	}
+	return c
+}
+
+func robustnessWeights(y, trend, seasonal, rw []float64) {
+	r := make([]float64, len(y))
+	for i := range y {
+		r[i] = math.Abs(y[i] - trend[i] - seasonal[i])
+	}
+	h := robustnessFactor * median(append([]float64(nil), r...))
+	for i := range r {
+		if h == 0 {
+			rw[i] = 1
+			continue
+		}
+		u := r[i] / h
+		if u >= 1 {
+			rw[i] = 0
+		} else {
+			rw[i] = (1 - u*u) * (1 - u*u)
+		}
+	}
+}
+
+func smooth(y, rw []float64, q int) []float64 {
+	res := make([]float64, len(y))
+	w := make([]float64, min(q, len(y)))
+	for i := range y {
+		res[i] = loess(y, rw, q, float64(i), w)
+	}
+	return res
+}
+
+// loess returns value of degree 1 local regression at x, using q nearest points of y (positioned at 0..len(y)-1).
+// w is a buffer for weights of at least min(q, len(y)) elements, so it's allocated once by the caller
+func loess(y, rw []float64, q int, x float64, w []float64) float64 {
+	n := len(y)
+	if n == 0 {
+		return math.NaN()
+	}
+	if n == 1 {
+		return y[0]
+	}
+
+	lo, hi := 0, n-1
+	if q < n {
+		lo = int(math.Round(x)) - q/2
+		if lo < 0 {
+			lo = 0
+		}
+		if lo+q > n {
+			lo = n - q
+		}
+		hi = lo + q - 1
+	}
+	h := math.Max(x-float64(lo), float64(hi)-x)
+	if q > n {
+		h += float64(q-n) / 2
+	}
+	if h <= 0 {
+		h = 1
+	}
+
+	var sw, swx, swy float64
+	w = w[:hi-lo+1]
+	for i := lo; i <= hi; i++ {
+		d := math.Abs(float64(i)-x) / h
+		if d >= 1 {
+			w[i-lo] = 0
+			continue
+		}
+		t := 1 - d*d*d
+		wi := t * t * t
+		if rw != nil {
+			wi *= rw[i]
+		}
+		w[i-lo] = wi
+		sw += wi
+		swx += wi * float64(i)
+		swy += wi * y[i]
+	}
+	if sw == 0 {
+		return y[int(math.Min(math.Max(math.Round(x), 0), float64(n-1)))]
+	}
+
+	mx := swx / sw
+	my := swy / sw
+	var sxx, sxy float64
+	for i := lo; i <= hi; i++ {
+		dx := float64(i) - mx
+		sxx += w[i-lo] * dx * dx
+		sxy += w[i-lo] * dx * (y[i] - my)
+	}
+	if sxx == 0 {
+		return my
+	}
+	return my + sxy/sxx*(x-mx)
+}
+
+func movingAverage(y []float64, w int) []float64 {
+	if len(y) < w {
+		return nil
+	}
+	res := make([]float64, len(y)-w+1)
+	var sum float64
+	for i := 0; i < w; i++ {
+		sum += y[i]
+	}
+	res[0] = sum / float64(w)
+	for i := w; i < len(y); i++ {
+		sum += y[i] - y[i-w]
+		res[i-w+1] = sum / float64(w)
+	}
+	return res
+}
+
+// interpolate fills missing values linearly, leading and trailing ones with the nearest known value
+func interpolate(series []float64) ([]float64, bool) {
+	y := make([]float64, len(series))
+	last := -1
+	for i, v := range series {
+		y[i] = v
+		if math.IsNaN(v) {
+			continue
+		}
+		if last == -1 {
+			for j := 0; j < i; j++ {
+				y[j] = v
+			}
+		} else if i-last > 1 {
+			delta := (v - y[last]) / float64(i-last)
+			for j := last + 1; j < i; j++ {
+				y[j] = y[last] + delta*float64(j-last)
+			}
+		}
+		last = i
+	}
+	if last == -1 {
+		return nil, false
+	}
+	for j := last + 1; j < len(y); j++ {
+		y[j] = y[last]
+	}
+	return y, true
+}
+
+func median(v []float64) float64 {
+	sort.Float64s(v)
+	n := len(v)
+	if n%2 == 1 {
+		return v[n/2]
+	}
+	return (v[n/2-1] + v[n/2]) / 2
+}
+
+func nextOdd(v int) int {
+	if v%2 == 0 {
+		return v + 1
+	}
+	return v
+}
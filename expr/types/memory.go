@@ -0,0 +1,37 @@
+package types
+
+import (
+	"context"
+
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+)
+
+// SetMemoryAccountant sets memory accountant of the request the series belongs to. Values allocated for the series and
+// for its copies with types helpers are charged in it.
+func (r *MetricData) SetMemoryAccountant(a *utilctx.MemoryAccountant) *MetricData {
+	r.memoryAccountant = a
+	return r
+}
+
+// MakeValues allocates values for n points (none if n is negative) and charges them in memory accountant of the
+// request (if any), series copied from r later are charged in the same accountant. It returns
+// utilctx.ErrMemoryLimitExceeded without allocating if request would go over the limit.
+func (r *MetricData) MakeValues(ctx context.Context, n int) error {
+	n = max(n, 0)
+	a := utilctx.GetMemoryAccountant(ctx)
+	if err := a.Charge(int64(n) * utilctx.Float64Size); err != nil {
+		return err
+	}
+	r.memoryAccountant = a
+	r.Values = make([]float64, n)
+	return nil
+}
+
+// NewValues returns values for n points (none if n is negative), they are charged in memory accountant of the series
+// before they are allocated. Exceeded limit is kept by accountant and stops the request when the function is done, see
+// utilctx.MemoryAccountant.Err.
+func (r *MetricData) NewValues(n int) []float64 {
+	n = max(n, 0)
+	_ = r.memoryAccountant.Charge(int64(n) * utilctx.Float64Size)
+	return make([]float64, n)
+}
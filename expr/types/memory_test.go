@@ -0,0 +1,68 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ansel1/merry"
+
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+)
+
+func TestMemoryAccounting(t *testing.T) {
+	a := utilctx.NewMemoryAccountant(10 * 8)
+	ctx := utilctx.SetMemoryAccountant(context.Background(), a)
+
+	m := MakeMetricData("a", []float64{1, 2, 3, 4}, 1, 0).SetMemoryAccountant(a)
+	linked := m.CopyLinkTags()
+	if a.Peak() != 0 {
+		t.Errorf("shared values shouldn't be charged, got %d bytes", a.Peak())
+	}
+
+	copied := linked.Copy(true)
+	if a.Peak() != 4*8 {
+		t.Errorf("copied values should be charged in accountant of the series, got %d bytes", a.Peak())
+	}
+
+	r := copied.CopyName("b")
+	if err := r.MakeValues(ctx, 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Peak() != 8*8 {
+		t.Errorf("values allocated by MakeValues should be charged, got %d bytes", a.Peak())
+	}
+
+	r = m.CopyLinkTags()
+	if err := r.MakeValues(ctx, 4); !merry.Is(err, utilctx.ErrMemoryLimitExceeded) {
+		t.Errorf("expected ErrMemoryLimitExceeded, got %v", err)
+	}
+	if r.Values[0] != 1 {
+		t.Error("values shouldn't be allocated over the limit")
+	}
+	if merry.HTTPCode(a.Err()) != 422 {
+		t.Errorf("expected 422, got %d", merry.HTTPCode(a.Err()))
+	}
+
+	a = utilctx.NewMemoryAccountant(10 * 8)
+	m.SetMemoryAccountant(a)
+	m.SetValuesPerPoint(2)
+	m.AggregateValues()
+	if a.Peak() != 3*8 {
+		t.Errorf("consolidated values should be charged before they are allocated, got %d bytes", a.Peak())
+	}
+	if values := m.CopyNameWithVal("c").NewValues(8); len(values) != 8 {
+		t.Errorf("expected 8 values, got %d", len(values))
+	}
+	if !merry.Is(a.Err(), utilctx.ErrMemoryLimitExceeded) {
+		t.Errorf("expected ErrMemoryLimitExceeded to be kept by accountant, got %v", a.Err())
+	}
+
+	m = MakeMetricData("a", []float64{1, 2, 3, 4}, 1, 0)
+	if err := m.MakeValues(context.Background(), 4); err != nil {
+		t.Errorf("unexpected error without accountant: %v", err)
+	}
+	m.Copy(true)
+	if utilctx.NewMemoryAccountant(0) != nil {
+		t.Error("accountant shouldn't be created without limit")
+	}
+}
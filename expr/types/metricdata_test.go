@@ -55,6 +55,50 @@ func TestJSONResponseNoNullPoints(t *testing.T) {
 	}
 }
 
+func makeHighPrecisionMetricData(name string, values []float64, step, start int64) *MetricData {
+	r := MakeMetricData(name, values, step, start)
+	r.HighPrecisionTimestamps = true
+	return r
+}
+
+func TestJSONResponseHighPrecision(t *testing.T) {
+
+	tests := []struct {
+		results             []*MetricData
+		timestampMultiplier int64
+		out                 []byte
+	}{
+		{
+			[]*MetricData{
+				makeHighPrecisionMetricData("metric1", []float64{1, 1.5, math.NaN()}, 250, 100500),
+			},
+			1,
+			[]byte(`[{"target":"metric1","datapoints":[[1,100.5],[1.5,100.75],[null,101]],"tags":{"name":"metric1"}}]`),
+		},
+		{
+			[]*MetricData{
+				makeHighPrecisionMetricData("metric1", []float64{1, 1.5, math.NaN()}, 250, 100500),
+			},
+			1000,
+			[]byte(`[{"target":"metric1","datapoints":[[1,100500],[1.5,100750],[null,101000]],"tags":{"name":"metric1"}}]`),
+		},
+		{
+			[]*MetricData{
+				makeHighPrecisionMetricData("metric1", []float64{1}, 250, 100500),
+			},
+			1000000,
+			[]byte(`[{"target":"metric1","datapoints":[[1,100500000]],"tags":{"name":"metric1"}}]`),
+		},
+	}
+
+	for _, tt := range tests {
+		b := MarshalJSON(tt.results, tt.timestampMultiplier, false)
+		if !bytes.Equal(b, tt.out) {
+			t.Errorf("marshalJSON(%+v, %d): got\n%+v\nwant\n%+v", tt.results, tt.timestampMultiplier, string(b), string(tt.out))
+		}
+	}
+}
+
 func TestRawResponse(t *testing.T) {
 
 	tests := []struct {
@@ -245,3 +289,17 @@ func BenchmarkMarshalCSVLong(b *testing.B) {
 		_ = MarshalCSV(data)
 	}
 }
+
+func TestCSVResponseHighPrecision(t *testing.T) {
+	results := []*MetricData{
+		makeHighPrecisionMetricData("metric1", []float64{1, 1.5, math.NaN()}, 45, 100005),
+	}
+	want := `"metric1",1970-01-01 00:01:40.005,1` + "\n" +
+		`"metric1",1970-01-01 00:01:40.050,1.5` + "\n" +
+		`"metric1",1970-01-01 00:01:40.095,` + "\n"
+
+	b := MarshalCSV(results)
+	if string(b) != want {
+		t.Errorf("marshalCSV(%+v): \n%+v\nwant\n%+v", results, string(b), want)
+	}
+}
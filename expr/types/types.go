@@ -12,6 +12,7 @@ import (
 	"github.com/go-graphite/carbonapi/expr/consolidations"
 	"github.com/go-graphite/carbonapi/expr/tags"
 	"github.com/go-graphite/carbonapi/expr/types/config"
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
 	pbv2 "github.com/go-graphite/protocol/carbonapi_v2_pb"
 	pb "github.com/go-graphite/protocol/carbonapi_v3_pb"
 	pickle "github.com/lomik/og-rek"
@@ -32,6 +33,7 @@ type MetricData struct {
 
 	ValuesPerPoint    int
 	aggregatedValues  []float64
+	memoryAccountant  *utilctx.MemoryAccountant
 	Tags              map[string]string
 	AggregateFunction func([]float64) float64 `json:"-"`
 }
@@ -44,6 +46,13 @@ func appendInt2(b []byte, n int64) []byte {
 	return strconv.AppendInt(b, n, 10)
 }
 
+func appendInt3(b []byte, n int64) []byte {
+	if n < 100 {
+		b = append(b, '0')
+	}
+	return appendInt2(b, n)
+}
+
 // MarshalCSV marshals metric data to CSV
 func MarshalCSV(results []*MetricData) []byte {
 	if len(results) == 0 {
@@ -61,6 +70,9 @@ func MarshalCSV(results []*MetricData) []byte {
 			b = append(b, r.Name...)
 			b = append(b, `",`...)
 			tm := time.Unix(t, 0).UTC()
+			if r.HighPrecisionTimestamps {
+				tm = time.UnixMilli(t).UTC()
+			}
 			b = strconv.AppendInt(b, int64(tm.Year()), 10)
 			b = append(b, '-')
 			b = appendInt2(b, int64(tm.Month()))
@@ -72,6 +84,10 @@ func MarshalCSV(results []*MetricData) []byte {
 			b = appendInt2(b, int64(tm.Minute()))
 			b = append(b, ':')
 			b = appendInt2(b, int64(tm.Second()))
+			if r.HighPrecisionTimestamps {
+				b = append(b, '.')
+				b = appendInt3(b, int64(tm.Nanosecond()/int(time.Millisecond)))
+			}
 			b = append(b, ',')
 			if !math.IsNaN(v) {
 				b = strconv.AppendFloat(b, v, 'f', -1, 64)
@@ -116,6 +132,18 @@ func ConsolidateJSON(maxDataPoints int64, results []*MetricData) {
 	}
 }
 
+// appendTimestamp appends timestamp t scaled by timestampMultiplier. High precision timestamps are stored in
+// milliseconds, so they are printed as fractional seconds when seconds were requested.
+func appendTimestamp(b []byte, t, timestampMultiplier int64, highPrecision bool) []byte {
+	if !highPrecision {
+		return strconv.AppendInt(b, t*timestampMultiplier, 10)
+	}
+	if timestampMultiplier == 1 {
+		return strconv.AppendFloat(b, float64(t)/1000, 'f', -1, 64)
+	}
+	return strconv.AppendInt(b, t*timestampMultiplier/1000, 10)
+}
+
 // MarshalJSON marshals metric data to JSON
 func MarshalJSON(results []*MetricData, timestampMultiplier int64, noNullPoints bool) []byte {
 	if len(results) == 0 {
@@ -142,10 +170,10 @@ func MarshalJSON(results []*MetricData, timestampMultiplier int64, noNullPoints
 		b = append(b, `,"datapoints":[`...)
 
 		var innerComma bool
-		t := r.AggregatedStartTime() * timestampMultiplier
+		t := r.AggregatedStartTime()
 		for _, v := range r.AggregatedValues() {
 			if noNullPoints && math.IsNaN(v) {
-				t += r.AggregatedTimeStep() * timestampMultiplier
+				t += r.AggregatedTimeStep()
 			} else {
 				if innerComma {
 					b = append(b, ',')
@@ -162,11 +190,11 @@ func MarshalJSON(results []*MetricData, timestampMultiplier int64, noNullPoints
 
 				b = append(b, ',')
 
-				b = strconv.AppendInt(b, t, 10)
+				b = appendTimestamp(b, t, timestampMultiplier, r.HighPrecisionTimestamps)
 
 				b = append(b, ']')
 
-				t += r.AggregatedTimeStep() * timestampMultiplier
+				t += r.AggregatedTimeStep()
 			}
 		}
 
@@ -409,32 +437,38 @@ func (r *MetricData) AggregatedValues() []float64 {
 // AggregateValues aggregates values
 func (r *MetricData) AggregateValues() {
 	if r.ValuesPerPoint == 1 || r.ValuesPerPoint == 0 {
-		r.aggregatedValues = make([]float64, len(r.Values))
+		r.aggregatedValues = r.NewValues(len(r.Values))
 		copy(r.aggregatedValues, r.Values)
 		return
 	}
 	aggFunc := r.GetAggregateFunction()
 
 	n := len(r.Values)/r.ValuesPerPoint + 1
-	aggV := make([]float64, 0, n)
+	aggV := r.NewValues(n)[:0]
 
 	nudgeCount := r.nudgePointsCount()
 	v := r.Values[nudgeCount:]
 
 	for len(v) >= r.ValuesPerPoint {
-		val := aggFunc(v[:r.ValuesPerPoint])
-		aggV = append(aggV, val)
+		aggV = append(aggV, r.aggregateBucket(aggFunc, v[:r.ValuesPerPoint]))
 		v = v[r.ValuesPerPoint:]
 	}
 
 	if len(v) > 0 {
-		val := aggFunc(v)
-		aggV = append(aggV, val)
+		aggV = append(aggV, r.aggregateBucket(aggFunc, v))
 	}
 
 	r.aggregatedValues = aggV
 }
 
+// aggregateBucket consolidates values of a single bucket, bucket is null if it doesn't satisfy xFilesFactor of the series
+func (r *MetricData) aggregateBucket(aggFunc func([]float64) float64, values []float64) float64 {
+	if !consolidations.XFilesFactorValues(values, r.XFilesFactor) {
+		return math.NaN()
+	}
+	return aggFunc(values)
+}
+
 // Copy returns the copy of r. If includeValues set to true, it copies values as well.
 func (r *MetricData) Copy(includeValues bool) *MetricData {
 	var values, aggregatedValues []float64
@@ -443,11 +477,11 @@ func (r *MetricData) Copy(includeValues bool) *MetricData {
 	aggregatedValues = nil
 
 	if includeValues {
-		values = make([]float64, len(r.Values))
+		values = r.NewValues(len(r.Values))
 		copy(values, r.Values)
 
 		if r.aggregatedValues != nil {
-			aggregatedValues = make([]float64, len(r.aggregatedValues))
+			aggregatedValues = r.NewValues(len(r.aggregatedValues))
 			copy(aggregatedValues, r.aggregatedValues)
 		}
 
@@ -478,6 +512,7 @@ func (r *MetricData) Copy(includeValues bool) *MetricData {
 		GraphOptions:      r.GraphOptions,
 		ValuesPerPoint:    r.ValuesPerPoint,
 		aggregatedValues:  aggregatedValues,
+		memoryAccountant:  r.memoryAccountant,
 		Tags:              tags,
 		AggregateFunction: r.AggregateFunction,
 	}
@@ -513,6 +548,7 @@ func (r *MetricData) CopyLink() *MetricData {
 		GraphOptions:      r.GraphOptions,
 		ValuesPerPoint:    r.ValuesPerPoint,
 		aggregatedValues:  r.aggregatedValues,
+		memoryAccountant:  r.memoryAccountant,
 		Tags:              tags,
 		AggregateFunction: r.AggregateFunction,
 	}
@@ -538,6 +574,7 @@ func (r *MetricData) CopyLinkTags() *MetricData {
 		GraphOptions:      r.GraphOptions,
 		ValuesPerPoint:    r.ValuesPerPoint,
 		aggregatedValues:  r.aggregatedValues,
+		memoryAccountant:  r.memoryAccountant,
 		Tags:              r.Tags,
 		AggregateFunction: r.AggregateFunction,
 	}
@@ -583,6 +620,7 @@ func (r *MetricData) CopyNameWithDefault(name, defaultName string) *MetricData {
 		GraphOptions:      r.GraphOptions,
 		ValuesPerPoint:    r.ValuesPerPoint,
 		aggregatedValues:  r.aggregatedValues,
+		memoryAccountant:  r.memoryAccountant,
 		Tags:              tags,
 		AggregateFunction: r.AggregateFunction,
 	}
@@ -613,6 +651,7 @@ func (r *MetricData) CopyTag(name string, tags map[string]string) *MetricData {
 		GraphOptions:      r.GraphOptions,
 		ValuesPerPoint:    r.ValuesPerPoint,
 		aggregatedValues:  r.aggregatedValues,
+		memoryAccountant:  r.memoryAccountant,
 		Tags:              tags,
 		AggregateFunction: r.AggregateFunction,
 	}
@@ -656,6 +695,7 @@ func (r *MetricData) CopyNameArg(name, defaultName string, defaultTags map[strin
 		GraphOptions:      r.GraphOptions,
 		ValuesPerPoint:    r.ValuesPerPoint,
 		aggregatedValues:  r.aggregatedValues,
+		memoryAccountant:  r.memoryAccountant,
 		Tags:              tagsExtracted,
 		AggregateFunction: r.AggregateFunction,
 	}
@@ -667,7 +707,7 @@ func (r *MetricData) CopyNameWithVal(name string) *MetricData {
 		return r.Copy(true)
 	}
 
-	values := make([]float64, len(r.Values))
+	values := r.NewValues(len(r.Values))
 	copy(values, r.Values)
 
 	tags := tags.ExtractTags(ExtractName(name))
@@ -690,6 +730,7 @@ func (r *MetricData) CopyNameWithVal(name string) *MetricData {
 		GraphOptions:      r.GraphOptions,
 		ValuesPerPoint:    r.ValuesPerPoint,
 		aggregatedValues:  r.aggregatedValues,
+		memoryAccountant:  r.memoryAccountant,
 		Tags:              tags,
 		AggregateFunction: r.AggregateFunction,
 	}
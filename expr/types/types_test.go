@@ -1,6 +1,7 @@
 package types
 
 import (
+	"math"
 	"testing"
 
 	"github.com/go-graphite/carbonapi/expr/types/config"
@@ -220,3 +221,52 @@ func TestAggregatedValuesConfigVariants(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregatedValuesXFilesFactor(t *testing.T) {
+	config.Config.NudgeStartTimeOnAggregation = false
+	config.Config.UseBucketsHighestTimestampOnAggregation = false
+
+	nan := math.NaN()
+	values := []float64{1, nan, nan, nan, 3, 5, nan, nan, 7}
+
+	tests := []struct {
+		name         string
+		xFilesFactor float32
+		want         []float64
+	}{
+		{
+			name:         "no xFilesFactor, only empty buckets are null",
+			xFilesFactor: 0,
+			want:         []float64{1, nan, 4, nan, 7},
+		},
+		{
+			name:         "exactly half of points",
+			xFilesFactor: 0.5,
+			want:         []float64{1, nan, 4, nan, 7},
+		},
+		{
+			name:         "more than half of points",
+			xFilesFactor: 0.6,
+			want:         []float64{nan, nan, 4, nan, 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := MakeMetricData("test", values, 10, 10)
+			input.XFilesFactor = tt.xFilesFactor
+			input.SetValuesPerPoint(2)
+
+			got := input.AggregatedValues()
+			if assert.Len(t, got, len(tt.want)) {
+				for i := range tt.want {
+					if math.IsNaN(tt.want[i]) {
+						assert.True(t, math.IsNaN(got[i]), "point %d: want NaN, got %v", i, got[i])
+					} else {
+						assert.Equal(t, tt.want[i], got[i], "point %d", i)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package auth
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/ansel1/merry"
+
+	"github.com/go-graphite/carbonapi/expr/tags"
+)
+
+// tagConstraint is a seriesByTag expression, missing tag has empty value
+type tagConstraint struct {
+	expr  string
+	tag   string
+	value string
+	re    *regexp.Regexp
+	not   bool
+}
+
+func newTagConstraint(expr string) (*tagConstraint, error) {
+	tag, value, ok := strings.Cut(expr, "=")
+	if !ok || tag == "" || tag == "!" {
+		return nil, merry.Errorf("invalid tag constraint %q", expr)
+	}
+	c := &tagConstraint{expr: expr, tag: tag, value: value}
+	if strings.HasSuffix(tag, "!") {
+		c.tag = tag[:len(tag)-1]
+		c.not = true
+	}
+	if strings.HasPrefix(value, "~") {
+		re, err := regexp.Compile("^(?:" + value[1:] + ")")
+		if err != nil {
+			return nil, merry.Prependf(err, "invalid tag constraint %q", expr)
+		}
+		c.re = re
+	}
+	return c, nil
+}
+
+func (c *tagConstraint) match(seriesTags map[string]string) bool {
+	v := seriesTags[c.tag]
+	var matched bool
+	if c.re != nil {
+		matched = c.re.MatchString(v)
+	} else {
+		matched = v == c.value
+	}
+	return matched != c.not
+}
+
+// globNode converts node of graphite glob to regexp
+func globNode(node string) string {
+	var sb strings.Builder
+	for len(node) > 0 {
+		n := strings.IndexAny(node, "*?[{")
+		if n < 0 {
+			sb.WriteString(regexp.QuoteMeta(node))
+			break
+		}
+		sb.WriteString(regexp.QuoteMeta(node[:n]))
+		ch := node[n]
+		node = node[n+1:]
+		switch ch {
+		case '*':
+			sb.WriteString("[^.]*")
+		case '?':
+			sb.WriteString("[^.]")
+		case '[':
+			end := strings.IndexByte(node, ']')
+			if end < 0 {
+				sb.WriteString(regexp.QuoteMeta("[" + node))
+				node = ""
+				continue
+			}
+			sb.WriteString("[" + node[:end] + "]")
+			node = node[end+1:]
+		case '{':
+			end := strings.IndexByte(node, '}')
+			if end < 0 {
+				sb.WriteString(regexp.QuoteMeta("{" + node))
+				node = ""
+				continue
+			}
+			alts := strings.Split(node[:end], ",")
+			for i := range alts {
+				alts[i] = regexp.QuoteMeta(alts[i])
+			}
+			sb.WriteString("(?:" + strings.Join(alts, "|") + ")")
+			node = node[end+1:]
+		}
+	}
+	return sb.String()
+}
+
+// prefix is a glob that matches the first nodes of metric name
+type prefix struct {
+	glob  string
+	nodes []*regexp.Regexp
+	// re matches names that start with prefix, it's used in tag expressions
+	re string
+}
+
+func newPrefix(glob string) (*prefix, error) {
+	p := &prefix{glob: glob}
+	nodes := strings.Split(glob, ".")
+	res := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		re := globNode(node)
+		compiled, err := regexp.Compile("^(?:" + re + ")$")
+		if err != nil {
+			return nil, merry.Prependf(err, "invalid prefix %q", glob)
+		}
+		p.nodes = append(p.nodes, compiled)
+		res = append(res, "(?:"+re+")")
+	}
+	p.re = strings.Join(res, `\.`) + `(?:\.|$)`
+	return p, nil
+}
+
+// match checks that the first nodes of path match prefix. If partial is set, path can be shorter than prefix,
+// so branches that lead to allowed metrics are matched too.
+func (p *prefix) match(nodes []string, partial bool) bool {
+	if len(nodes) < len(p.nodes) && !partial {
+		return false
+	}
+	for i, node := range nodes {
+		if i >= len(p.nodes) {
+			break
+		}
+		if !p.nodes[i].MatchString(node) {
+			return false
+		}
+	}
+	return true
+}
+
+type rule struct {
+	cfg      Rule
+	users    map[string]struct{}
+	groups   map[string]struct{}
+	prefixes []*prefix
+	tags     []*tagConstraint
+}
+
+func newRule(cfg Rule) (*rule, error) {
+	r := &rule{
+		cfg:    cfg,
+		users:  make(map[string]struct{}, len(cfg.Users)),
+		groups: make(map[string]struct{}, len(cfg.Groups)),
+	}
+	for _, u := range cfg.Users {
+		r.users[u] = struct{}{}
+	}
+	for _, g := range cfg.Groups {
+		r.groups[g] = struct{}{}
+	}
+	for _, glob := range cfg.Prefixes {
+		p, err := newPrefix(glob)
+		if err != nil {
+			return nil, err
+		}
+		r.prefixes = append(r.prefixes, p)
+	}
+	for _, expr := range cfg.Tags {
+		c, err := newTagConstraint(expr)
+		if err != nil {
+			return nil, err
+		}
+		r.tags = append(r.tags, c)
+	}
+	return r, nil
+}
+
+func (r *rule) appliesTo(id *Identity) bool {
+	if _, ok := r.users["*"]; ok {
+		return true
+	}
+	if _, ok := r.groups["*"]; ok {
+		return true
+	}
+	if _, ok := r.users[id.User]; ok {
+		return true
+	}
+	for _, g := range id.Groups {
+		if _, ok := r.groups[g]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *rule) unrestricted() bool {
+	return len(r.prefixes) == 0 && len(r.tags) == 0
+}
+
+func (r *rule) matchName(nodes []string, partial bool) bool {
+	if len(r.prefixes) == 0 {
+		return true
+	}
+	for _, p := range r.prefixes {
+		if p.match(nodes, partial) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *rule) allowMetric(name string) bool {
+	if !strings.Contains(name, ";") {
+		return len(r.tags) == 0 && r.matchName(strings.Split(name, "."), false)
+	}
+	seriesTags := tags.ExtractTags(name)
+	if !r.matchName(strings.Split(seriesTags["name"], "."), false) {
+		return false
+	}
+	for _, c := range r.tags {
+		if !c.match(seriesTags) {
+			return false
+		}
+	}
+	return true
+}
+
+// tagExprs returns seriesByTag expressions that select only series allowed by the rule
+func (r *rule) tagExprs() []string {
+	exprs := make([]string, 0, len(r.tags)+1)
+	if len(r.prefixes) > 0 {
+		res := make([]string, 0, len(r.prefixes))
+		for _, p := range r.prefixes {
+			res = append(res, p.re)
+		}
+		exprs = append(exprs, "name=~^(?:"+strings.Join(res, "|")+")")
+	}
+	for _, c := range r.tags {
+		exprs = append(exprs, c.expr)
+	}
+	return exprs
+}
+
+// ACL describes metrics that user is allowed to read
+type ACL struct {
+	rules []*rule
+	key   string
+}
+
+func newACL(rules []*rule) *ACL {
+	cfgs := make([]Rule, 0, len(rules))
+	for _, r := range rules {
+		cfgs = append(cfgs, Rule{Prefixes: r.cfg.Prefixes, Tags: r.cfg.Tags})
+	}
+	b, _ := json.Marshal(cfgs)
+	sum := sha1.Sum(b)
+	return &ACL{
+		rules: rules,
+		key:   "acl:" + hex.EncodeToString(sum[:8]),
+	}
+}
+
+// Key identifies set of allowed metrics, responses for users with the same key can be shared
+func (a *ACL) Key() string {
+	return a.key
+}
+
+// AllowMetric checks if user can read the metric, name can contain tags (name;tag=value)
+func (a *ACL) AllowMetric(name string) bool {
+	for _, r := range a.rules {
+		if r.allowMetric(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowBranch checks if there are metrics that user can read under the path
+func (a *ACL) AllowBranch(path string) bool {
+	nodes := strings.Split(path, ".")
+	for _, r := range a.rules {
+		if len(r.tags) == 0 && r.matchName(nodes, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// TagExprs returns sets of seriesByTag expressions that select metrics user can read, one set per rule
+func (a *ACL) TagExprs() [][]string {
+	exprs := make([][]string, 0, len(a.rules))
+	for _, r := range a.rules {
+		exprs = append(exprs, r.tagExprs())
+	}
+	return exprs
+}
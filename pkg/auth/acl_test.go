@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/ansel1/merry"
+	pb "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-graphite/carbonapi/expr/types"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
+)
+
+func newTestACL(t *testing.T, rules ...Rule) *ACL {
+	a, err := New(Config{HtpasswdFile: writeFile(t, "htpasswd", ""), Rules: rules})
+	if err != nil {
+		t.Fatal(err)
+	}
+	acl, err := a.ACL(&Identity{User: "alice", Groups: []string{"team1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return acl
+}
+
+func TestACL(t *testing.T) {
+	acl := newTestACL(t,
+		Rule{Users: []string{"alice"}, Prefixes: []string{"team1.*.cpu", "shared.{a,b}"}},
+		Rule{Groups: []string{"team1"}, Tags: []string{"team=team1", "env!=prod"}},
+		Rule{Users: []string{"bob"}, Prefixes: []string{"team2"}},
+	)
+
+	metrics := []struct {
+		name string
+		want bool
+	}{
+		{"team1.host1.cpu.user", true},
+		{"team1.host1.cpu", true},
+		{"team1.host1.mem.used", false},
+		{"team1.host1", false},
+		{"shared.a.x", true},
+		{"shared.c.x", false},
+		{"team2.host1.cpu.user", false},
+		{"cpu;team=team1", true},
+		{"cpu;team=team1;env=prod", false},
+		{"cpu;team=team2", false},
+		{"team1.host1.cpu.user;env=prod", true},
+		{"team1.host1.mem;team=team1;env=prod", false},
+	}
+	for _, tt := range metrics {
+		assert.Equal(t, tt.want, acl.AllowMetric(tt.name), tt.name)
+	}
+
+	branches := []struct {
+		path string
+		want bool
+	}{
+		{"team1", true},
+		{"team1.host1", true},
+		{"team1.host1.mem", false},
+		{"team2", false},
+		{"shared", true},
+	}
+	for _, tt := range branches {
+		assert.Equal(t, tt.want, acl.AllowBranch(tt.path), tt.path)
+	}
+
+	assert.Equal(t, [][]string{
+		{`name=~^(?:(?:team1)\.(?:[^.]*)\.(?:cpu)(?:\.|$)|(?:shared)\.(?:(?:a|b))(?:\.|$))`},
+		{"team=team1", "env!=prod"},
+	}, acl.TagExprs())
+
+	assert.Equal(t, acl.Key(), newTestACL(t, Rule{Users: []string{"*"}, Prefixes: []string{"team1.*.cpu", "shared.{a,b}"}}, Rule{Groups: []string{"team1"}, Tags: []string{"team=team1", "env!=prod"}}).Key())
+	assert.NotEqual(t, acl.Key(), newTestACL(t, Rule{Users: []string{"alice"}, Prefixes: []string{"team1"}}).Key())
+}
+
+func TestACLRules(t *testing.T) {
+	a, err := New(Config{HtpasswdFile: writeFile(t, "htpasswd", ""), Rules: []Rule{
+		{Groups: []string{"admins"}},
+		{Users: []string{"alice"}, Prefixes: []string{"team1"}},
+	}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	acl, err := a.ACL(&Identity{User: "root", Groups: []string{"admins"}})
+	assert.NoError(t, err)
+	assert.Nil(t, acl, "rule without restrictions allows everything")
+
+	acl, err = a.ACL(&Identity{User: "alice"})
+	assert.NoError(t, err)
+	assert.NotNil(t, acl)
+
+	_, err = a.ACL(&Identity{User: "bob"})
+	assert.True(t, merry.Is(err, ErrForbidden))
+
+	_, err = New(Config{Rules: []Rule{{Users: []string{"alice"}}}})
+	assert.Error(t, err, "rules without authentication")
+}
+
+type fakeZipper struct {
+	queries []string
+}
+
+func (z *fakeZipper) Find(_ context.Context, request pb.MultiGlobRequest) (*pb.MultiGlobResponse, *zipperTypes.Stats, merry.Error) {
+	return &pb.MultiGlobResponse{Metrics: []pb.GlobResponse{{
+		Name: request.Metrics[0],
+		Matches: []pb.GlobMatch{
+			{Path: "team1", IsLeaf: false},
+			{Path: "team2", IsLeaf: false},
+			{Path: "team1.cpu", IsLeaf: true},
+			{Path: "team2.cpu", IsLeaf: true},
+		},
+	}}}, nil, nil
+}
+
+func (z *fakeZipper) Info(_ context.Context, _ []string) (*pb.ZipperInfoResponse, *zipperTypes.Stats, merry.Error) {
+	return nil, nil, nil
+}
+
+func (z *fakeZipper) RenderCompat(ctx context.Context, metrics []string, _, _ int64) ([]*types.MetricData, *zipperTypes.Stats, merry.Error) {
+	return z.Render(ctx, pb.MultiFetchRequest{})
+}
+
+func (z *fakeZipper) Render(_ context.Context, _ pb.MultiFetchRequest) ([]*types.MetricData, *zipperTypes.Stats, merry.Error) {
+	return []*types.MetricData{
+		types.MakeMetricData("team1.cpu", []float64{1}, 60, 0),
+		types.MakeMetricData("team2.cpu", []float64{2}, 60, 0),
+	}, nil, nil
+}
+
+func (z *fakeZipper) TagNames(_ context.Context, query string, _ int64) ([]string, merry.Error) {
+	z.queries = append(z.queries, query)
+	return []string{"name", "team"}, nil
+}
+
+func (z *fakeZipper) TagValues(_ context.Context, query string, _ int64) ([]string, merry.Error) {
+	return nil, nil
+}
+
+func (z *fakeZipper) FindSeries(_ context.Context, query string, _ int64) ([]string, merry.Error) {
+	return []string{"team1.cpu;env=dev", "team2.cpu;env=dev"}, nil
+}
+
+func (z *fakeZipper) ScaleToCommonStep() bool {
+	return false
+}
+
+func (z *fakeZipper) PushdownFunctions(_ context.Context, _ string) map[string]struct{} {
+	return map[string]struct{}{"sumSeries": {}}
+}
+
+func (z *fakeZipper) List(_ context.Context) (*pb.ListMetricsResponse, *zipperTypes.Stats, merry.Error) {
+	return &pb.ListMetricsResponse{Metrics: []string{"team1.cpu", "team2.cpu"}}, nil, nil
+}
+
+func (z *fakeZipper) Details(_ context.Context) (*pb.MetricDetailsResponse, *zipperTypes.Stats, merry.Error) {
+	return &pb.MetricDetailsResponse{Metrics: map[string]*pb.MetricDetails{"team1.cpu": {}, "team2.cpu": {}}}, nil, nil
+}
+
+func TestZipper(t *testing.T) {
+	fake := &fakeZipper{}
+	z := NewZipper(fake)
+	ctx := SetACL(context.Background(), newTestACL(t, Rule{Users: []string{"alice"}, Prefixes: []string{"team1"}}))
+
+	find, _, err := z.Find(ctx, pb.MultiGlobRequest{Metrics: []string{"*"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []pb.GlobMatch{{Path: "team1"}, {Path: "team1.cpu", IsLeaf: true}}, find.Metrics[0].Matches)
+
+	series, _, err := z.Render(ctx, pb.MultiFetchRequest{})
+	assert.NoError(t, err)
+	if assert.Len(t, series, 1) {
+		assert.Equal(t, "team1.cpu", series[0].Name)
+	}
+
+	names, err := z.FindSeries(ctx, "expr=env%3Ddev", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"team1.cpu;env=dev"}, names)
+
+	_, err = z.TagNames(ctx, "tagPrefix=t&expr=env%3Ddev", 1)
+	assert.NoError(t, err)
+	if assert.Len(t, fake.queries, 1) {
+		q, _ := url.ParseQuery(fake.queries[0])
+		assert.Equal(t, []string{"env=dev", `name=~^(?:(?:team1)(?:\.|$))`}, q["expr"])
+		assert.Equal(t, "t", q.Get("tagPrefix"))
+	}
+
+	list, _, err := z.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"team1.cpu"}, list.Metrics)
+
+	details, _, err := z.Details(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*pb.MetricDetails{"team1.cpu": {}}, details.Metrics)
+
+	assert.Nil(t, z.PushdownFunctions(ctx, "team1.*"), "pushdown must be disabled for users with ACL")
+	assert.NotNil(t, z.PushdownFunctions(context.Background(), "team1.*"))
+
+	// requests without ACL are passed as is
+	series, _, _ = z.Render(context.Background(), pb.MultiFetchRequest{})
+	assert.Len(t, series, 2)
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/ansel1/merry"
+)
+
+var (
+	// ErrUnauthorized is returned when request has no valid credentials
+	ErrUnauthorized = merry.New("authentication required").WithHTTPCode(http.StatusUnauthorized)
+	// ErrForbidden is returned when user isn't allowed to read any metrics
+	ErrForbidden = merry.New("access denied").WithHTTPCode(http.StatusForbidden)
+)
+
+// Identity is an authenticated user
+type Identity struct {
+	User   string
+	Groups []string
+}
+
+// Auth authenticates requests and returns ACL for them
+type Auth struct {
+	htpasswd       *htpasswd
+	jwt            *jwtValidator
+	header         TrustedHeaderConfig
+	trustedProxies []*net.IPNet
+	rules          []*rule
+}
+
+// New loads htpasswd and JWKS files and compiles rules. It returns nil if authentication isn't configured.
+func New(cfg Config) (*Auth, error) {
+	if !cfg.Enabled() {
+		if len(cfg.Rules) > 0 {
+			return nil, merry.New("auth rules are configured, but no authentication method is enabled")
+		}
+		return nil, nil
+	}
+
+	a := &Auth{header: cfg.TrustedHeader}
+	var err error
+	if cfg.HtpasswdFile != "" {
+		if a.htpasswd, err = loadHtpasswd(cfg.HtpasswdFile); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.JWT.JWKSFile != "" {
+		if a.jwt, err = loadJWKS(cfg.JWT); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.TrustedHeader.UserHeader != "" {
+		if len(cfg.TrustedHeader.TrustedProxies) == 0 {
+			return nil, merry.New("trustedHeader requires trustedProxies")
+		}
+		for _, p := range cfg.TrustedHeader.TrustedProxies {
+			if !strings.Contains(p, "/") {
+				if strings.Contains(p, ":") {
+					p += "/128"
+				} else {
+					p += "/32"
+				}
+			}
+			_, ipNet, err := net.ParseCIDR(p)
+			if err != nil {
+				return nil, merry.Prepend(err, "invalid trusted proxy")
+			}
+			a.trustedProxies = append(a.trustedProxies, ipNet)
+		}
+	}
+	for i := range cfg.Rules {
+		r, err := newRule(cfg.Rules[i])
+		if err != nil {
+			return nil, merry.Prependf(err, "auth rule %d", i)
+		}
+		a.rules = append(a.rules, r)
+	}
+	return a, nil
+}
+
+func (a *Auth) trustedProxy(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, n := range a.trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticate returns identity of the user, trusted headers are checked first, then bearer token and basic auth.
+// It returns ErrUnauthorized if there are no valid credentials.
+func (a *Auth) Authenticate(r *http.Request) (*Identity, error) {
+	if a.header.UserHeader != "" && a.trustedProxy(r.RemoteAddr) {
+		if user := r.Header.Get(a.header.UserHeader); user != "" {
+			id := &Identity{User: user}
+			if a.header.GroupsHeader != "" {
+				for _, g := range strings.Split(r.Header.Get(a.header.GroupsHeader), ",") {
+					if g = strings.TrimSpace(g); g != "" {
+						id.Groups = append(id.Groups, g)
+					}
+				}
+			}
+			return id, nil
+		}
+	}
+	if _, ok := bearerToken(r); ok && a.jwt != nil {
+		return a.jwt.authenticate(r)
+	}
+	if _, _, ok := r.BasicAuth(); ok && a.htpasswd != nil {
+		return a.htpasswd.authenticate(r)
+	}
+	return nil, ErrUnauthorized
+}
+
+// BasicAuthEnabled returns true if users can authenticate with login and password
+func (a *Auth) BasicAuthEnabled() bool {
+	return a.htpasswd != nil
+}
+
+// ACL returns ACL for the identity, nil means that user can read all metrics. It returns ErrForbidden if no rules
+// apply to the user.
+func (a *Auth) ACL(id *Identity) (*ACL, error) {
+	if len(a.rules) == 0 {
+		return nil, nil
+	}
+	var rules []*rule
+	for _, r := range a.rules {
+		if !r.appliesTo(id) {
+			continue
+		}
+		if r.unrestricted() {
+			return nil, nil
+		}
+		rules = append(rules, r)
+	}
+	if len(rules) == 0 {
+		return nil, ErrForbidden
+	}
+	return newACL(rules), nil
+}
+
+type key int
+
+const (
+	identityKey key = iota
+	aclKey
+)
+
+// SetIdentity stores authenticated user for the request
+func SetIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey, id)
+}
+
+// GetIdentity returns authenticated user or nil if request isn't authenticated
+func GetIdentity(ctx context.Context) *Identity {
+	if v := ctx.Value(identityKey); v != nil {
+		return v.(*Identity)
+	}
+	return nil
+}
+
+// SetACL stores ACL of the user, so it's enforced by Zipper
+func SetACL(ctx context.Context, acl *ACL) context.Context {
+	return context.WithValue(ctx, aclKey, acl)
+}
+
+// GetACL returns ACL of the user or nil if all metrics are allowed
+func GetACL(ctx context.Context) *ACL {
+	if v := ctx.Value(aclKey); v != nil {
+		return v.(*ACL)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ansel1/merry"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestHtpasswd(t *testing.T) {
+	// generated by `openssl passwd -apr1 -salt saltsalt secret` and `htpasswd -s`
+	path := writeFile(t, "htpasswd", "# users\nalice:$apr1$saltsalt$LrttParrLPdxvgutaSXWJ0\nbob:{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=\n")
+	a, err := New(Config{HtpasswdFile: path})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tests := []struct {
+		user, password string
+		wantErr        bool
+	}{
+		{user: "alice", password: "secret"},
+		{user: "bob", password: "secret"},
+		{user: "alice", password: "wrong", wantErr: true},
+		{user: "carol", password: "secret", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.user+":"+tt.password, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/render", nil)
+			r.SetBasicAuth(tt.user, tt.password)
+			id, err := a.Authenticate(r)
+			if tt.wantErr {
+				assert.Equal(t, http.StatusUnauthorized, merry.HTTPCode(err))
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.user, id.User)
+		})
+	}
+
+	_, err = a.Authenticate(httptest.NewRequest(http.MethodGet, "/render", nil))
+	assert.True(t, merry.Is(err, ErrUnauthorized))
+
+	_, err = New(Config{HtpasswdFile: writeFile(t, "bcrypt", "alice:$2y$05$abcdefghijklmnopqrstuv\n")})
+	assert.Error(t, err)
+}
+
+func encodeSegment(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func signRS256(key *rsa.PrivateKey, header, claims map[string]interface{}) string {
+	signed := encodeSegment(header) + "." + encodeSegment(claims)
+	digest := sha256.Sum256([]byte(signed))
+	sig, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func signHS256(secret []byte, header, claims map[string]interface{}) string {
+	signed := encodeSegment(header) + "." + encodeSegment(claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(t, err) {
+		return
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(t, err) {
+		return
+	}
+	secret := []byte("hmac-secret")
+
+	jwks, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "rsa1",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			},
+			{
+				"kty": "oct",
+				"kid": "hmac1",
+				"alg": "HS256",
+				"k":   base64.RawURLEncoding.EncodeToString(secret),
+			},
+		},
+	})
+	a, err := New(Config{JWT: JWTConfig{
+		JWKSFile: writeFile(t, "jwks.json", string(jwks)),
+		Issuer:   "https://issuer",
+		Audience: "carbonapi",
+	}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	rsaPublicKeyDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	now := time.Now().Unix()
+	claims := func(modify func(c map[string]interface{})) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub":    "alice",
+			"groups": []string{"team1", "team2"},
+			"iss":    "https://issuer",
+			"aud":    []string{"grafana", "carbonapi"},
+			"exp":    now + 60,
+		}
+		if modify != nil {
+			modify(c)
+		}
+		return c
+	}
+	rs256 := map[string]interface{}{"alg": "RS256", "kid": "rsa1"}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "rs256",
+			token: signRS256(key, rs256, claims(nil)),
+		},
+		{
+			name:  "hs256",
+			token: signHS256(secret, map[string]interface{}{"alg": "HS256"}, claims(nil)),
+		},
+		{
+			name:    "wrong key",
+			token:   signRS256(otherKey, rs256, claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			token:   signRS256(key, rs256, claims(func(c map[string]interface{}) { c["exp"] = now - 1 })),
+			wantErr: true,
+		},
+		{
+			name:    "no exp",
+			token:   signRS256(key, rs256, claims(func(c map[string]interface{}) { delete(c, "exp") })),
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			token:   signRS256(key, rs256, claims(func(c map[string]interface{}) { c["aud"] = "grafana" })),
+			wantErr: true,
+		},
+		{
+			name:    "wrong issuer",
+			token:   signRS256(key, rs256, claims(func(c map[string]interface{}) { c["iss"] = "https://other" })),
+			wantErr: true,
+		},
+		{
+			name:    "alg none",
+			token:   encodeSegment(map[string]interface{}{"alg": "none"}) + "." + encodeSegment(claims(nil)) + ".",
+			wantErr: true,
+		},
+		{
+			// public RSA key must not be used as HMAC secret
+			name:    "hs256 with rsa kid",
+			token:   signHS256(key.N.Bytes(), map[string]interface{}{"alg": "HS256", "kid": "rsa1"}, claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "hs256 with rsa public key",
+			token:   signHS256(rsaPublicKeyDER, map[string]interface{}{"alg": "HS256"}, claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "rs256 with hmac kid",
+			token:   signRS256(key, map[string]interface{}{"alg": "RS256", "kid": "hmac1"}, claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "not yet valid",
+			token:   signRS256(key, rs256, claims(func(c map[string]interface{}) { c["nbf"] = now + 60 })),
+			wantErr: true,
+		},
+		{
+			name:    "exp is not a number",
+			token:   signRS256(key, rs256, claims(func(c map[string]interface{}) { c["exp"] = "never" })),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/render", nil)
+			r.Header.Set("Authorization", "Bearer "+tt.token)
+			id, err := a.Authenticate(r)
+			if tt.wantErr {
+				assert.Equal(t, http.StatusUnauthorized, merry.HTTPCode(err))
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, &Identity{User: "alice", Groups: []string{"team1", "team2"}}, id)
+		})
+	}
+}
+
+func TestJWTKeyAlg(t *testing.T) {
+	jwks := `{"keys": [{"kty": "oct", "kid": "hmac1", "alg": "RS256", "k": "c2VjcmV0"}]}`
+	_, err := New(Config{JWT: JWTConfig{JWKSFile: writeFile(t, "jwks.json", jwks)}})
+	assert.Error(t, err, "RS256 can't be used with symmetric key")
+}
+
+func TestTrustedHeader(t *testing.T) {
+	_, err := New(Config{TrustedHeader: TrustedHeaderConfig{UserHeader: "X-Forwarded-User"}})
+	assert.Error(t, err, "trusted proxies are required")
+
+	a, err := New(Config{TrustedHeader: TrustedHeaderConfig{
+		UserHeader:     "X-Forwarded-User",
+		GroupsHeader:   "X-Forwarded-Groups",
+		TrustedProxies: []string{"10.0.0.0/8", "127.0.0.1"},
+	}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/render", nil)
+	r.RemoteAddr = "10.1.2.3:12345"
+	r.Header.Set("X-Forwarded-User", "alice")
+	r.Header.Set("X-Forwarded-Groups", "team1, team2")
+	id, err := a.Authenticate(r)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, &Identity{User: "alice", Groups: []string{"team1", "team2"}}, id)
+
+	r.RemoteAddr = "192.168.1.1:12345"
+	_, err = a.Authenticate(r)
+	assert.True(t, merry.Is(err, ErrUnauthorized), "header from untrusted address must be ignored")
+}
@@ -0,0 +1,49 @@
+package auth
+
+// Config describes how users are authenticated and which metrics they are allowed to read
+type Config struct {
+	// HtpasswdFile is a file with users and password hashes ({SHA} or $apr1$), checked for HTTP basic auth
+	HtpasswdFile string `mapstructure:"htpasswdFile"`
+	// JWT validates bearer tokens
+	JWT JWTConfig `mapstructure:"jwt"`
+	// TrustedHeader takes user and groups from headers set by authenticating proxy
+	TrustedHeader TrustedHeaderConfig `mapstructure:"trustedHeader"`
+	// Rules map users and groups to metrics they can read. Without rules, authenticated users can read everything.
+	Rules []Rule `mapstructure:"rules"`
+}
+
+// JWTConfig describes how bearer tokens are validated. Tokens must be signed with one of the keys from JWKSFile
+// (RS*, ES* or HS* algorithms) and must not be expired.
+type JWTConfig struct {
+	JWKSFile string `mapstructure:"jwksFile"`
+	// Issuer and Audience are checked if set
+	Issuer   string `mapstructure:"issuer"`
+	Audience string `mapstructure:"audience"`
+	// UserClaim and GroupsClaim are claims with user name and list of groups, "sub" and "groups" by default
+	UserClaim   string `mapstructure:"userClaim"`
+	GroupsClaim string `mapstructure:"groupsClaim"`
+}
+
+// TrustedHeaderConfig describes headers with user and groups (comma-separated), they are accepted only from
+// TrustedProxies
+type TrustedHeaderConfig struct {
+	UserHeader     string   `mapstructure:"userHeader"`
+	GroupsHeader   string   `mapstructure:"groupsHeader"`
+	TrustedProxies []string `mapstructure:"trustedProxies"`
+}
+
+// Rule allows users and groups to read metrics that match one of Prefixes and all Tags constraints. Prefixes are
+// graphite globs that match the first nodes of metric name, e.g. "team1.*.cpu" allows "team1.host1.cpu.user".
+// Tags constraints are seriesByTag expressions (tag=value, tag!=value, tag=~regex, tag!=~regex), series without tags
+// don't match them. "*" in Users or Groups matches any authenticated user.
+type Rule struct {
+	Users    []string `mapstructure:"users"`
+	Groups   []string `mapstructure:"groups"`
+	Prefixes []string `mapstructure:"prefixes"`
+	Tags     []string `mapstructure:"tags"`
+}
+
+// Enabled returns true if any authentication method is configured
+func (c *Config) Enabled() bool {
+	return c.HtpasswdFile != "" || c.JWT.JWKSFile != "" || c.TrustedHeader.UserHeader != ""
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ansel1/merry"
+)
+
+// htpasswd checks basic auth credentials against htpasswd file
+type htpasswd struct {
+	users map[string]string
+}
+
+func loadHtpasswd(path string) (*htpasswd, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &htpasswd{users: make(map[string]string)}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		user, hash, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, merry.Errorf("%s:%d: invalid line", path, n)
+		}
+		if !strings.HasPrefix(hash, "{SHA}") && !strings.HasPrefix(hash, "$apr1$") && !strings.HasPrefix(hash, "$1$") {
+			return nil, merry.Errorf("%s:%d: unsupported hash for user %q, only {SHA} and $apr1$ are supported", path, n, user)
+		}
+		h.users[user] = hash
+	}
+	return h, scanner.Err()
+}
+
+func (h *htpasswd) authenticate(r *http.Request) (*Identity, error) {
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+	hash, ok := h.users[user]
+	if !ok || !checkPassword(password, hash) {
+		return nil, ErrUnauthorized
+	}
+	return &Identity{User: user}, nil
+}
+
+func checkPassword(password, hash string) bool {
+	var computed string
+	switch {
+	case strings.HasPrefix(hash, "{SHA}"):
+		sum := sha1.Sum([]byte(password))
+		computed = "{SHA}" + base64.StdEncoding.EncodeToString(sum[:])
+	case strings.HasPrefix(hash, "$apr1$"):
+		computed = md5Crypt(password, hash, "$apr1$")
+	case strings.HasPrefix(hash, "$1$"):
+		computed = md5Crypt(password, hash, "$1$")
+	default:
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(hash)) == 1
+}
+
+const itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// md5Crypt implements MD5-based crypt used by apache htpasswd, salt is taken from hash
+func md5Crypt(password, hash, magic string) string {
+	salt := strings.TrimPrefix(hash, magic)
+	if i := strings.IndexByte(salt, '$'); i >= 0 {
+		salt = salt[:i]
+	}
+	if len(salt) > 8 {
+		salt = salt[:8]
+	}
+	pw := []byte(password)
+
+	alt := md5.New()
+	alt.Write(pw)
+	alt.Write([]byte(salt))
+	alt.Write(pw)
+	altSum := alt.Sum(nil)
+
+	d := md5.New()
+	d.Write(pw)
+	d.Write([]byte(magic))
+	d.Write([]byte(salt))
+	for i := len(pw); i > 0; i -= 16 {
+		if i > 16 {
+			d.Write(altSum)
+		} else {
+			d.Write(altSum[:i])
+		}
+	}
+	for i := len(pw); i > 0; i >>= 1 {
+		if i&1 != 0 {
+			d.Write([]byte{0})
+		} else {
+			d.Write(pw[:1])
+		}
+	}
+	sum := d.Sum(nil)
+
+	for i := 0; i < 1000; i++ {
+		d := md5.New()
+		if i&1 != 0 {
+			d.Write(pw)
+		} else {
+			d.Write(sum)
+		}
+		if i%3 != 0 {
+			d.Write([]byte(salt))
+		}
+		if i%7 != 0 {
+			d.Write(pw)
+		}
+		if i&1 != 0 {
+			d.Write(sum)
+		} else {
+			d.Write(pw)
+		}
+		sum = d.Sum(nil)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(magic)
+	sb.WriteString(salt)
+	sb.WriteByte('$')
+	to64 := func(v uint32, n int) {
+		for ; n > 0; n-- {
+			sb.WriteByte(itoa64[v&0x3f])
+			v >>= 6
+		}
+	}
+	for _, g := range [][3]int{{0, 6, 12}, {1, 7, 13}, {2, 8, 14}, {3, 9, 15}, {4, 10, 5}} {
+		to64(uint32(sum[g[0]])<<16|uint32(sum[g[1]])<<8|uint32(sum[g[2]]), 4)
+	}
+	to64(uint32(sum[11]), 2)
+	return sb.String()
+}
@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ansel1/merry"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwk is a key from JSON Web Key Set (RFC 7517)
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// symmetric
+	K string `json:"k"`
+}
+
+// jwtAlgs are signing algorithms allowed for each key type
+var jwtAlgs = map[string][]string{
+	"RSA": {"RS256", "RS384", "RS512"},
+	"EC":  {"ES256", "ES384", "ES512"},
+	"oct": {"HS256", "HS384", "HS512"},
+}
+
+var jwtValidMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "HS256", "HS384", "HS512"}
+
+type jwtKey struct {
+	kid  string
+	algs []string
+	key  interface{}
+}
+
+func (k *jwtKey) allows(alg string) bool {
+	return hasAlg(k.algs, alg)
+}
+
+func hasAlg(algs []string, alg string) bool {
+	for _, a := range algs {
+		if a == alg {
+			return true
+		}
+	}
+	return false
+}
+
+// jwtValidator checks bearer tokens against keys from JWKS file
+type jwtValidator struct {
+	cfg    JWTConfig
+	keys   []jwtKey
+	parser *jwt.Parser
+}
+
+func loadJWKS(cfg JWTConfig) (*jwtValidator, error) {
+	b, err := os.ReadFile(cfg.JWKSFile)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, merry.Prepend(err, "failed to parse "+cfg.JWKSFile)
+	}
+
+	if cfg.UserClaim == "" {
+		cfg.UserClaim = "sub"
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(jwtValidMethods),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	v := &jwtValidator{cfg: cfg, parser: jwt.NewParser(opts...)}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, merry.Prependf(err, "%s: key %q", cfg.JWKSFile, k.Kid)
+		}
+		algs := jwtAlgs[k.Kty]
+		if k.Alg != "" {
+			if !hasAlg(algs, k.Alg) {
+				return nil, merry.Errorf("%s: key %q: algorithm %q is not supported for key type %q", cfg.JWKSFile, k.Kid, k.Alg, k.Kty)
+			}
+			algs = []string{k.Alg}
+		}
+		v.keys = append(v.keys, jwtKey{kid: k.Kid, algs: algs, key: key})
+	}
+	if len(v.keys) == 0 {
+		return nil, merry.Errorf("%s: no signing keys", cfg.JWKSFile)
+	}
+	return v, nil
+}
+
+func decodeSegment(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+func (k *jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeSegment(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeSegment(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, merry.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeSegment(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeSegment(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "oct":
+		return decodeSegment(k.K)
+	}
+	return nil, merry.Errorf("unsupported key type %q", k.Kty)
+}
+
+// claimStrings returns claim that can be either a string or a list of strings
+func claimStrings(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []interface{}:
+		res := make([]string, 0, len(v))
+		for _, s := range v {
+			if s, ok := s.(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+// keyFunc returns keys that can verify token, key type must match the algorithm so that public keys can't be used as
+// HMAC secrets
+func (v *jwtValidator) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	alg := token.Method.Alg()
+	set := jwt.VerificationKeySet{}
+	for _, k := range v.keys {
+		if (kid != "" && k.kid != kid) || !k.allows(alg) {
+			continue
+		}
+		set.Keys = append(set.Keys, k.key)
+	}
+	if len(set.Keys) == 0 {
+		return nil, ErrUnauthorized
+	}
+	return set, nil
+}
+
+func (v *jwtValidator) validate(token string) (*Identity, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.keyFunc); err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	user, _ := claims[v.cfg.UserClaim].(string)
+	if user == "" {
+		return nil, ErrUnauthorized
+	}
+	return &Identity{User: user, Groups: claimStrings(claims[v.cfg.GroupsClaim])}, nil
+}
+
+func (v *jwtValidator) authenticate(r *http.Request) (*Identity, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+	return v.validate(token)
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	h := r.Header.Get("Authorization")
+	if len(h) < 7 || !strings.EqualFold(h[:7], "Bearer ") {
+		return "", false
+	}
+	return strings.TrimSpace(h[7:]), true
+}
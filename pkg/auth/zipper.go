@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/ansel1/merry"
+	pb "github.com/go-graphite/protocol/carbonapi_v3_pb"
+
+	"github.com/go-graphite/carbonapi/expr/types"
+	zipper "github.com/go-graphite/carbonapi/zipper/interfaces"
+	zipperTypes "github.com/go-graphite/carbonapi/zipper/types"
+)
+
+// Zipper enforces ACL from the request context: metrics that user isn't allowed to read are removed from find, info,
+// render, list and tags responses, so globs can't leak other namespaces. Requests without ACL are passed as is.
+type Zipper struct {
+	z zipper.CarbonZipper
+}
+
+// NewZipper returns zipper that enforces ACL for z
+func NewZipper(z zipper.CarbonZipper) *Zipper {
+	return &Zipper{z: z}
+}
+
+func (z *Zipper) Find(ctx context.Context, request pb.MultiGlobRequest) (*pb.MultiGlobResponse, *zipperTypes.Stats, merry.Error) {
+	res, stats, err := z.z.Find(ctx, request)
+	acl := GetACL(ctx)
+	if acl == nil || res == nil {
+		return res, stats, err
+	}
+
+	filtered := &pb.MultiGlobResponse{Metrics: make([]pb.GlobResponse, 0, len(res.Metrics))}
+	for _, m := range res.Metrics {
+		matches := make([]pb.GlobMatch, 0, len(m.Matches))
+		for _, match := range m.Matches {
+			if (match.IsLeaf && acl.AllowMetric(match.Path)) || (!match.IsLeaf && acl.AllowBranch(match.Path)) {
+				matches = append(matches, match)
+			}
+		}
+		filtered.Metrics = append(filtered.Metrics, pb.GlobResponse{Name: m.Name, Matches: matches})
+	}
+	return filtered, stats, err
+}
+
+func (z *Zipper) Info(ctx context.Context, metrics []string) (*pb.ZipperInfoResponse, *zipperTypes.Stats, merry.Error) {
+	res, stats, err := z.z.Info(ctx, metrics)
+	acl := GetACL(ctx)
+	if acl == nil || res == nil {
+		return res, stats, err
+	}
+
+	filtered := &pb.ZipperInfoResponse{Info: make(map[string]pb.MultiMetricsInfoResponse, len(res.Info))}
+	for server, info := range res.Info {
+		allowed := pb.MultiMetricsInfoResponse{Metrics: make([]pb.MetricsInfoResponse, 0, len(info.Metrics))}
+		for _, m := range info.Metrics {
+			if acl.AllowMetric(m.Name) {
+				allowed.Metrics = append(allowed.Metrics, m)
+			}
+		}
+		if len(allowed.Metrics) > 0 {
+			filtered.Info[server] = allowed
+		}
+	}
+	return filtered, stats, err
+}
+
+func filterSeries(acl *ACL, series []*types.MetricData) []*types.MetricData {
+	filtered := make([]*types.MetricData, 0, len(series))
+	for _, s := range series {
+		if acl.AllowMetric(s.Name) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+func (z *Zipper) RenderCompat(ctx context.Context, metrics []string, from, until int64) ([]*types.MetricData, *zipperTypes.Stats, merry.Error) {
+	res, stats, err := z.z.RenderCompat(ctx, metrics, from, until)
+	if acl := GetACL(ctx); acl != nil {
+		res = filterSeries(acl, res)
+	}
+	return res, stats, err
+}
+
+func (z *Zipper) Render(ctx context.Context, request pb.MultiFetchRequest) ([]*types.MetricData, *zipperTypes.Stats, merry.Error) {
+	res, stats, err := z.z.Render(ctx, request)
+	if acl := GetACL(ctx); acl != nil {
+		res = filterSeries(acl, res)
+	}
+	return res, stats, err
+}
+
+// restrictedQuery runs tags query once for each rule of ACL, with rule's expressions added to the query, and merges
+// the results
+func restrictedQuery(acl *ACL, query string, limit int64, fetch func(query string) ([]string, merry.Error)) ([]string, merry.Error) {
+	values, e := url.ParseQuery(query)
+	if e != nil {
+		return nil, merry.Wrap(e).WithHTTPCode(http.StatusBadRequest)
+	}
+
+	seen := make(map[string]struct{})
+	var res []string
+	var err merry.Error
+	for _, exprs := range acl.TagExprs() {
+		q := make(url.Values, len(values)+1)
+		for k, v := range values {
+			q[k] = v
+		}
+		q["expr"] = append(append([]string(nil), values["expr"]...), exprs...)
+
+		r, e := fetch(q.Encode())
+		if e != nil {
+			if !merry.Is(e, zipperTypes.ErrNoMetricsFetched) {
+				err = e
+			}
+			if len(r) == 0 {
+				continue
+			}
+		}
+		for _, v := range r {
+			if _, ok := seen[v]; !ok {
+				seen[v] = struct{}{}
+				res = append(res, v)
+			}
+		}
+	}
+
+	sort.Strings(res)
+	if limit > 0 && int64(len(res)) > limit {
+		res = res[:limit]
+	}
+	return res, err
+}
+
+func (z *Zipper) TagNames(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	acl := GetACL(ctx)
+	if acl == nil {
+		return z.z.TagNames(ctx, query, limit)
+	}
+	return restrictedQuery(acl, query, limit, func(query string) ([]string, merry.Error) {
+		return z.z.TagNames(ctx, query, limit)
+	})
+}
+
+func (z *Zipper) TagValues(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	acl := GetACL(ctx)
+	if acl == nil {
+		return z.z.TagValues(ctx, query, limit)
+	}
+	return restrictedQuery(acl, query, limit, func(query string) ([]string, merry.Error) {
+		return z.z.TagValues(ctx, query, limit)
+	})
+}
+
+func (z *Zipper) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	acl := GetACL(ctx)
+	if acl == nil {
+		return z.z.FindSeries(ctx, query, limit)
+	}
+	return restrictedQuery(acl, query, limit, func(query string) ([]string, merry.Error) {
+		series, err := z.z.FindSeries(ctx, query, limit)
+		filtered := make([]string, 0, len(series))
+		for _, s := range series {
+			if acl.AllowMetric(s) {
+				filtered = append(filtered, s)
+			}
+		}
+		return filtered, err
+	})
+}
+
+// List returns metrics that user is allowed to read
+func (z *Zipper) List(ctx context.Context) (*pb.ListMetricsResponse, *zipperTypes.Stats, merry.Error) {
+	l, ok := z.z.(zipper.Lister)
+	if !ok {
+		return nil, nil, zipperTypes.ErrNotSupportedByBackend
+	}
+	res, stats, err := l.List(ctx)
+	acl := GetACL(ctx)
+	if acl == nil || res == nil {
+		return res, stats, err
+	}
+
+	filtered := &pb.ListMetricsResponse{Metrics: make([]string, 0, len(res.Metrics))}
+	for _, m := range res.Metrics {
+		if acl.AllowMetric(m) {
+			filtered.Metrics = append(filtered.Metrics, m)
+		}
+	}
+	return filtered, stats, err
+}
+
+// Details returns details of metrics that user is allowed to read
+func (z *Zipper) Details(ctx context.Context) (*pb.MetricDetailsResponse, *zipperTypes.Stats, merry.Error) {
+	l, ok := z.z.(zipper.Lister)
+	if !ok {
+		return nil, nil, zipperTypes.ErrNotSupportedByBackend
+	}
+	res, stats, err := l.Details(ctx)
+	acl := GetACL(ctx)
+	if acl == nil || res == nil {
+		return res, stats, err
+	}
+
+	filtered := &pb.MetricDetailsResponse{
+		Metrics:    make(map[string]*pb.MetricDetails, len(res.Metrics)),
+		FreeSpace:  res.FreeSpace,
+		TotalSpace: res.TotalSpace,
+	}
+	for name, d := range res.Metrics {
+		if acl.AllowMetric(name) {
+			filtered.Metrics[name] = d
+		}
+	}
+	return filtered, stats, err
+}
+
+func (z *Zipper) ScaleToCommonStep() bool {
+	return z.z.ScaleToCommonStep()
+}
+
+// PushdownFunctions doesn't allow backends to evaluate functions for users with ACL, as series they aren't allowed to
+// read could be aggregated
+func (z *Zipper) PushdownFunctions(ctx context.Context, pathExpression string) map[string]struct{} {
+	pd, ok := z.z.(zipper.FunctionPushdown)
+	if !ok || GetACL(ctx) != nil {
+		return nil
+	}
+	return pd.PushdownFunctions(ctx, pathExpression)
+}
@@ -50,6 +50,9 @@ var (
 	ErrMissingValues = errors.New("unexpected empty time series")
 	// ErrUnknownTimeUnits is an eval error returned when a time unit is unknown to system
 	ErrUnknownTimeUnits = errors.New("unknown time units")
+	// ErrSubSecondInterval is an eval error returned when an interval is not a whole number of seconds in a request
+	// without high precision timestamps
+	ErrSubSecondInterval = errors.New("sub-second intervals require high precision timestamps")
 	// ErrInvalidArg is eval error for invalid or mismatch function arg
 	ErrInvalidArg = errors.New("invalid function arg")
 	// ErrInvalidInterval is an eval error returned when an interval is set to 0
@@ -123,10 +126,16 @@ type Expr interface {
 	// Metrics returns list of metric requests
 	Metrics(from, until int64, tz *time.Location) []MetricRequest
 
-	// GetIntervalArg returns interval typed argument.
-	GetIntervalArg(n int, defaultSign int) (int32, error)
+	// TimeUnit returns time unit (Seconds or Milliseconds) of timestamps and intervals of the expression
+	TimeUnit() int64
+	// SetTimeUnit changes time unit of the expression and all of its arguments
+	SetTimeUnit(unit int64)
+
+	// GetIntervalArg returns interval typed argument in time units of the expression.
+	GetIntervalArg(n int, defaultSign int) (int64, error)
 
 	// GetIntervalNamedOrPosArgDefault returns interval typed argument that can be passed as a named argument or as position or replace it with default if none found.
+	// Default is set in seconds, result is in time units of the expression.
 	GetIntervalNamedOrPosArgDefault(k string, n, defaultSign int, v int64) (int64, error)
 
 	// GetStringArg returns n-th argument as string.
@@ -5,8 +5,23 @@ import (
 	"strings"
 )
 
+// Time units of the expression, see Expr.TimeUnit
+const (
+	// Seconds is a default time unit of timestamps, steps and intervals
+	Seconds int64 = 1
+	// Milliseconds is a time unit of requests with high precision timestamps
+	Milliseconds int64 = 1000
+)
+
 // IntervalString converts a sign and string into a number of seconds
 func IntervalString(s string, defaultSign int) (int32, error) {
+	interval, err := IntervalStringUnit(s, defaultSign, Seconds)
+	return int32(interval), err
+}
+
+// IntervalStringUnit converts a sign and string into a number of time units (Seconds or Milliseconds).
+// Interval could be set in milliseconds ("500ms"), but it should be a whole number of units.
+func IntervalStringUnit(s string, defaultSign int, unit int64) (int64, error) {
 	if len(s) == 0 {
 		return 0, ErrUnknownTimeUnits
 	}
@@ -26,7 +41,8 @@ func IntervalString(s string, defaultSign int) (int32, error) {
 		s = s[1:]
 	}
 
-	var totalInterval int32
+	// interval is summed up in milliseconds and then converted to requested unit
+	var totalInterval int64
 	for len(s) > 0 {
 		var j int
 		for j < len(s) && '0' <= s[j] && s[j] <= '9' {
@@ -42,22 +58,24 @@ func IntervalString(s string, defaultSign int) (int32, error) {
 		var unitStr string
 		unitStr, s = s[:j], s[j:]
 
-		var units int
+		var units int64
 		switch strings.ToLower(unitStr) {
-		case "s", "sec", "secs", "second", "seconds":
+		case "ms", "msec", "msecs", "millisecond", "milliseconds":
 			units = 1
+		case "s", "sec", "secs", "second", "seconds":
+			units = 1000
 		case "m", "min", "mins", "minute", "minutes":
-			units = 60
+			units = 60 * 1000
 		case "h", "hour", "hours":
-			units = 60 * 60
+			units = 60 * 60 * 1000
 		case "d", "day", "days":
-			units = 24 * 60 * 60
+			units = 24 * 60 * 60 * 1000
 		case "w", "week", "weeks":
-			units = 7 * 24 * 60 * 60
+			units = 7 * 24 * 60 * 60 * 1000
 		case "mon", "month", "months":
-			units = 30 * 24 * 60 * 60
+			units = 30 * 24 * 60 * 60 * 1000
 		case "y", "year", "years":
-			units = 365 * 24 * 60 * 60
+			units = 365 * 24 * 60 * 60 * 1000
 		default:
 			return 0, ErrUnknownTimeUnits
 		}
@@ -66,10 +84,13 @@ func IntervalString(s string, defaultSign int) (int32, error) {
 		if err != nil {
 			return 0, err
 		}
-		totalInterval += int32(sign * offset * units)
+		totalInterval += int64(sign*offset) * units
 	}
 
-	return totalInterval, nil
+	if totalInterval*unit%1000 != 0 {
+		return 0, ErrSubSecondInterval
+	}
+	return totalInterval * unit / 1000, nil
 }
 
 func TruthyBool(s string) bool {
@@ -54,3 +54,27 @@ func TestInterval(t *testing.T) {
 		}
 	}
 }
+
+func TestIntervalUnit(t *testing.T) {
+	var tests = []struct {
+		t        string
+		unit     int64
+		interval int64
+		err      error
+	}{
+		{"1s", Milliseconds, 1000, nil},
+		{"500ms", Milliseconds, 500, nil},
+		{"1s250msec", Milliseconds, 1250, nil},
+		{"-2min", Milliseconds, -120000, nil},
+		{"2000ms", Seconds, 2, nil},
+		{"1s500ms", Seconds, 0, ErrSubSecondInterval},
+		{"10x", Milliseconds, 0, ErrUnknownTimeUnits},
+	}
+
+	for _, tt := range tests {
+		interval, err := IntervalStringUnit(tt.t, 1, tt.unit)
+		if interval != tt.interval || err != tt.err {
+			t.Errorf("IntervalStringUnit(%q, %d)=%d, %v, want %d, %v\n", tt.t, tt.unit, interval, err, tt.interval, tt.err)
+		}
+	}
+}
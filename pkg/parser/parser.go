@@ -10,8 +10,6 @@ import (
 	"unicode"
 	"unicode/utf8"
 
-	"github.com/go-graphite/carbonapi/expr/holtwinters"
-
 	"github.com/ansel1/merry"
 )
 
@@ -25,6 +23,8 @@ type expr struct {
 	args      []*expr // positional
 	namedArgs map[string]*expr
 	argString string
+	// timeUnit is Milliseconds for requests with high precision timestamps, zero means Seconds
+	timeUnit int64
 }
 
 func (e *expr) IsName() bool {
@@ -141,6 +141,22 @@ func (e *expr) NamedArg(name string) (Expr, bool) {
 	return expr, exist
 }
 
+// MetricsAdjuster is implemented by functions, that need to fetch their arguments with other parameters than
+// requested, e.g. with a bootstrap interval before from. See interfaces.MetricsAdjuster.
+type MetricsAdjuster interface {
+	// AdjustMetrics receives requests for the arguments of the function call e (made for [from, until]) and returns
+	// requests that should be fetched instead. nil means that arguments are invalid and nothing should be fetched.
+	AdjustMetrics(e Expr, from, until int64, tz *time.Location, r []MetricRequest) []MetricRequest
+}
+
+var metricsAdjusterLookup func(name string) MetricsAdjuster
+
+// SetMetricsAdjusterLookup sets how Metrics() finds MetricsAdjuster for the function by its name.
+// It's set by the functions registry (expr/metadata).
+func SetMetricsAdjusterLookup(lookup func(name string) MetricsAdjuster) {
+	metricsAdjusterLookup = lookup
+}
+
 func (e *expr) Metrics(from, until int64, tz *time.Location) []MetricRequest {
 	switch e.etype {
 	case EtName:
@@ -153,178 +169,9 @@ func (e *expr) Metrics(from, until int64, tz *time.Location) []MetricRequest {
 			r = append(r, a.Metrics(from, until, tz)...)
 		}
 
-		switch e.target {
-		case "transformNull":
-			referenceSeriesExpr := e.GetNamedArg("referenceSeries")
-			if !referenceSeriesExpr.IsInterfaceNil() {
-				r = append(r, referenceSeriesExpr.Metrics(from, until, tz)...)
-			}
-		case "consolidateBy":
-			function, err := e.GetStringArg(1)
-			if err != nil {
-				break
-			}
-			for i := range r {
-				r[i].ConsolidationFunc = function
-			}
-		case "timeShift":
-			offs, err := e.GetIntervalArg(1, -1)
-			if err != nil {
-				return nil
-			}
-
-			alignDST, err := e.GetBoolArgDefault(3, false)
-			if err != nil {
-				return nil
-			}
-
-			var r2 []MetricRequest
-			for i := range r {
-				newFrom := r[i].From + int64(offs)
-				newUntil := r[i].Until + int64(offs)
-				if alignDST {
-					newFrom, newUntil, err = AlignDST(from, until, offs, tz)
-					if err != nil {
-						return nil
-					}
-				}
-
-				// Append a request with modified start and end time
-				r2 = append(r2, MetricRequest{
-					Metric: r[i].Metric,
-					From:   newFrom,
-					Until:  newUntil,
-				})
-			}
-
-			return append(r, r2...)
-		case "timeStack":
-			offs, err := e.GetIntervalArg(1, -1)
-			if err != nil {
-				return nil
-			}
-
-			start, err := e.GetIntArg(2)
-			if err != nil {
-				return nil
-			}
-
-			end, err := e.GetIntArg(3)
-			if err != nil {
-				return nil
-			}
-
-			var r2 []MetricRequest
-			for _, v := range r {
-				for i := int64(start); i < int64(end); i++ {
-					fromNew := v.From + i*int64(offs)
-					untilNew := v.Until + i*int64(offs)
-					r2 = append(r2, MetricRequest{
-						Metric: v.Metric,
-						From:   fromNew,
-						Until:  untilNew,
-					})
-				}
-			}
-
-			return r2
-		case "holtWintersForecast":
-			bootstrapInterval, err := e.GetIntervalNamedOrPosArgDefault("bootstrapInterval", 1, 1, holtwinters.DefaultBootstrapInterval)
-			if err != nil {
-				return nil
-			}
-
-			for i := range r {
-				r[i].From -= bootstrapInterval
-			}
-		case "holtWintersConfidenceBands", "holtWintersConfidenceArea":
-			bootstrapInterval, err := e.GetIntervalNamedOrPosArgDefault("bootstrapInterval", 2, 1, holtwinters.DefaultBootstrapInterval)
-			if err != nil {
-				return nil
-			}
-
-			for i := range r {
-				r[i].From -= bootstrapInterval
-			}
-		case "holtWintersAberration":
-			bootstrapInterval, err := e.GetIntervalNamedOrPosArgDefault("bootstrapInterval", 2, 1, holtwinters.DefaultBootstrapInterval)
-			if err != nil {
-				return nil
-			}
-
-			// For this function, we also need to pull data with an adjusted From time,
-			// so additional requests are added with the adjusted start time based on the
-			// bootstrapInterval
-			for i := range r {
-				adjustedReq := MetricRequest{
-					Metric: r[i].Metric,
-					From:   r[i].From - bootstrapInterval,
-					Until:  r[i].Until,
-				}
-				r = append(r, adjustedReq)
-			}
-		case "movingAverage", "movingMedian", "movingMin", "movingMax", "movingSum", "exponentialMovingAverage":
-			if len(e.args) < 2 {
-				return nil
-			}
-			if e.args[1].etype == EtString {
-				offs, err := e.GetIntervalArg(1, 1)
-				if err != nil {
-					return nil
-				}
-				for i := range r {
-					fromNew := r[i].From - int64(offs)
-					r[i].From = fromNew
-				}
-			}
-		case "hitcount":
-			if len(e.args) < 2 {
-				return nil
-			}
-
-			alignToInterval, err := e.GetBoolNamedOrPosArgDefault("alignToInterval", 2, false)
-			if err != nil {
-				return nil
-			}
-			if alignToInterval {
-				bucketSizeInt32, err := e.GetIntervalArg(1, 1)
-				if err != nil {
-					return nil
-				}
-
-				interval := int64(bucketSizeInt32)
-				// This is done in order to replicate the behavior in Graphite web when alignToInterval is set,
-				// in which new data is fetched with the adjusted start time.
-				for i, _ := range r {
-					start := r[i].From
-					for _, v := range []int64{86400, 3600, 60} {
-						if interval >= v {
-							start -= start % v
-							break
-						}
-					}
-
-					r[i].From = start
-				}
-			}
-		case "smartSummarize":
-			if len(e.args) < 2 {
-				return nil
-			}
-
-			alignToInterval, err := e.GetStringNamedOrPosArgDefault("alignTo", 3, "")
-			if err != nil {
-				return nil
-			}
-
-			if alignToInterval != "" {
-				for i, _ := range r {
-					newStart, err := StartAlignTo(r[i].From, alignToInterval)
-					if err != nil {
-						return nil
-					}
-					r[i].From = newStart
-				}
+		if metricsAdjusterLookup != nil {
+			if adjuster := metricsAdjusterLookup(e.target); adjuster != nil {
+				return adjuster.AdjustMetrics(e, from, until, tz, r)
 			}
 		}
 		return r
@@ -333,35 +180,42 @@ func (e *expr) Metrics(from, until int64, tz *time.Location) []MetricRequest {
 	return nil
 }
 
-func AlignDST(from, until int64, offs int32, tz *time.Location) (int64, int64, error) {
-	var dstOffset int32
+// AlignDST shifts from and until by offs and compensates DST change between original and shifted intervals.
+// All of them are set in time units (Seconds or Milliseconds).
+func AlignDST(from, until, offs, unit int64, tz *time.Location) (int64, int64, error) {
+	var dstOffset int64
 	var err error
 
-	newFrom := from + int64(offs)
-	newUntil := until + int64(offs)
+	newFrom := from + offs
+	newUntil := until + offs
 
-	reqStartDST := localTimeIsDST(time.Unix(from, 0), tz)
-	reqEndDST := localTimeIsDST(time.Unix(until, 0), tz)
-	offsetStartDST := localTimeIsDST(time.Unix(from+int64(offs), 0), tz)
-	offsetEndDST := localTimeIsDST(time.Unix(until+int64(offs), 0), tz)
+	reqStartDST := localTimeIsDST(UnitTime(from, unit), tz)
+	reqEndDST := localTimeIsDST(UnitTime(until, unit), tz)
+	offsetStartDST := localTimeIsDST(UnitTime(from+offs, unit), tz)
+	offsetEndDST := localTimeIsDST(UnitTime(until+offs, unit), tz)
 
 	if (reqStartDST && reqEndDST) && (!offsetStartDST && !offsetEndDST) {
-		dstOffset, err = IntervalString("1h", 1)
+		dstOffset, err = IntervalStringUnit("1h", 1, unit)
 		if err != nil {
 			return newFrom, newUntil, err
 		}
 	} else if (!reqStartDST && !reqEndDST) && (offsetStartDST && offsetEndDST) {
-		dstOffset, err = IntervalString("-1h", -1)
+		dstOffset, err = IntervalStringUnit("-1h", -1, unit)
 		if err != nil {
 			return newFrom, newUntil, err
 		}
 	}
-	newFrom += int64(dstOffset)
-	newUntil += int64(dstOffset)
+	newFrom += dstOffset
+	newUntil += dstOffset
 
 	return newFrom, newUntil, err
 }
 
+// UnitTime converts timestamp set in time units (Seconds or Milliseconds) to time.Time
+func UnitTime(ts, unit int64) time.Time {
+	return time.Unix(ts/unit, ts%unit*int64(time.Second)/unit)
+}
+
 func localTimeIsDST(t time.Time, tz *time.Location) bool {
 	if z, err := time.LoadLocation(tz.String()); err != nil {
 		tz = z
@@ -370,7 +224,24 @@ func localTimeIsDST(t time.Time, tz *time.Location) bool {
 	return localTime.IsDST()
 }
 
-func (e *expr) GetIntervalArg(n, defaultSign int) (int32, error) {
+func (e *expr) TimeUnit() int64 {
+	if e.timeUnit == 0 {
+		return Seconds
+	}
+	return e.timeUnit
+}
+
+func (e *expr) SetTimeUnit(unit int64) {
+	e.timeUnit = unit
+	for _, a := range e.args {
+		a.SetTimeUnit(unit)
+	}
+	for _, a := range e.namedArgs {
+		a.SetTimeUnit(unit)
+	}
+}
+
+func (e *expr) GetIntervalArg(n, defaultSign int) (int64, error) {
 	if len(e.args) <= n {
 		return 0, ErrMissingArgument
 	}
@@ -379,12 +250,15 @@ func (e *expr) GetIntervalArg(n, defaultSign int) (int32, error) {
 		return 0, ErrBadType
 	}
 
-	seconds, err := IntervalString(e.args[n].valStr, defaultSign)
+	interval, err := IntervalStringUnit(e.args[n].valStr, defaultSign, e.TimeUnit())
 	if err != nil {
+		if err == ErrSubSecondInterval {
+			return 0, err
+		}
 		return 0, ErrBadType
 	}
 
-	return seconds, nil
+	return interval, nil
 }
 
 func (e *expr) GetIntervalNamedOrPosArgDefault(k string, n, defaultSign int, v int64) (int64, error) {
@@ -397,7 +271,7 @@ func (e *expr) GetIntervalNamedOrPosArgDefault(k string, n, defaultSign int, v i
 		}
 	} else {
 		if len(e.args) <= n {
-			return v, nil
+			return v * e.TimeUnit(), nil
 		}
 
 		if e.args[n].etype != EtString {
@@ -406,12 +280,15 @@ func (e *expr) GetIntervalNamedOrPosArgDefault(k string, n, defaultSign int, v i
 		val = e.args[n].valStr
 	}
 
-	seconds, err := IntervalString(val, defaultSign)
+	interval, err := IntervalStringUnit(val, defaultSign, e.TimeUnit())
 	if err != nil {
+		if err == ErrSubSecondInterval {
+			return 0, err
+		}
 		return 0, ErrBadType
 	}
 
-	return int64(seconds), nil
+	return interval, nil
 }
 
 func (e *expr) GetStringArg(n int) (string, error) {
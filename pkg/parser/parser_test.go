@@ -661,644 +661,31 @@ func TestDoGetIntOrInf(t *testing.T) {
 	}
 }
 
-func TestMetrics(t *testing.T) {
-	tests := []struct {
-		s        string
-		e        *expr
-		from     int64
-		to       int64
-		expected []MetricRequest
-		tz       string
-	}{
-		{
-			"hitcount(metric1, '1h', true)",
-			&expr{
-				target: "hitcount",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "1h", etype: EtString},
-					{valStr: "true", etype: EtBool},
-				},
-				argString: "metric1, '1h', true",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410343200,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"hitcount(metric1, '1h', alignToInterval=True)",
-			&expr{
-				target: "hitcount",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "1h", etype: EtString},
-				},
-				namedArgs: map[string]*expr{
-					"alignToInterval": {
-						target: "true",
-						etype:  EtBool,
-						valStr: "true",
-					},
-				},
-				argString: "metric1, '1h', alignToInterval=True",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410343200,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"hitcount(metric1, '1h')",
-			&expr{
-				target: "hitcount",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "1h", etype: EtString},
-				},
-				argString: "metric1, '1h'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410346740,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"hitcount(timeShift(metric1, '-1h'),'1h')",
-			&expr{
-				target: "hitcount",
-				etype:  EtFunc,
-				args: []*expr{
-					{
-						target: "timeShift",
-						etype:  EtFunc,
-						args: []*expr{
-							{target: "metric1"},
-							{valStr: "-1h", etype: EtString},
-						},
-						argString: "metric1, '-1h'",
-					},
-					{valStr: "1h", etype: EtString},
-					{valStr: "true", etype: EtBool},
-				},
-				argString: "timeShift(metric1, '-1h'),'1h'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410343200,
-					Until:  1410346865,
-				},
-				{
-					Metric: "metric1",
-					From:   1410339600,
-					Until:  1410343265,
-				},
-			},
-			"UTC",
-		},
-		{
-			"holtWintersAberration(metric1)",
-			&expr{
-				target: "holtWintersAberration",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-				},
-				argString: "metric1",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410346740,
-					Until:  1410346865,
-				},
-				{
-					Metric: "metric1",
-					From:   1409741940,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"holtWintersAberration(metric1,3,'6d')",
-			&expr{
-				target: "holtWintersAberration",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "3", etype: EtConst},
-					{valStr: "6d", etype: EtString},
-				},
-				argString: "metric1, 3, '6d'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410346740,
-					Until:  1410346865,
-				},
-				{
-					Metric: "metric1",
-					From:   1409828340,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"holtWintersConfidenceBands(metric1)",
-			&expr{
-				target: "holtWintersConfidenceBands",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-				},
-				argString: "metric1",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1409741940,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"holtWintersConfidenceBands(metric1, 4, '1d')",
-			&expr{
-				target: "holtWintersConfidenceBands",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "4", etype: EtConst},
-					{valStr: "1d", etype: EtString},
-				},
-				argString: "metric1, 4, '1d'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410260340,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"holtWintersConfidenceBands(metric1, 4, bootstrapInterval='3d')",
-			&expr{
-				target: "holtWintersConfidenceBands",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "4", etype: EtConst},
-				},
-				namedArgs: map[string]*expr{
-					"bootstrapInterval": {etype: EtString, valStr: "3d"},
-				},
-				argString: "metric1, 4, '3d'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410087540,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"holtWintersForecast(metric1,'1d')",
-			&expr{
-				target: "holtWintersConfidenceBands",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-				},
-				argString: "metric1",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1409741940,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"holtWintersAberration(metric1)",
-			&expr{
-				target: "holtWintersAberration",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-				},
-				argString: "metric1",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410346740,
-					Until:  1410346865,
-				},
-				{
-					Metric: "metric1",
-					From:   1409741940,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"holtWintersAberration(metric1,3,'6d')",
-			&expr{
-				target: "holtWintersAberration",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "3", etype: EtConst},
-					{valStr: "6d", etype: EtString},
-				},
-				argString: "metric1, 3, '6d'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410346740,
-					Until:  1410346865,
-				},
-				{
-					Metric: "metric1",
-					From:   1409828340,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"holtWintersConfidenceBands(metric1)",
-			&expr{
-				target: "holtWintersConfidenceBands",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-				},
-				argString: "metric1",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1409741940,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"holtWintersConfidenceBands(metric1, 4, '1d')",
-			&expr{
-				target: "holtWintersConfidenceBands",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "4", etype: EtConst},
-					{valStr: "1d", etype: EtString},
-				},
-				argString: "metric1, 4, '1d'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410260340,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"holtWintersConfidenceBands(metric1, 4, bootstrapInterval='3d')",
-			&expr{
-				target: "holtWintersConfidenceBands",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "4", etype: EtConst},
-				},
-				namedArgs: map[string]*expr{
-					"bootstrapInterval": {etype: EtString, valStr: "3d"},
-				},
-				argString: "metric1, 4, '3d'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410087540,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"holtWintersForecast(metric1,'1d')",
-			&expr{
-				target: "holtWintersConfidenceBands",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-				},
-				argString: "metric1",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1409741940,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"smartSummarize(metric1, '1h', 'sum', 'seconds')",
-			&expr{
-				target: "smartSummarize",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "1h", etype: EtString},
-					{valStr: "sum", etype: EtString},
-					{valStr: "seconds", etype: EtString},
-				},
-				argString: "metric1, '1h', 'sum', 'seconds'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410346740,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"smartSummarize(metric1, '1h', 'sum', '1minutes')",
-			&expr{
-				target: "smartSummarize",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "1h", etype: EtString},
-					{valStr: "sum", etype: EtString},
-					{valStr: "1minutes", etype: EtString},
-				},
-				argString: "metric1, '1h', 'sum', '1minutes'",
-			},
-			1410346745,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410346740,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"smartSummarize(metric1, '1h', 'sum', 'hours')",
-			&expr{
-				target: "smartSummarize",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "1h", etype: EtString},
-					{valStr: "sum", etype: EtString},
-					{valStr: "hours", etype: EtString},
-				},
-				argString: "metric1, '1h', 'sum', 'hours'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410343200,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"smartSummarize(metric1, '1h', 'sum', 'days')",
-			&expr{
-				target: "smartSummarize",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "1h", etype: EtString},
-					{valStr: "sum", etype: EtString},
-					{valStr: "days", etype: EtString},
-				},
-				argString: "metric1, '1h', 'sum', 'days'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1410307200,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"smartSummarize(metric1, '1hours','sum','weeks5')",
-			&expr{
-				target: "smartSummarize",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "1h", etype: EtString},
-					{valStr: "sum", etype: EtString},
-					{valStr: "weeks5", etype: EtString},
-				},
-				argString: "metric1, '1h', 'sum', 'weeks5'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1409875200,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"smartSummarize(metric1, '1hours','sum','months')",
-			&expr{
-				target: "smartSummarize",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "1h", etype: EtString},
-					{valStr: "sum", etype: EtString},
-					{valStr: "months", etype: EtString},
-				},
-				argString: "metric1, '1h', 'sum', 'months'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1409529600,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"smartSummarize(metric1, '1hours','sum','y')",
-			&expr{
-				target: "smartSummarize",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "1h", etype: EtString},
-					{valStr: "sum", etype: EtString},
-					{valStr: "years", etype: EtString},
-				},
-				argString: "metric1, '1h', 'sum', 'years'",
-			},
-			1410346740,
-			1410346865,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   1388534400,
-					Until:  1410346865,
-				},
-			},
-			"UTC",
-		},
-		{
-			"timeShift(metric1, '1h')",
-			&expr{
-				target: "timeShift",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "1h", etype: EtString},
-				},
-				argString: "metric1, '1h'",
-			},
-			323869020,
-			323872620,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   323869020,
-					Until:  323872620,
-				},
-				{
-					Metric: "metric1",
-					From:   323865420,
-					Until:  323869020,
-				},
-			},
-			"Europe/Berlin",
-		},
-		{
-			"timeShift(metric1, '1d',true,true)",
-			&expr{
-				target: "timeShift",
-				etype:  EtFunc,
-				args: []*expr{
-					{target: "metric1"},
-					{valStr: "1d", etype: EtString},
-					{valStr: "true", etype: EtBool},
-					{valStr: "true", etype: EtBool},
-				},
-				argString: "metric1, '1d', true, true",
-			},
-			323869020,
-			323872620,
-			[]MetricRequest{
-				{
-					Metric: "metric1",
-					From:   323869020,
-					Until:  323872620,
-				},
-				{
-					Metric: "metric1",
-					From:   323786220,
-					Until:  323789820,
-				},
-			},
-			"Europe/Berlin",
-		},
+type shiftAdjuster struct{}
+
+func (shiftAdjuster) AdjustMetrics(e Expr, from, until int64, tz *time.Location, r []MetricRequest) []MetricRequest {
+	for i := range r {
+		r[i].From -= 60
 	}
-	for _, tt := range tests {
-		t.Run(tt.s, func(t *testing.T) {
+	return r
+}
 
-			loc, _ := time.LoadLocation(tt.tz)
-			r := tt.e.Metrics(tt.from, tt.to, loc)
-			assert.Equal(t, tt.expected, r)
-		})
+func TestMetricsAdjuster(t *testing.T) {
+	defer SetMetricsAdjusterLookup(metricsAdjusterLookup)
+	SetMetricsAdjusterLookup(func(name string) MetricsAdjuster {
+		if name == "shift" {
+			return shiftAdjuster{}
+		}
+		return nil
+	})
+
+	e, _, err := ParseExpr("sumSeries(shift(metric1),metric2)")
+	if !assert.NoError(t, err) {
+		return
 	}
+
+	assert.Equal(t, []MetricRequest{
+		{Metric: "metric1", From: 940, Until: 2000},
+		{Metric: "metric2", From: 1000, Until: 2000},
+	}, e.Metrics(1000, 2000, time.UTC))
 }
@@ -0,0 +1,858 @@
+// Package promql translates a subset of PromQL into graphite expressions over tagged series.
+//
+// Supported subset:
+//   - instant vector selectors with matchers: metric{label="value", label!="value", label=~"re", label!~"re"}
+//   - range vectors inside rate, irate and increase: rate(metric[5m])
+//   - offset modifier: metric offset 1h, rate(metric[5m] offset 1h)
+//   - aggregations sum, avg, min, max and count, with optional `by (labels)` clause
+//   - arithmetic operators (+, -, *, /, ^) between a vector and a scalar or between two scalars
+package promql
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ansel1/merry"
+)
+
+var (
+	// ErrUnsupported is returned when query uses PromQL feature that is not supported
+	ErrUnsupported = errors.New("unsupported query")
+	// ErrBadQuery is returned when query can't be parsed
+	ErrBadQuery = errors.New("bad query")
+)
+
+// Query is a result of PromQL translation
+type Query struct {
+	// Target is graphite expression equivalent to the query. Empty for scalar queries.
+	Target string
+	// Scalar is set when query is a constant expression, Value contains its result
+	Scalar bool
+	Value  float64
+	// KeepName is set when resulting series are still identified by a metric name (plain selectors)
+	KeepName bool
+}
+
+// Translate parses PromQL query and translates it into graphite expression
+func Translate(query string) (*Query, error) {
+	p := &promParser{lex: lexer{input: query}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	n, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.typ != tokEOF {
+		return nil, p.errorf("unexpected %q", p.tok.val)
+	}
+
+	if num, ok := n.(*numberLiteral); ok {
+		return &Query{Scalar: true, Value: num.value}, nil
+	}
+
+	target, err := n.graphite()
+	if err != nil {
+		return nil, err
+	}
+
+	_, keepName := n.(*vectorSelector)
+	return &Query{Target: target, KeepName: keepName}, nil
+}
+
+// ParseSelector parses series selector (as used in match[] parameter) into list of graphite tag expressions
+func ParseSelector(selector string) ([]string, error) {
+	p := &promParser{lex: lexer{input: selector}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.typ != tokEOF {
+		return nil, p.errorf("unexpected %q", p.tok.val)
+	}
+	vs, ok := n.(*vectorSelector)
+	if !ok || vs.rangeSeconds != 0 || vs.offsetSeconds != 0 {
+		return nil, merry.WithMessagef(ErrBadQuery, "%q is not a series selector", selector)
+	}
+
+	return vs.tagExpressions()
+}
+
+// ParseDuration parses PromQL duration (e.x. 5m, 1h30m) or a number of seconds
+func ParseDuration(s string) (time.Duration, error) {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		if math.IsNaN(f) || math.IsInf(f, 0) || f < 0 {
+			return 0, merry.WithMessagef(ErrBadQuery, "invalid duration %q", s)
+		}
+		return time.Duration(f * float64(time.Second)), nil
+	}
+
+	if s == "" {
+		return 0, merry.WithMessagef(ErrBadQuery, "empty duration")
+	}
+
+	var total time.Duration
+	orig := s
+	for len(s) > 0 {
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return 0, merry.WithMessagef(ErrBadQuery, "invalid duration %q", orig)
+		}
+		n, err := strconv.ParseInt(s[:i], 10, 64)
+		if err != nil {
+			return 0, merry.WithMessagef(ErrBadQuery, "invalid duration %q", orig)
+		}
+		s = s[i:]
+
+		j := 0
+		for j < len(s) && (s[j] < '0' || s[j] > '9') {
+			j++
+		}
+		var unit time.Duration
+		switch s[:j] {
+		case "ms":
+			unit = time.Millisecond
+		case "s":
+			unit = time.Second
+		case "m":
+			unit = time.Minute
+		case "h":
+			unit = time.Hour
+		case "d":
+			unit = 24 * time.Hour
+		case "w":
+			unit = 7 * 24 * time.Hour
+		case "y":
+			unit = 365 * 24 * time.Hour
+		default:
+			return 0, merry.WithMessagef(ErrBadQuery, "invalid duration %q", orig)
+		}
+		s = s[j:]
+		total += time.Duration(n) * unit
+	}
+
+	return total, nil
+}
+
+// durationSeconds converts duration to whole seconds, as graphite intervals have seconds precision
+func durationSeconds(s string) (int64, error) {
+	d, err := ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 || d%time.Second != 0 {
+		return 0, merry.WithMessagef(ErrUnsupported, "duration %q must be a positive whole number of seconds", s)
+	}
+	return int64(d / time.Second), nil
+}
+
+type node interface {
+	graphite() (string, error)
+}
+
+type numberLiteral struct {
+	value float64
+}
+
+func (n *numberLiteral) graphite() (string, error) {
+	return formatFloat(n.value), nil
+}
+
+type labelMatcher struct {
+	name  string
+	op    string
+	value string
+}
+
+type vectorSelector struct {
+	name          string
+	matchers      []labelMatcher
+	rangeSeconds  int64
+	offsetSeconds int64
+}
+
+func (n *vectorSelector) tagExpressions() ([]string, error) {
+	res := make([]string, 0, len(n.matchers)+1)
+	haveEquality := false
+	if n.name != "" {
+		res = append(res, "name="+n.name)
+		haveEquality = true
+	}
+	for _, m := range n.matchers {
+		name := m.name
+		if name == "__name__" {
+			name = "name"
+		}
+		value := m.value
+		var op string
+		switch m.op {
+		case "=":
+			op = "="
+			if value != "" {
+				haveEquality = true
+			}
+		case "!=":
+			op = "!="
+		case "=~":
+			op = "=~"
+			value = "^(?:" + value + ")$"
+		case "!~":
+			op = "!=~"
+			value = "^(?:" + value + ")$"
+		}
+		res = append(res, name+op+value)
+	}
+	if !haveEquality {
+		return nil, merry.WithMessagef(ErrUnsupported, "vector selector must contain at least one non-empty equality matcher")
+	}
+	return res, nil
+}
+
+func (n *vectorSelector) graphite() (string, error) {
+	if n.rangeSeconds != 0 {
+		return "", merry.WithMessagef(ErrUnsupported, "range vector is supported only as rate, irate or increase argument")
+	}
+	return n.instant()
+}
+
+func (n *vectorSelector) instant() (string, error) {
+	tags, err := n.tagExpressions()
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	sb.WriteString("seriesByTag(")
+	for i, t := range tags {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(quote(t))
+	}
+	sb.WriteByte(')')
+
+	if n.offsetSeconds != 0 {
+		return "timeShift(" + sb.String() + ",'" + strconv.FormatInt(n.offsetSeconds, 10) + "s')", nil
+	}
+	return sb.String(), nil
+}
+
+type call struct {
+	fn  string
+	arg node
+}
+
+func (n *call) graphite() (string, error) {
+	vs, ok := n.arg.(*vectorSelector)
+	if !ok || vs.rangeSeconds == 0 {
+		return "", merry.WithMessagef(ErrBadQuery, "%s expects a range vector argument", n.fn)
+	}
+	arg, err := vs.instant()
+	if err != nil {
+		return "", err
+	}
+	window := "'" + strconv.FormatInt(vs.rangeSeconds, 10) + "s'"
+
+	switch n.fn {
+	case "rate":
+		return "movingAverage(perSecond(" + arg + ")," + window + ")", nil
+	case "irate":
+		// rate between the last two samples, there is no result if there are no samples in the window
+		return "movingWindow(perSecond(" + arg + ")," + window + ",'last')", nil
+	case "increase":
+		return "movingSum(nonNegativeDerivative(" + arg + ")," + window + ")", nil
+	}
+	return "", merry.WithMessagef(ErrUnsupported, "function %s is not supported", n.fn)
+}
+
+type aggregation struct {
+	op       string
+	grouping []string
+	arg      node
+}
+
+var aggregationFunctions = map[string]string{
+	"sum":   "sum",
+	"avg":   "average",
+	"min":   "min",
+	"max":   "max",
+	"count": "count",
+}
+
+func (n *aggregation) graphite() (string, error) {
+	arg, err := n.arg.graphite()
+	if err != nil {
+		return "", err
+	}
+	fn := aggregationFunctions[n.op]
+	if len(n.grouping) == 0 {
+		return fn + "Series(" + arg + ")", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("groupByTags(")
+	sb.WriteString(arg)
+	sb.WriteString(",'")
+	sb.WriteString(fn)
+	sb.WriteByte('\'')
+	for _, l := range n.grouping {
+		if l == "__name__" {
+			l = "name"
+		}
+		sb.WriteByte(',')
+		sb.WriteString(quote(l))
+	}
+	sb.WriteByte(')')
+	return sb.String(), nil
+}
+
+type binaryExpr struct {
+	op  string
+	lhs node
+	rhs node
+}
+
+func (n *binaryExpr) graphite() (string, error) {
+	lNum, lIsNum := n.lhs.(*numberLiteral)
+	rNum, rIsNum := n.rhs.(*numberLiteral)
+	switch {
+	case rIsNum:
+		vector, err := n.lhs.graphite()
+		if err != nil {
+			return "", err
+		}
+		v := rNum.value
+		switch n.op {
+		case "+":
+			return "offset(" + vector + "," + formatFloat(v) + ")", nil
+		case "-":
+			return "offset(" + vector + "," + formatFloat(-v) + ")", nil
+		case "*":
+			return "scale(" + vector + "," + formatFloat(v) + ")", nil
+		case "/":
+			return "scale(" + vector + "," + formatFloat(1/v) + ")", nil
+		case "^":
+			return "pow(" + vector + "," + formatFloat(v) + ")", nil
+		}
+	case lIsNum:
+		vector, err := n.rhs.graphite()
+		if err != nil {
+			return "", err
+		}
+		v := lNum.value
+		switch n.op {
+		case "+":
+			return "offset(" + vector + "," + formatFloat(v) + ")", nil
+		case "-":
+			return "offset(scale(" + vector + ",-1)," + formatFloat(v) + ")", nil
+		case "*":
+			return "scale(" + vector + "," + formatFloat(v) + ")", nil
+		case "/":
+			return "scale(invert(" + vector + ")," + formatFloat(v) + ")", nil
+		}
+	default:
+		return "", merry.WithMessagef(ErrUnsupported, "binary operations between two vectors are not supported")
+	}
+
+	return "", merry.WithMessagef(ErrUnsupported, "operator %s is not supported with scalar on the left side", n.op)
+}
+
+func applyOp(op string, l, r float64) float64 {
+	switch op {
+	case "+":
+		return l + r
+	case "-":
+		return l - r
+	case "*":
+		return l * r
+	case "/":
+		return l / r
+	case "^":
+		return math.Pow(l, r)
+	}
+	return math.NaN()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// quote quotes string as graphite string argument
+func quote(s string) string {
+	if strings.Contains(s, "'") {
+		return strconv.Quote(s)
+	}
+	return "'" + s + "'"
+}
+
+type tokenType int
+
+const (
+	tokEOF tokenType = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokDuration
+	tokOp
+	tokLParen
+	tokRParen
+	tokLBrace
+	tokRBrace
+	tokLBracket
+	tokRBracket
+	tokComma
+)
+
+type token struct {
+	typ tokenType
+	val string
+	pos int
+}
+
+type lexer struct {
+	input string
+	pos   int
+	// inBrackets is set inside [] where durations are expected
+	inBrackets bool
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) {
+		r, w := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		l.pos += w
+	}
+	if l.pos >= len(l.input) {
+		return token{typ: tokEOF, pos: l.pos}, nil
+	}
+
+	start := l.pos
+	c := l.input[l.pos]
+	switch {
+	case c == '(':
+		l.pos++
+		return token{tokLParen, "(", start}, nil
+	case c == ')':
+		l.pos++
+		return token{tokRParen, ")", start}, nil
+	case c == '{':
+		l.pos++
+		return token{tokLBrace, "{", start}, nil
+	case c == '}':
+		l.pos++
+		return token{tokRBrace, "}", start}, nil
+	case c == '[':
+		l.pos++
+		l.inBrackets = true
+		return token{tokLBracket, "[", start}, nil
+	case c == ']':
+		l.pos++
+		l.inBrackets = false
+		return token{tokRBracket, "]", start}, nil
+	case c == ',':
+		l.pos++
+		return token{tokComma, ",", start}, nil
+	case c == '"' || c == '\'' || c == '`':
+		return l.lexString(c)
+	case c == '=' || c == '!':
+		if l.pos+1 < len(l.input) {
+			two := l.input[l.pos : l.pos+2]
+			if two == "=~" || two == "!~" || two == "!=" || two == "==" {
+				l.pos += 2
+				return token{tokOp, two, start}, nil
+			}
+		}
+		if c == '=' {
+			l.pos++
+			return token{tokOp, "=", start}, nil
+		}
+	case strings.IndexByte("+-*/^%<>", c) >= 0:
+		l.pos++
+		return token{tokOp, string(c), start}, nil
+	case c >= '0' && c <= '9' || c == '.':
+		return l.lexNumberOrDuration()
+	case c == '_' || c == ':' || unicode.IsLetter(rune(c)):
+		for l.pos < len(l.input) {
+			c := l.input[l.pos]
+			if c == '_' || c == ':' || c == '.' && !l.inBrackets || unicode.IsLetter(rune(c)) || c >= '0' && c <= '9' {
+				l.pos++
+				continue
+			}
+			break
+		}
+		return token{tokIdent, l.input[start:l.pos], start}, nil
+	}
+	return token{}, merry.WithMessagef(ErrBadQuery, "unexpected character %q at position %d", c, start)
+}
+
+func (l *lexer) lexString(quoteChar byte) (token, error) {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		if c == '\\' && quoteChar != '`' {
+			l.pos += 2
+			continue
+		}
+		if c == quoteChar {
+			l.pos++
+			raw := l.input[start:l.pos]
+			if quoteChar == '`' {
+				return token{tokString, raw[1 : len(raw)-1], start}, nil
+			}
+			if quoteChar == '\'' {
+				raw = "\"" + strings.ReplaceAll(strings.ReplaceAll(raw[1:len(raw)-1], "\\'", "'"), "\"", "\\\"") + "\""
+			}
+			s, err := strconv.Unquote(raw)
+			if err != nil {
+				return token{}, merry.WithMessagef(ErrBadQuery, "invalid string at position %d", start)
+			}
+			return token{tokString, s, start}, nil
+		}
+		l.pos++
+	}
+	return token{}, merry.WithMessagef(ErrBadQuery, "unterminated string at position %d", start)
+}
+
+func (l *lexer) lexNumberOrDuration() (token, error) {
+	start := l.pos
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		if c >= '0' && c <= '9' || c == '.' || c == 'e' || c == 'E' {
+			l.pos++
+			continue
+		}
+		if (c == '+' || c == '-') && (l.input[l.pos-1] == 'e' || l.input[l.pos-1] == 'E') {
+			l.pos++
+			continue
+		}
+		break
+	}
+	// duration, e.x. 5m or 1h30m
+	if l.pos < len(l.input) && strings.IndexByte("smhdwy", l.input[l.pos]) >= 0 {
+		for l.pos < len(l.input) {
+			c := l.input[l.pos]
+			if c >= '0' && c <= '9' || strings.IndexByte("smhdwy", c) >= 0 {
+				l.pos++
+				continue
+			}
+			break
+		}
+		return token{tokDuration, l.input[start:l.pos], start}, nil
+	}
+	return token{tokNumber, l.input[start:l.pos], start}, nil
+}
+
+type promParser struct {
+	lex lexer
+	tok token
+}
+
+func (p *promParser) next() error {
+	t, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = t
+	return nil
+}
+
+func (p *promParser) errorf(format string, args ...interface{}) error {
+	return merry.WithMessagef(ErrBadQuery, "%s at position %d", fmt.Sprintf(format, args...), p.tok.pos)
+}
+
+func (p *promParser) expect(typ tokenType, what string) error {
+	if p.tok.typ != typ {
+		return p.errorf("expected %s, got %q", what, p.tok.val)
+	}
+	return p.next()
+}
+
+var precedence = map[string]int{
+	"+": 1,
+	"-": 1,
+	"*": 2,
+	"/": 2,
+	"%": 2,
+	"^": 3,
+}
+
+// parseExpr parses binary expressions with precedence climbing
+func (p *promParser) parseExpr(minPrec int) (node, error) {
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok.typ == tokOp {
+		op := p.tok.val
+		prec, ok := precedence[op]
+		if !ok {
+			return nil, merry.WithMessagef(ErrUnsupported, "operator %s is not supported", op)
+		}
+		if prec < minPrec {
+			break
+		}
+		if op == "%" {
+			return nil, merry.WithMessagef(ErrUnsupported, "operator %s is not supported", op)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		nextPrec := prec + 1
+		// ^ is right-associative
+		if op == "^" {
+			nextPrec = prec
+		}
+		rhs, err := p.parseExpr(nextPrec)
+		if err != nil {
+			return nil, err
+		}
+		lNum, lIsNum := lhs.(*numberLiteral)
+		rNum, rIsNum := rhs.(*numberLiteral)
+		if lIsNum && rIsNum {
+			lhs = &numberLiteral{value: applyOp(op, lNum.value, rNum.value)}
+		} else {
+			lhs = &binaryExpr{op: op, lhs: lhs, rhs: rhs}
+		}
+	}
+
+	return lhs, nil
+}
+
+func (p *promParser) parseUnary() (node, error) {
+	if p.tok.typ == tokOp && (p.tok.val == "-" || p.tok.val == "+") {
+		op := p.tok.val
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if op == "+" {
+			return n, nil
+		}
+		if num, ok := n.(*numberLiteral); ok {
+			return &numberLiteral{value: -num.value}, nil
+		}
+		return &binaryExpr{op: "*", lhs: n, rhs: &numberLiteral{value: -1}}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *promParser) parsePrimary() (node, error) {
+	switch p.tok.typ {
+	case tokNumber:
+		v, err := strconv.ParseFloat(p.tok.val, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", p.tok.val)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		return &numberLiteral{value: v}, nil
+	case tokLParen:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		n, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokRParen, ")"); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case tokLBrace:
+		return p.parseSelector("")
+	case tokIdent:
+		name := p.tok.val
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if _, ok := aggregationFunctions[name]; ok && (p.tok.typ == tokLParen || p.tok.typ == tokIdent) {
+			return p.parseAggregation(name)
+		}
+		if p.tok.typ == tokLParen {
+			return p.parseCall(name)
+		}
+		switch name {
+		case "inf", "Inf":
+			return &numberLiteral{value: math.Inf(1)}, nil
+		case "nan", "NaN":
+			return &numberLiteral{value: math.NaN()}, nil
+		}
+		return p.parseSelector(name)
+	}
+	return nil, p.errorf("unexpected %q", p.tok.val)
+}
+
+func (p *promParser) parseCall(fn string) (node, error) {
+	switch fn {
+	case "rate", "irate", "increase":
+	default:
+		return nil, merry.WithMessagef(ErrUnsupported, "function %s is not supported", fn)
+	}
+	if err := p.expect(tokLParen, "("); err != nil {
+		return nil, err
+	}
+	arg, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(tokRParen, ")"); err != nil {
+		return nil, err
+	}
+	return &call{fn: fn, arg: arg}, nil
+}
+
+func (p *promParser) parseGrouping() ([]string, error) {
+	modifier := p.tok.val
+	if modifier != "by" {
+		return nil, merry.WithMessagef(ErrUnsupported, "aggregation modifier %q is not supported", modifier)
+	}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if err := p.expect(tokLParen, "("); err != nil {
+		return nil, err
+	}
+	var labels []string
+	for p.tok.typ != tokRParen {
+		if p.tok.typ != tokIdent {
+			return nil, p.errorf("expected label name, got %q", p.tok.val)
+		}
+		labels = append(labels, p.tok.val)
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.typ == tokComma {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		} else if p.tok.typ != tokRParen {
+			return nil, p.errorf("expected , or ), got %q", p.tok.val)
+		}
+	}
+	return labels, p.next()
+}
+
+func (p *promParser) parseAggregation(op string) (node, error) {
+	var grouping []string
+	var err error
+	if p.tok.typ == tokIdent {
+		if grouping, err = p.parseGrouping(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(tokLParen, "("); err != nil {
+		return nil, err
+	}
+	arg, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(tokRParen, ")"); err != nil {
+		return nil, err
+	}
+	if p.tok.typ == tokIdent && grouping == nil {
+		if grouping, err = p.parseGrouping(); err != nil {
+			return nil, err
+		}
+	}
+	return &aggregation{op: op, grouping: grouping, arg: arg}, nil
+}
+
+func (p *promParser) parseSelector(name string) (node, error) {
+	vs := &vectorSelector{name: name}
+	if p.tok.typ == tokLBrace {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		for p.tok.typ != tokRBrace {
+			if p.tok.typ != tokIdent {
+				return nil, p.errorf("expected label name, got %q", p.tok.val)
+			}
+			m := labelMatcher{name: p.tok.val}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if p.tok.typ != tokOp || (p.tok.val != "=" && p.tok.val != "!=" && p.tok.val != "=~" && p.tok.val != "!~") {
+				return nil, p.errorf("expected label matching operator, got %q", p.tok.val)
+			}
+			m.op = p.tok.val
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if p.tok.typ != tokString {
+				return nil, p.errorf("expected label value, got %q", p.tok.val)
+			}
+			m.value = p.tok.val
+			vs.matchers = append(vs.matchers, m)
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			if p.tok.typ == tokComma {
+				if err := p.next(); err != nil {
+					return nil, err
+				}
+			} else if p.tok.typ != tokRBrace {
+				return nil, p.errorf("expected , or }, got %q", p.tok.val)
+			}
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.tok.typ == tokLBracket {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.typ != tokDuration && p.tok.typ != tokNumber {
+			return nil, p.errorf("expected duration, got %q", p.tok.val)
+		}
+		var err error
+		if vs.rangeSeconds, err = durationSeconds(p.tok.val); err != nil {
+			return nil, err
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokRBracket, "]"); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.tok.typ == tokIdent && p.tok.val == "offset" {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.typ != tokDuration && p.tok.typ != tokNumber {
+			return nil, p.errorf("expected duration, got %q", p.tok.val)
+		}
+		var err error
+		if vs.offsetSeconds, err = durationSeconds(p.tok.val); err != nil {
+			return nil, err
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+
+	return vs, nil
+}
@@ -0,0 +1,163 @@
+package promql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ansel1/merry"
+
+	"github.com/go-graphite/carbonapi/pkg/parser"
+)
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		query    string
+		want     string
+		keepName bool
+	}{
+		{`http_requests_total`, `seriesByTag('name=http_requests_total')`, true},
+		{`http_requests_total{job="api", code!="200"}`, `seriesByTag('name=http_requests_total','job=api','code!=200')`, true},
+		{`{__name__="up", instance=~"host-(1|2)"}`, `seriesByTag('name=up','instance=~^(?:host-(1|2))$')`, true},
+		{`up{env!~'dev.*'}`, `seriesByTag('name=up','env!=~^(?:dev.*)$')`, true},
+		{`up offset 1h`, `timeShift(seriesByTag('name=up'),'3600s')`, true},
+		{`rate(http_requests_total[5m])`, `movingAverage(perSecond(seriesByTag('name=http_requests_total')),'300s')`, false},
+		{`irate(http_requests_total[1m])`, `movingWindow(perSecond(seriesByTag('name=http_requests_total')),'60s','last')`, false},
+		{`increase(http_requests_total[1h30m] offset 1d)`, `movingSum(nonNegativeDerivative(timeShift(seriesByTag('name=http_requests_total'),'86400s')),'5400s')`, false},
+		{`sum(rate(requests[5m]))`, `sumSeries(movingAverage(perSecond(seriesByTag('name=requests')),'300s'))`, false},
+		{`sum by (job, code) (requests)`, `groupByTags(seriesByTag('name=requests'),'sum','job','code')`, false},
+		{`avg(requests) by (job)`, `groupByTags(seriesByTag('name=requests'),'average','job')`, false},
+		{`max by (__name__) (requests)`, `groupByTags(seriesByTag('name=requests'),'max','name')`, false},
+		{`requests * 2`, `scale(seriesByTag('name=requests'),2)`, false},
+		{`requests / 4`, `scale(seriesByTag('name=requests'),0.25)`, false},
+		{`requests - 1`, `offset(seriesByTag('name=requests'),-1)`, false},
+		{`10 - requests`, `offset(scale(seriesByTag('name=requests'),-1),10)`, false},
+		{`2 / requests`, `scale(invert(seriesByTag('name=requests')),2)`, false},
+		{`requests ^ 2`, `pow(seriesByTag('name=requests'),2)`, false},
+		{`-requests`, `scale(seriesByTag('name=requests'),-1)`, false},
+		{`requests * 2 + 1`, `offset(scale(seriesByTag('name=requests'),2),1)`, false},
+		{`requests * (2 + 1)`, `scale(seriesByTag('name=requests'),3)`, false},
+		{`sum(requests) * 100 / 2`, `scale(scale(sumSeries(seriesByTag('name=requests')),100),0.5)`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Translate(tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if q.Target != tt.want {
+				t.Errorf("target mismatch:\ngot  %s\nwant %s", q.Target, tt.want)
+			}
+			if q.KeepName != tt.keepName {
+				t.Errorf("keepName mismatch: got %v, want %v", q.KeepName, tt.keepName)
+			}
+			if _, e, err := parser.ParseExpr(q.Target); err != nil || e != "" {
+				t.Errorf("failed to parse translated target %s: %v, left '%s'", q.Target, err, e)
+			}
+		})
+	}
+}
+
+func TestTranslateScalar(t *testing.T) {
+	tests := []struct {
+		query string
+		want  float64
+	}{
+		{`1`, 1},
+		{`2 * 3 + 1`, 7},
+		{`2 ^ 3 ^ 2`, 512},
+		{`-(1 + 2)`, -3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Translate(tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !q.Scalar || q.Value != tt.want {
+				t.Errorf("got %+v, want scalar %v", q, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslateErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		err   error
+	}{
+		{`requests[5m]`, ErrUnsupported},
+		{`rate(requests)`, ErrBadQuery},
+		{`a + b`, ErrUnsupported},
+		{`a > 1`, ErrUnsupported},
+		{`histogram_quantile(0.9, a)`, ErrUnsupported},
+		{`sum without (job) (a)`, ErrUnsupported},
+		{`{job=""}`, ErrUnsupported},
+		{`{job!="a"}`, ErrUnsupported},
+		{`a{job="a"`, ErrBadQuery},
+		{`a{job="a}`, ErrBadQuery},
+		{`rate(a[5x])`, ErrBadQuery},
+		{`rate(a[500ms])`, ErrUnsupported},
+		{`a )`, ErrBadQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Translate(tt.query)
+			if !merry.Is(err, tt.err) {
+				t.Errorf("got error %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestParseSelector(t *testing.T) {
+	got, err := ParseSelector(`up{job="api",instance!~"db.*"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"name=up", "job=api", "instance!=~^(?:db.*)$"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+
+	if _, err := ParseSelector(`rate(up[5m])`); err == nil {
+		t.Errorf("expected error for non-selector")
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"15", 15 * time.Second},
+		{"0.5", 500 * time.Millisecond},
+		{"5m", 5 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+		{"1w", 7 * 24 * time.Hour},
+		{"100ms", 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "5x", "m", "-1"} {
+		if _, err := ParseDuration(in); err == nil {
+			t.Errorf("%s: expected error", in)
+		}
+	}
+}
@@ -567,6 +567,10 @@ func (zp TestZipper) TagValues(ctx context.Context, query string, limit int64) (
 	return nil, zipperTypes.ErrNotImplementedYet
 }
 
+func (zp TestZipper) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	return nil, zipperTypes.ErrNotImplementedYet
+}
+
 func (zp TestZipper) ScaleToCommonStep() bool {
 	return false
 }
@@ -0,0 +1,89 @@
+package ctx
+
+import (
+	"context"
+	"sync"
+
+	pb "github.com/go-graphite/protocol/carbonapi_v3_pb"
+)
+
+// CapturedFetch is a fetch request sent to zipper with merged response of backends
+type CapturedFetch struct {
+	Request  pb.MultiFetchRequest
+	Response *pb.MultiFetchResponse
+	Err      error
+}
+
+// CapturedFind is a find request sent to zipper with merged response of backends
+type CapturedFind struct {
+	Request  pb.MultiGlobRequest
+	Response *pb.MultiGlobResponse
+	Err      error
+}
+
+// Capture collects requests to zipper and their responses, so the request could be replayed later against
+// mockbackend, see SetCapture
+type Capture struct {
+	mu      sync.Mutex
+	fetches []CapturedFetch
+	finds   []CapturedFind
+	from    int64
+	until   int64
+}
+
+// SetTimeRange records time range of the request resolved to unix timestamps, so relative from and until could be
+// replaced with it on replay
+func (c *Capture) SetTimeRange(from, until int64) {
+	c.mu.Lock()
+	c.from, c.until = from, until
+	c.mu.Unlock()
+}
+
+// TimeRange returns resolved time range of the request or zeroes if it wasn't set
+func (c *Capture) TimeRange() (int64, int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.from, c.until
+}
+
+// AddFetch records fetch request and its response
+func (c *Capture) AddFetch(req pb.MultiFetchRequest, resp *pb.MultiFetchResponse, err error) {
+	c.mu.Lock()
+	c.fetches = append(c.fetches, CapturedFetch{Request: req, Response: resp, Err: err})
+	c.mu.Unlock()
+}
+
+// AddFind records find request and its response
+func (c *Capture) AddFind(req pb.MultiGlobRequest, resp *pb.MultiGlobResponse, err error) {
+	c.mu.Lock()
+	c.finds = append(c.finds, CapturedFind{Request: req, Response: resp, Err: err})
+	c.mu.Unlock()
+}
+
+// Fetches returns recorded fetch requests in the order they were sent
+func (c *Capture) Fetches() []CapturedFetch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]CapturedFetch(nil), c.fetches...)
+}
+
+// Finds returns recorded find requests in the order they were sent
+func (c *Capture) Finds() []CapturedFind {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]CapturedFind(nil), c.finds...)
+}
+
+// SetCapture stores collector of zipper requests for the request that is captured
+func SetCapture(ctx context.Context, c *Capture) context.Context {
+	return context.WithValue(ctx, captureKey, c)
+}
+
+// GetCapture returns collector of zipper requests or nil if the request isn't captured
+func GetCapture(ctx context.Context) *Capture {
+	v := ctx.Value(captureKey)
+	if v != nil {
+		return v.(*Capture)
+	}
+	return nil
+}
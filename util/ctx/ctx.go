@@ -3,6 +3,9 @@ package ctx
 import (
 	"context"
 	"net/http"
+	"sort"
+	"sync"
+	"time"
 )
 
 type key int
@@ -15,6 +18,13 @@ const (
 	headersToPassKey
 	headersToLogKey
 	maxDataPoints
+	timeZoneKey
+	pushedDownKey
+	memoryAccountantKey
+	backendTimingsKey
+	xFilesFactorKey
+	captureKey
+	failedServersKey
 )
 
 func ifaceToString(v interface{}) string {
@@ -80,6 +90,160 @@ func GetMaxDatapoints(ctx context.Context) int64 {
 	return getCtxInt64(ctx, maxDataPoints)
 }
 
+// SetTimeZone stores request time zone (tz parameter), so functions can work with local time
+func SetTimeZone(ctx context.Context, tz *time.Location) context.Context {
+	return context.WithValue(ctx, timeZoneKey, tz)
+}
+
+// GetTimeZone returns request time zone or nil if it wasn't set
+func GetTimeZone(ctx context.Context) *time.Location {
+	v := ctx.Value(timeZoneKey)
+	if v != nil {
+		return v.(*time.Location)
+	}
+	return nil
+}
+
+// SetXFilesFactor stores xFilesFactor of the request (xFilesFactor parameter), it's used for series fetched from backends
+// and by aggregate functions
+func SetXFilesFactor(ctx context.Context, xFilesFactor float32) context.Context {
+	return context.WithValue(ctx, xFilesFactorKey, xFilesFactor)
+}
+
+// GetXFilesFactor returns xFilesFactor of the request, ok is false if it wasn't set
+func GetXFilesFactor(ctx context.Context) (xFilesFactor float32, ok bool) {
+	xFilesFactor, ok = ctx.Value(xFilesFactorKey).(float32)
+	return
+}
+
+// PushedDown collects expressions that were evaluated by backends, see SetPushedDown
+type PushedDown struct {
+	mu    sync.Mutex
+	exprs []string
+}
+
+// Add records expression that was evaluated by a backend
+func (p *PushedDown) Add(expr string) {
+	p.mu.Lock()
+	p.exprs = append(p.exprs, expr)
+	p.mu.Unlock()
+}
+
+// Len returns amount of expressions that were evaluated by backends
+func (p *PushedDown) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.exprs)
+}
+
+// List returns expressions that were evaluated by backends
+func (p *PushedDown) List() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.exprs...)
+}
+
+// SetPushedDown stores collector of expressions pushed down to backends, so they can be logged. Nothing is pushed down
+// if it isn't set, evaluator relies on it to know if results of backends must be looked up
+func SetPushedDown(ctx context.Context, p *PushedDown) context.Context {
+	return context.WithValue(ctx, pushedDownKey, p)
+}
+
+// GetPushedDown returns collector of expressions pushed down to backends or nil if it wasn't set
+func GetPushedDown(ctx context.Context) *PushedDown {
+	v := ctx.Value(pushedDownKey)
+	if v != nil {
+		return v.(*PushedDown)
+	}
+	return nil
+}
+
+// BackendTimings collects time spent in fetch requests to each backend, see SetBackendTimings
+type BackendTimings struct {
+	mu    sync.Mutex
+	times map[string]time.Duration
+}
+
+// Add records time spent in fetch request to backend
+func (b *BackendTimings) Add(backend string, d time.Duration) {
+	b.mu.Lock()
+	if b.times == nil {
+		b.times = make(map[string]time.Duration)
+	}
+	b.times[backend] += d
+	b.mu.Unlock()
+}
+
+// Map returns time spent in fetch requests by backend
+func (b *BackendTimings) Map() map[string]time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	times := make(map[string]time.Duration, len(b.times))
+	for backend, d := range b.times {
+		times[backend] = d
+	}
+	return times
+}
+
+// SetBackendTimings stores collector of time spent in fetch requests to backends, so it can be logged
+func SetBackendTimings(ctx context.Context, b *BackendTimings) context.Context {
+	return context.WithValue(ctx, backendTimingsKey, b)
+}
+
+// GetBackendTimings returns collector of time spent in fetch requests to backends or nil if it wasn't set
+func GetBackendTimings(ctx context.Context) *BackendTimings {
+	v := ctx.Value(backendTimingsKey)
+	if v != nil {
+		return v.(*BackendTimings)
+	}
+	return nil
+}
+
+// FailedServers collects backends that failed to reply to fetch requests, see SetFailedServers
+type FailedServers struct {
+	mu      sync.Mutex
+	servers map[string]struct{}
+}
+
+// Add records backends that failed to reply
+func (f *FailedServers) Add(servers ...string) {
+	f.mu.Lock()
+	if f.servers == nil {
+		f.servers = make(map[string]struct{})
+	}
+	for _, s := range servers {
+		f.servers[s] = struct{}{}
+	}
+	f.mu.Unlock()
+}
+
+// List returns sorted list of backends that failed to reply
+func (f *FailedServers) List() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	servers := make([]string, 0, len(f.servers))
+	for s := range f.servers {
+		servers = append(servers, s)
+	}
+	sort.Strings(servers)
+	return servers
+}
+
+// SetFailedServers stores collector of backends that failed to reply to fetch requests, so they could be reported
+// with partial results
+func SetFailedServers(ctx context.Context, f *FailedServers) context.Context {
+	return context.WithValue(ctx, failedServersKey, f)
+}
+
+// GetFailedServers returns collector of backends that failed to reply or nil if it wasn't set
+func GetFailedServers(ctx context.Context) *FailedServers {
+	v := ctx.Value(failedServersKey)
+	if v != nil {
+		return v.(*FailedServers)
+	}
+	return nil
+}
+
 func ParseCtx(h http.HandlerFunc, uuidKey string) http.HandlerFunc {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		uuid := req.Header.Get(uuidKey)
@@ -0,0 +1,82 @@
+package ctx
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/ansel1/merry"
+)
+
+// ErrMemoryLimitExceeded is returned when request allocated more memory for series than allowed
+var ErrMemoryLimitExceeded = merry.New("memory limit for the request exceeded").WithHTTPCode(http.StatusUnprocessableEntity)
+
+// Float64Size is amount of memory used by a value of series
+const Float64Size = int64(unsafe.Sizeof(float64(0)))
+
+// MemoryAccountant counts memory allocated for series values during the request. Memory is never released until the
+// request is done, so usage is also the peak usage. Methods of nil accountant do nothing, so callers don't have to
+// check if memory is accounted.
+type MemoryAccountant struct {
+	limit int64
+	used  int64
+}
+
+// NewMemoryAccountant returns accountant that fails after limit bytes were allocated or nil if limit isn't set
+func NewMemoryAccountant(limit int64) *MemoryAccountant {
+	if limit <= 0 {
+		return nil
+	}
+	return &MemoryAccountant{limit: limit}
+}
+
+// Charge counts bytes allocated by the request, it returns ErrMemoryLimitExceeded if request is over the limit
+func (a *MemoryAccountant) Charge(bytes int64) error {
+	if a == nil {
+		return nil
+	}
+	atomic.AddInt64(&a.used, bytes)
+	return a.Err()
+}
+
+// ChargeValues counts memory used by values, it returns ErrMemoryLimitExceeded if request is over the limit
+func (a *MemoryAccountant) ChargeValues(values []float64) error {
+	return a.Charge(int64(cap(values)) * Float64Size)
+}
+
+// Err returns ErrMemoryLimitExceeded if request is over the limit
+func (a *MemoryAccountant) Err() error {
+	if a == nil {
+		return nil
+	}
+	if atomic.LoadInt64(&a.used) > a.limit {
+		return merry.WithMessagef(ErrMemoryLimitExceeded, "memory limit for the request exceeded: series use more than %d bytes", a.limit)
+	}
+	return nil
+}
+
+// Peak returns amount of memory used by the request
+func (a *MemoryAccountant) Peak() int64 {
+	if a == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&a.used)
+}
+
+// SetMemoryAccountant stores memory accountant for the request, nothing is stored for nil accountant
+func SetMemoryAccountant(ctx context.Context, a *MemoryAccountant) context.Context {
+	if a == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, memoryAccountantKey, a)
+}
+
+// GetMemoryAccountant returns memory accountant for the request or nil if memory isn't accounted
+func GetMemoryAccountant(ctx context.Context) *MemoryAccountant {
+	v := ctx.Value(memoryAccountantKey)
+	if v != nil {
+		return v.(*MemoryAccountant)
+	}
+	return nil
+}
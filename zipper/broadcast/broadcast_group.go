@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/ansel1/merry"
 	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
@@ -34,8 +38,36 @@ type BroadcastGroup struct {
 
 	fetcher   types.Fetcher
 	pathCache pathcache.PathCache
-	logger    *zap.Logger
-	dialer    *net.Dialer
+	// pushdownPaths caches backends that have series for path expression, see backendsWithMatches
+	pushdownPaths *pathcache.PathCache
+	logger        *zap.Logger
+	dialer        *net.Dialer
+
+	states map[string]*backendState
+	tlds   *tldMap
+}
+
+// backendState is a runtime state of the child backend, see Status
+type backendState struct {
+	inFlight int64
+	waiting  int64
+
+	mu           sync.Mutex
+	lastProbe    time.Time
+	lastProbeErr merry.Error
+}
+
+func (s *backendState) setProbeResult(err merry.Error) {
+	s.mu.Lock()
+	s.lastProbe = time.Now()
+	s.lastProbeErr = err
+	s.mu.Unlock()
+}
+
+// tldMap is a result of the last ProbeTLDs, TLD -> backends that have it
+type tldMap struct {
+	sync.RWMutex
+	m map[string][]string
 }
 
 type Option func(group *BroadcastGroup)
@@ -80,6 +112,8 @@ func WithBackends(backends []types.BackendServer) Option {
 func WithPathCache(expireDelaySec int32) Option {
 	return func(bg *BroadcastGroup) {
 		bg.pathCache = pathcache.NewPathCache(expireDelaySec)
+		pushdownPaths := pathcache.NewPathCache(expireDelaySec)
+		bg.pushdownPaths = &pushdownPaths
 	}
 }
 
@@ -122,6 +156,7 @@ func WithSuccess(requireSuccessAll bool) Option {
 func New(opts ...Option) (*BroadcastGroup, merry.Error) {
 	bg := &BroadcastGroup{
 		limiter: limiter.NoopLimiter{},
+		tlds:    &tldMap{},
 	}
 
 	for _, opt := range opts {
@@ -143,9 +178,36 @@ func New(opts ...Option) (*BroadcastGroup, merry.Error) {
 		bg.limiter = limiter.NewServerLimiter(bg.servers, bg.concurrencyLimit)
 	}
 
+	bg.states = make(map[string]*backendState, len(bg.servers))
+	for _, s := range bg.servers {
+		bg.states[s] = &backendState{}
+	}
+
 	return bg, nil
 }
 
+// enter waits for a free slot in limiter for the backend and counts requests in flight
+func (bg *BroadcastGroup) enter(ctx context.Context, backend string) error {
+	state := bg.states[backend]
+	if state == nil {
+		return bg.limiter.Enter(ctx, backend)
+	}
+	atomic.AddInt64(&state.waiting, 1)
+	err := bg.limiter.Enter(ctx, backend)
+	atomic.AddInt64(&state.waiting, -1)
+	if err == nil {
+		atomic.AddInt64(&state.inFlight, 1)
+	}
+	return err
+}
+
+func (bg *BroadcastGroup) leave(ctx context.Context, backend string) {
+	if state := bg.states[backend]; state != nil {
+		atomic.AddInt64(&state.inFlight, -1)
+	}
+	bg.limiter.Leave(ctx, backend)
+}
+
 func (bg *BroadcastGroup) Children() []types.BackendServer {
 	return bg.backends
 }
@@ -223,6 +285,28 @@ func (bg BroadcastGroup) MaxMetricsPerRequest() int {
 	return bg.maxMetricsPerRequest
 }
 
+// fetch gets response from backend. Series streamed by backend are sent to resCh as partial responses as soon as they
+// are received, so they are merged without waiting for the rest of the response, and only stats and errors are returned.
+func (bg *BroadcastGroup) fetch(ctx context.Context, backend types.BackendServer, request *protov3.MultiFetchRequest, resCh chan types.ServerFetcherResponse) (*protov3.MultiFetchResponse, *types.Stats, merry.Error) {
+	if _, ok := backend.(types.StreamFetcher); !ok {
+		return types.FetchWithPrecision(ctx, backend, request)
+	}
+
+	stats, err := types.FetchStreamWithPrecision(ctx, backend, request, func(response *protov3.MultiFetchResponse) merry.Error {
+		r := types.NewServerFetchResponse()
+		r.Server = backend.Name()
+		r.Response = response
+		r.Partial = true
+		select {
+		case resCh <- r:
+			return nil
+		case <-ctx.Done():
+			return types.ErrTimeoutExceeded.WithValue("server", backend.Name())
+		}
+	})
+	return nil, stats, err
+}
+
 func (bg *BroadcastGroup) doMultiFetch(ctx context.Context, logger *zap.Logger, backend types.BackendServer, reqs interface{}, resCh chan types.ServerFetcherResponse) {
 	logger = logger.With(zap.Bool("multi_fetch", true))
 	request, ok := reqs.(*protov3.MultiFetchRequest)
@@ -253,19 +337,23 @@ func (bg *BroadcastGroup) doMultiFetch(ctx context.Context, logger *zap.Logger,
 			response := types.NewServerFetchResponse()
 			response.Server = backend.Name()
 
-			if err := bg.limiter.Enter(ctx, backend.Name()); err != nil {
+			if err := bg.enter(ctx, backend.Name()); err != nil {
 				logger.Debug("timeout waiting for a slot")
 				resCh <- response.NonFatalError(merry.Prepend(err, "timeout waiting for slot"))
 				return
 			}
 
 			logger.Debug("got slot")
-			defer bg.limiter.Leave(ctx, backend.Name())
+			defer bg.leave(ctx, backend.Name())
 
 			// uuid := util.GetUUID(ctx)
 			var err merry.Error
 			logger.Debug("sending request")
-			response.Response, response.Stats, err = backend.Fetch(ctx, req)
+			t0 := time.Now()
+			response.Response, response.Stats, err = bg.fetch(ctx, backend, req, resCh)
+			if timings := utilctx.GetBackendTimings(ctx); timings != nil {
+				timings.Add(backend.Name(), time.Since(t0))
+			}
 			response.AddError(err)
 			if response.Response != nil && response.Stats != nil {
 				logger.Debug("got response",
@@ -325,21 +413,25 @@ func (bg *BroadcastGroup) doSingleFetch(ctx context.Context, logger *zap.Logger,
 	response := types.NewServerFetchResponse()
 	response.Server = backend.Name()
 
-	if err := bg.limiter.Enter(ctx, backend.Name()); err != nil {
+	if err := bg.enter(ctx, backend.Name()); err != nil {
 		logger.Debug("timeout waiting for a slot")
 		resCh <- response.NonFatalError(merry.Prepend(err, "timeout waiting for slot"))
 		return
 	}
 
 	logger.Debug("got slot")
-	defer bg.limiter.Leave(ctx, backend.Name())
+	defer bg.leave(ctx, backend.Name())
 
 	// uuid := util.GetUUID(ctx)
 	var err merry.Error
 	for _, req := range requests {
 		logger.Debug("sending request")
 		r := types.NewServerFetchResponse()
-		r.Response, r.Stats, err = backend.Fetch(ctx, req)
+		t0 := time.Now()
+		r.Response, r.Stats, err = bg.fetch(ctx, backend, req, resCh)
+		if timings := utilctx.GetBackendTimings(ctx); timings != nil {
+			timings.Add(backend.Name(), time.Since(t0))
+		}
 		r.AddError(err)
 		if r.Stats != nil && r.Response != nil {
 			logger.Debug("got response",
@@ -580,7 +672,7 @@ func (bg *BroadcastGroup) doFind(ctx context.Context, logger *zap.Logger, backen
 	r := types.NewServerFindResponse()
 	r.Server = backend.Name()
 
-	if err := bg.limiter.Enter(ctx, backend.Name()); err != nil {
+	if err := bg.enter(ctx, backend.Name()); err != nil {
 		logger.Debug("timeout waiting for a slot")
 		r.AddError(merry.Prepend(err, "timeout waiting for slot"))
 		resCh <- r
@@ -588,7 +680,7 @@ func (bg *BroadcastGroup) doFind(ctx context.Context, logger *zap.Logger, backen
 	}
 
 	logger.Debug("got slot")
-	defer bg.limiter.Leave(ctx, backend.Name())
+	defer bg.leave(ctx, backend.Name())
 
 	var err merry.Error
 	r.Response, r.Stats, err = backend.Find(ctx, request)
@@ -684,13 +776,13 @@ func (bg *BroadcastGroup) doInfoRequest(ctx context.Context, logger *zap.Logger,
 		Server: backend.Name(),
 	}
 
-	if err := bg.limiter.Enter(ctx, backend.Name()); err != nil {
+	if err := bg.enter(ctx, backend.Name()); err != nil {
 		logger.Debug("timeout waiting for a slot")
 		r.AddError(merry.Prepend(err, "timeout waiting for slot"))
 		resCh <- r
 		return
 	}
-	defer bg.limiter.Leave(ctx, backend.Name())
+	defer bg.leave(ctx, backend.Name())
 
 	logger.Debug("got a slot")
 	var err merry.Error
@@ -741,17 +833,155 @@ func (bg *BroadcastGroup) Info(ctx context.Context, request *protov3.MultiMetric
 	return result.Response, result.Stats, err
 }
 
+func (bg *BroadcastGroup) doListRequest(ctx context.Context, logger *zap.Logger, backend types.BackendServer, _ interface{}, resCh chan types.ServerFetcherResponse) {
+	logger = logger.With(
+		zap.String("group_name", bg.groupName),
+		zap.String("backend_name", backend.Name()),
+	)
+	r := &types.ServerListResponse{
+		Server: backend.Name(),
+	}
+
+	if err := bg.enter(ctx, backend.Name()); err != nil {
+		logger.Debug("timeout waiting for a slot")
+		r.AddError(merry.Prepend(err, "timeout waiting for slot"))
+		resCh <- r
+		return
+	}
+	defer bg.leave(ctx, backend.Name())
+
+	logger.Debug("got a slot")
+	var err merry.Error
+	r.Response, r.Stats, err = backend.List(ctx)
+	if !unsupported(err) {
+		r.AddError(err)
+	}
+	resCh <- r
+}
+
+// unsupported is true for backends that can't do List or Stats, they are skipped instead of failing the whole request
+func unsupported(err merry.Error) bool {
+	return merry.Is(err, types.ErrNotSupportedByBackend) || merry.Is(err, types.ErrNotImplementedYet)
+}
+
+// broadcastError converts errors of the backends to the error of the group
+func (bg *BroadcastGroup) broadcastError(errs []merry.Error) merry.Error {
+	if errs == nil {
+		return nil
+	}
+	var err merry.Error
+	if bg.requireSuccessAll {
+		err = types.ErrFailedToFetch
+	} else {
+		err = types.ErrNonFatalErrors
+	}
+	for _, e := range errs {
+		err = err.WithCause(e)
+	}
+	return err
+}
+
+// List returns all metrics known to the backends, backends that don't support listing are skipped
 func (bg *BroadcastGroup) List(ctx context.Context) (*protov3.ListMetricsResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotImplementedYet
+	logger := bg.logger.With(zap.String("type", "list"))
+
+	ctxNew, cancel := context.WithTimeout(ctx, bg.timeout.Render)
+	defer cancel()
+	backends := bg.Children()
+	result := types.NewServerListResponse()
+	result.Server = bg.Name()
+	result.Stats.ZipperRequests = uint64(len(backends))
+
+	resultNew, responseCount := types.DoRequest(ctxNew, logger, backends, result, nil, bg.doListRequest)
+
+	result, ok := resultNew.Self().(*types.ServerListResponse)
+	if !ok {
+		logger.Fatal("unhandled error in List",
+			zap.Stack("stack"),
+			zap.String("got_type", fmt.Sprintf("%T", resultNew.Self())),
+			zap.String("expected_type", fmt.Sprintf("%T", result)),
+		)
+	}
+
+	logger.Debug("got some responses",
+		zap.Int("backends_count", len(backends)),
+		zap.Int("response_count", responseCount),
+		zap.Bool("have_errors", len(result.Err) != 0),
+	)
+
+	return result.Response, result.Stats, bg.broadcastError(result.Err)
+}
+
+func (bg *BroadcastGroup) doDetailsRequest(ctx context.Context, logger *zap.Logger, backend types.BackendServer, _ interface{}, resCh chan types.ServerFetcherResponse) {
+	logger = logger.With(
+		zap.String("group_name", bg.groupName),
+		zap.String("backend_name", backend.Name()),
+	)
+	r := &types.ServerDetailsResponse{
+		Server: backend.Name(),
+	}
+
+	if err := bg.enter(ctx, backend.Name()); err != nil {
+		logger.Debug("timeout waiting for a slot")
+		r.AddError(merry.Prepend(err, "timeout waiting for slot"))
+		resCh <- r
+		return
+	}
+	defer bg.leave(ctx, backend.Name())
+
+	logger.Debug("got a slot")
+	var err merry.Error
+	r.Response, r.Stats, err = backend.Stats(ctx)
+	if !unsupported(err) {
+		r.AddError(err)
+	}
+	resCh <- r
 }
+
+// Stats returns details (size, modification and access time) of all metrics known to the backends, backends that don't
+// support it are skipped
 func (bg *BroadcastGroup) Stats(ctx context.Context) (*protov3.MetricDetailsResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotImplementedYet
+	logger := bg.logger.With(zap.String("type", "details"))
+
+	ctxNew, cancel := context.WithTimeout(ctx, bg.timeout.Render)
+	defer cancel()
+	backends := bg.Children()
+	result := types.NewServerDetailsResponse()
+	result.Server = bg.Name()
+	result.Stats.ZipperRequests = uint64(len(backends))
+
+	resultNew, responseCount := types.DoRequest(ctxNew, logger, backends, result, nil, bg.doDetailsRequest)
+
+	result, ok := resultNew.Self().(*types.ServerDetailsResponse)
+	if !ok {
+		logger.Fatal("unhandled error in Stats",
+			zap.Stack("stack"),
+			zap.String("got_type", fmt.Sprintf("%T", resultNew.Self())),
+			zap.String("expected_type", fmt.Sprintf("%T", result)),
+		)
+	}
+
+	logger.Debug("got some responses",
+		zap.Int("backends_count", len(backends)),
+		zap.Int("response_count", responseCount),
+		zap.Bool("have_errors", len(result.Err) != 0),
+	)
+
+	return result.Response, result.Stats, bg.broadcastError(result.Err)
 }
 
+type tagQueryType int
+
+const (
+	tagQueryNames tagQueryType = iota
+	tagQueryValues
+	tagQuerySeries
+)
+
 type tagQuery struct {
-	Query  string
-	Limit  int64
-	IsName bool
+	Query string
+	Limit int64
+	Type  tagQueryType
 }
 
 // Info request handling
@@ -775,20 +1005,23 @@ func (bg *BroadcastGroup) doTagRequest(ctx context.Context, logger *zap.Logger,
 
 	logger.Debug("waiting for a slot")
 
-	if err := bg.limiter.Enter(ctx, backend.Name()); err != nil {
+	if err := bg.enter(ctx, backend.Name()); err != nil {
 		logger.Debug("timeout waiting for a slot")
 		r.AddError(merry.Prepend(err, "timeout waiting for slot"))
 		resCh <- r
 		return
 	}
-	defer bg.limiter.Leave(ctx, backend.Name())
+	defer bg.leave(ctx, backend.Name())
 
 	logger.Debug("got a slot")
 	var err merry.Error
-	if request.IsName {
+	switch request.Type {
+	case tagQueryNames:
 		r.Response, err = backend.TagNames(ctx, request.Query, request.Limit)
-	} else {
+	case tagQueryValues:
 		r.Response, err = backend.TagValues(ctx, request.Query, request.Limit)
+	case tagQuerySeries:
+		r.Response, err = backend.FindSeries(ctx, request.Query, request.Limit)
 	}
 
 	if err != nil {
@@ -801,18 +1034,21 @@ func (bg *BroadcastGroup) doTagRequest(ctx context.Context, logger *zap.Logger,
 	resCh <- r
 }
 
-func (bg *BroadcastGroup) tagEverything(ctx context.Context, isTagName bool, query string, limit int64) ([]string, merry.Error) {
+func (bg *BroadcastGroup) tagEverything(ctx context.Context, queryType tagQueryType, query string, limit int64) ([]string, merry.Error) {
 	logger := bg.logger.With(zap.String("query", query))
-	if isTagName {
+	switch queryType {
+	case tagQueryNames:
 		logger = logger.With(zap.String("type", "tagName"))
-	} else {
+	case tagQueryValues:
 		logger = logger.With(zap.String("type", "tagValues"))
+	case tagQuerySeries:
+		logger = logger.With(zap.String("type", "findSeries"))
 	}
 
 	request := tagQuery{
-		Query:  query,
-		Limit:  limit,
-		IsName: isTagName,
+		Query: query,
+		Limit: limit,
+		Type:  queryType,
 	}
 
 	ctxNew, cancel := context.WithTimeout(ctx, bg.timeout.Find)
@@ -835,7 +1071,7 @@ func (bg *BroadcastGroup) tagEverything(ctx context.Context, isTagName bool, que
 
 	if limit != -1 && int64(len(result.Response)) > limit {
 		sort.Strings(result.Response)
-		result.Response = result.Response[:limit-1]
+		result.Response = result.Response[:limit]
 	}
 
 	logger.Debug("got some responses",
@@ -856,11 +1092,15 @@ func (bg *BroadcastGroup) tagEverything(ctx context.Context, isTagName bool, que
 }
 
 func (bg *BroadcastGroup) TagNames(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
-	return bg.tagEverything(ctx, true, query, limit)
+	return bg.tagEverything(ctx, tagQueryNames, query, limit)
 }
 
 func (bg *BroadcastGroup) TagValues(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
-	return bg.tagEverything(ctx, false, query, limit)
+	return bg.tagEverything(ctx, tagQueryValues, query, limit)
+}
+
+func (bg *BroadcastGroup) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	return bg.tagEverything(ctx, tagQuerySeries, query, limit)
 }
 
 type tldResponse struct {
@@ -907,6 +1147,9 @@ GATHER:
 		case r := <-resCh:
 			answeredServers[r.server.Name()] = struct{}{}
 			responses++
+			if state := bg.states[r.server.Name()]; state != nil {
+				state.setProbeResult(r.err)
+			}
 			if r.err != nil {
 				errs = append(errs, r.err)
 				continue
@@ -917,9 +1160,15 @@ GATHER:
 			}
 
 		case <-ctx.Done():
+			noAnswers := types.NoAnswerBackends(backends, answeredServers)
 			logger.Warn("timeout waiting for more responses",
-				zap.Strings("no_answers_from", types.NoAnswerBackends(backends, answeredServers)),
+				zap.Strings("no_answers_from", noAnswers),
 			)
+			for _, s := range noAnswers {
+				if state := bg.states[s]; state != nil {
+					state.setProbeResult(types.ErrTimeoutExceeded)
+				}
+			}
 			errs = append(errs, types.ErrTimeoutExceeded)
 			break GATHER
 		}
@@ -930,9 +1179,19 @@ GATHER:
 		tlds = append(tlds, tld)
 	}
 
+	tldBackends := make(map[string][]string, len(cache))
 	for k, v := range cache {
 		bg.pathCache.Set(k, v)
+		names := make([]string, 0, len(v))
+		for _, b := range v {
+			names = append(names, b.Name())
+		}
+		sort.Strings(names)
+		tldBackends[k] = names
 	}
+	bg.tlds.Lock()
+	bg.tlds.m = tldBackends
+	bg.tlds.Unlock()
 
 	var err merry.Error
 	if errs != nil {
@@ -944,3 +1203,146 @@ GATHER:
 
 	return tlds, err
 }
+
+// TLDs returns TLDs found by the last ProbeTLDs and backends that have them
+func (bg *BroadcastGroup) TLDs() map[string][]string {
+	bg.tlds.RLock()
+	defer bg.tlds.RUnlock()
+	res := make(map[string][]string, len(bg.tlds.m))
+	for k, v := range bg.tlds.m {
+		res[k] = v
+	}
+	return res
+}
+
+// Status returns state of the group and its children: health according to the last ProbeTLDs, requests in flight
+// and requests waiting for a slot in limiter
+func (bg *BroadcastGroup) Status() types.BackendStatus {
+	res := types.BackendStatus{
+		Name:     bg.groupName,
+		Servers:  bg.servers,
+		Health:   types.HealthUnknown,
+		Children: make([]types.BackendStatus, 0, len(bg.backends)),
+	}
+	for _, backend := range bg.backends {
+		var child types.BackendStatus
+		if inspector, ok := backend.(types.BackendInspector); ok {
+			child = inspector.Status()
+		} else {
+			child = types.BackendStatus{
+				Name:    backend.Name(),
+				Servers: backend.Backends(),
+			}
+		}
+		child.Health = types.HealthUnknown
+		child.ConcurrencyLimit = bg.concurrencyLimit
+		if state := bg.states[backend.Name()]; state != nil {
+			child.InFlight = atomic.LoadInt64(&state.inFlight)
+			child.WaitingForSlot = atomic.LoadInt64(&state.waiting)
+			state.mu.Lock()
+			if !state.lastProbe.IsZero() {
+				lastProbe := state.lastProbe
+				child.LastProbe = &lastProbe
+				if state.lastProbeErr != nil {
+					child.Health = types.HealthFailed
+					child.LastProbeError = state.lastProbeErr.Error()
+				} else {
+					child.Health = types.HealthOK
+				}
+			}
+			state.mu.Unlock()
+		}
+		res.InFlight += child.InFlight
+		res.WaitingForSlot += child.WaitingForSlot
+		res.Children = append(res.Children, child)
+	}
+	return res
+}
+
+// SupportsHighPrecisionTimestamps always reports true: requests with millisecond timestamps are converted for the
+// children that don't support them
+func (bg *BroadcastGroup) SupportsHighPrecisionTimestamps(ctx context.Context) bool {
+	return true
+}
+
+// PushdownFunctions returns functions that can be evaluated for pathExpression on the backend side. It's only possible
+// if all series matching pathExpression are stored on a single backend, so it can see them all.
+func (bg *BroadcastGroup) PushdownFunctions(ctx context.Context, pathExpression string) map[string]struct{} {
+	backends := bg.filterServersByTLD([]string{pathExpression}, bg.Children())
+	if len(backends) > 1 {
+		backends = bg.backendsWithMatches(ctx, pathExpression, backends)
+	}
+	if len(backends) != 1 {
+		return nil
+	}
+
+	pd, ok := backends[0].(types.FunctionPushdown)
+	if !ok {
+		return nil
+	}
+	return pd.PushdownFunctions(ctx, pathExpression)
+}
+
+// backendsWithMatches returns backends that have series matching pathExpression. If any of backends failed to answer,
+// it's unknown where series are, so nil is returned. Answers are cached for the path cache expiration time, so a find
+// isn't done for each render of the same path expression.
+func (bg *BroadcastGroup) backendsWithMatches(ctx context.Context, pathExpression string, backends []types.BackendServer) []types.BackendServer {
+	if bg.pushdownPaths != nil {
+		if found, ok := bg.pushdownPaths.Get(pathExpression); ok {
+			return found
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bg.timeout.Find)
+	defer cancel()
+
+	type findResult struct {
+		backend types.BackendServer
+		found   bool
+		err     merry.Error
+	}
+	resCh := make(chan findResult, len(backends))
+	for _, backend := range backends {
+		go func(backend types.BackendServer) {
+			request := &protov3.MultiGlobRequest{Metrics: []string{pathExpression}}
+			res, _, err := backend.Find(ctx, request)
+			r := findResult{backend: backend}
+			if err != nil && !merry.Is(err, types.ErrNotFound) && merry.HTTPCode(err) != http.StatusNotFound {
+				r.err = err
+			}
+			if res != nil {
+				for _, m := range res.Metrics {
+					if len(m.Matches) > 0 {
+						r.found = true
+						break
+					}
+				}
+			}
+			resCh <- r
+		}(backend)
+	}
+
+	var found []types.BackendServer
+	failed := false
+	for range backends {
+		r := <-resCh
+		if r.err != nil {
+			bg.logger.Debug("failed to check where series are for pushdown",
+				zap.String("path_expression", pathExpression),
+				zap.String("backend_name", r.backend.Name()),
+				zap.Error(r.err),
+			)
+			failed = true
+		}
+		if r.found {
+			found = append(found, r.backend)
+		}
+	}
+	if failed {
+		return nil
+	}
+	if bg.pushdownPaths != nil {
+		bg.pushdownPaths.Set(pathExpression, found)
+	}
+	return found
+}
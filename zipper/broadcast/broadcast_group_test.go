@@ -141,6 +141,93 @@ func TestProbeTLDs(t *testing.T) {
 	}
 }
 
+func TestFindSeries(t *testing.T) {
+	servers := []types.BackendServer{
+		dummy.NewDummyClient("client1", []string{"backend1"}, 1),
+		dummy.NewDummyClient("client2", []string{"backend2"}, 1),
+	}
+	servers[0].(*dummy.DummyClient).SetFindSeriesResponse([]string{"a;env=prod", "b;env=prod"})
+	servers[1].(*dummy.DummyClient).SetFindSeriesResponse([]string{"b;env=prod", "c;env=prod"})
+
+	b, err := NewBroadcastGroup(logger, "find series", true, servers, 60, 500, 100, timeouts, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	res, err := b.FindSeries(context.Background(), "expr=env%3Dprod", -1)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	sort.Strings(res)
+	if !reflect.DeepEqual(res, []string{"a;env=prod", "b;env=prod", "c;env=prod"}) {
+		t.Errorf("got %v, expected merged and deduplicated series", res)
+	}
+
+	res, err = b.FindSeries(context.Background(), "expr=env%3Dprod", 2)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(res, []string{"a;env=prod", "b;env=prod"}) {
+		t.Errorf("got %v, expected 2 series", res)
+	}
+}
+
+func TestListAndDetails(t *testing.T) {
+	client1 := dummy.NewDummyClient("client1", []string{"backend1"}, 1)
+	client1.SetListResponse(dummy.ListResponse{Response: &protov3.ListMetricsResponse{Metrics: []string{"a.b", "a.c"}}})
+	client1.SetStatsResponse(dummy.StatsResponse{Response: &protov3.MetricDetailsResponse{
+		Metrics:    map[string]*protov3.MetricDetails{"a.b": {Size_: 10, ModTime: 100}},
+		FreeSpace:  1,
+		TotalSpace: 2,
+	}})
+	client2 := dummy.NewDummyClient("client2", []string{"backend2"}, 1)
+	client2.SetListResponse(dummy.ListResponse{Response: &protov3.ListMetricsResponse{Metrics: []string{"a.c", "d"}}})
+	client2.SetStatsResponse(dummy.StatsResponse{Response: &protov3.MetricDetailsResponse{
+		Metrics:    map[string]*protov3.MetricDetails{"a.b": {Size_: 20, ModTime: 200}, "d": {Size_: 30}},
+		FreeSpace:  1,
+		TotalSpace: 2,
+	}})
+	// doesn't support listing, shouldn't fail the request
+	client3 := dummy.NewDummyClient("client3", []string{"backend3"}, 1)
+
+	b, err := NewBroadcastGroup(logger, "list", true, []types.BackendServer{client1, client2, client3}, 60, 500, 100, timeouts, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	list, _, err := b.List(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	sort.Strings(list.Metrics)
+	if !reflect.DeepEqual(list.Metrics, []string{"a.b", "a.c", "d"}) {
+		t.Errorf("got %v, expected merged and deduplicated metrics", list.Metrics)
+	}
+
+	details, _, err := b.Stats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := &protov3.MetricDetailsResponse{
+		Metrics:    map[string]*protov3.MetricDetails{"a.b": {Size_: 20, ModTime: 200}, "d": {Size_: 30}},
+		FreeSpace:  2,
+		TotalSpace: 4,
+	}
+	if !reflect.DeepEqual(details, expected) {
+		t.Errorf("got %+v, expected %+v", details, expected)
+	}
+
+	client2.SetListResponse(dummy.ListResponse{Errors: types.ErrTimeoutExceeded})
+	list, _, err = b.List(context.Background())
+	if !merry.Is(err, types.ErrNonFatalErrors) {
+		t.Errorf("got error %v, expected %v", err, types.ErrNonFatalErrors)
+	}
+	sort.Strings(list.Metrics)
+	if !reflect.DeepEqual(list.Metrics, []string{"a.b", "a.c"}) {
+		t.Errorf("got %v, expected partial result", list.Metrics)
+	}
+}
+
 type testCaseFetch struct {
 	name           string
 	servers        []types.BackendServer
@@ -1170,3 +1257,154 @@ func TestFetchRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestPushdownFunctions(t *testing.T) {
+	found := &protov3.MultiGlobResponse{
+		Metrics: []protov3.GlobResponse{{Name: "a.*", Matches: []protov3.GlobMatch{{Path: "a.b", IsLeaf: true}}}},
+	}
+	notFound := &protov3.MultiGlobResponse{Metrics: []protov3.GlobResponse{{Name: "a.*"}}}
+
+	client1 := dummy.NewDummyClient("client1", []string{"backend1"}, 0)
+	client1.SetPushdownFunctions([]string{"sumSeries"})
+	client2 := dummy.NewDummyClient("client2", []string{"backend2"}, 0)
+	client2.SetPushdownFunctions([]string{"sumSeries"})
+
+	for _, client := range []*dummy.DummyClient{client1, client2} {
+		client.AddFindResponse(&protov3.MultiGlobRequest{Metrics: []string{"b.*"}}, found, nil, nil)
+	}
+	client1.AddFindResponse(&protov3.MultiGlobRequest{Metrics: []string{"a.*"}}, found, nil, nil)
+	client2.AddFindResponse(&protov3.MultiGlobRequest{Metrics: []string{"a.*"}}, notFound, nil, types.ErrNotFound)
+	client1.AddFindResponse(&protov3.MultiGlobRequest{Metrics: []string{"c.*"}}, found, nil, nil)
+	client2.AddFindResponse(&protov3.MultiGlobRequest{Metrics: []string{"c.*"}}, nil, nil, types.ErrTimeoutExceeded)
+
+	b, err := NewBroadcastGroup(logger, "pushdown", true, []types.BackendServer{client1, client2}, 60, 500, 100, timeouts, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	tests := []struct {
+		pathExpression string
+		want           map[string]struct{}
+	}{
+		{pathExpression: "a.*", want: map[string]struct{}{"sumSeries": {}}},
+		{pathExpression: "b.*"},
+		{pathExpression: "c.*"},
+		{pathExpression: "d.*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pathExpression, func(t *testing.T) {
+			got := b.PushdownFunctions(context.Background(), tt.pathExpression)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, expected %v", got, tt.want)
+			}
+		})
+	}
+
+	// where series are is cached, so backends are not asked again for each render
+	client2.AddFindResponse(&protov3.MultiGlobRequest{Metrics: []string{"a.*"}}, found, nil, nil)
+	got := b.PushdownFunctions(context.Background(), "a.*")
+	if !reflect.DeepEqual(got, map[string]struct{}{"sumSeries": {}}) {
+		t.Errorf("got %v, expected cached backends to be used", got)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	client1 := dummy.NewDummyClient("client1", []string{"backend1"}, 1)
+	client1.SetTLDResponse(dummy.ProbeResponse{Response: []string{"a", "b"}})
+	client2 := dummy.NewDummyClient("client2", []string{"backend2"}, 1)
+	client2.SetTLDResponse(dummy.ProbeResponse{Errors: types.ErrTimeoutExceeded})
+
+	b, err := NewBroadcastGroup(logger, "root", true, []types.BackendServer{client1, client2}, 60, 2, 100, timeouts, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	status := b.Status()
+	for _, child := range status.Children {
+		if child.Health != types.HealthUnknown {
+			t.Errorf("%s: health before probe is %q, expected %q", child.Name, child.Health, types.HealthUnknown)
+		}
+	}
+
+	_, _ = b.ProbeTLDs(context.Background())
+	expectedTLDs := map[string][]string{"a": {"client1"}, "b": {"client1"}}
+	if tlds := b.TLDs(); !reflect.DeepEqual(tlds, expectedTLDs) {
+		t.Errorf("got tlds %v, expected %v", tlds, expectedTLDs)
+	}
+
+	ctx := context.Background()
+	if err := b.enter(ctx, "client1"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	status = b.Status()
+	b.leave(ctx, "client1")
+
+	if status.InFlight != 1 || len(status.Children) != 2 {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	expected := []struct {
+		health   string
+		inFlight int64
+	}{
+		{health: types.HealthOK, inFlight: 1},
+		{health: types.HealthFailed, inFlight: 0},
+	}
+	for i, child := range status.Children {
+		if child.Health != expected[i].health || child.InFlight != expected[i].inFlight || child.ConcurrencyLimit != 2 {
+			t.Errorf("%s: unexpected status %+v", child.Name, child)
+		}
+	}
+	if status := b.Status(); status.InFlight != 0 {
+		t.Errorf("in flight after leave is %v", status.InFlight)
+	}
+}
+
+// streamingClient passes series of the response one by one and then fails
+type streamingClient struct {
+	*dummy.DummyClient
+	response *protov3.MultiFetchResponse
+}
+
+func (c *streamingClient) FetchStream(ctx context.Context, request *protov3.MultiFetchRequest, fn func(response *protov3.MultiFetchResponse) merry.Error) (*types.Stats, merry.Error) {
+	for i := range c.response.Metrics {
+		if err := fn(&protov3.MultiFetchResponse{Metrics: c.response.Metrics[i : i+1]}); err != nil {
+			return nil, err
+		}
+	}
+	return &types.Stats{RenderRequests: 1}, types.ErrFailedToFetch.WithMessage("connection reset")
+}
+
+func TestFetchStream(t *testing.T) {
+	request := &protov3.MultiFetchRequest{Metrics: []protov3.FetchRequest{{Name: "a.*", PathExpression: "a.*", StartTime: 10, StopTime: 30}}}
+	client1 := &streamingClient{
+		DummyClient: dummy.NewDummyClient("client1", []string{"backend1"}, 0),
+		response: &protov3.MultiFetchResponse{Metrics: []protov3.FetchResponse{
+			{Name: "a.b", PathExpression: "a.*", StartTime: 10, StopTime: 30, StepTime: 10, RequestStartTime: 10, RequestStopTime: 30, Values: []float64{1, math.NaN()}},
+			{Name: "a.c", PathExpression: "a.*", StartTime: 10, StopTime: 30, StepTime: 10, RequestStartTime: 10, RequestStopTime: 30, Values: []float64{3, 4}},
+		}},
+	}
+	client2 := dummy.NewDummyClient("client2", []string{"backend2"}, 0)
+	client2.AddFetchResponse(request, &protov3.MultiFetchResponse{Metrics: []protov3.FetchResponse{
+		{Name: "a.b", PathExpression: "a.*", StartTime: 10, StopTime: 30, StepTime: 10, RequestStartTime: 10, RequestStopTime: 30, Values: []float64{math.NaN(), 2}},
+	}}, &types.Stats{}, nil)
+
+	b, err := NewBroadcastGroup(logger, "stream", true, []types.BackendServer{client1, client2}, 60, 500, 100, timeouts, false, false)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	res, _, err := b.Fetch(context.Background(), request)
+	if !merry.Is(err, types.ErrNonFatalErrors) {
+		t.Errorf("expected non-fatal error, got %v", err)
+	}
+	if res == nil || len(res.Metrics) != 2 {
+		t.Fatalf("expected series streamed before the error to be merged, got %v", res)
+	}
+	sort.Slice(res.Metrics, func(i, j int) bool { return res.Metrics[i].Name < res.Metrics[j].Name })
+	if !reflect.DeepEqual(res.Metrics[0].Values, []float64{1, 2}) {
+		t.Errorf("a.b: got %v, expected %v", res.Metrics[0].Values, []float64{1, 2})
+	}
+	if !reflect.DeepEqual(res.Metrics[1].Values, []float64{3, 4}) {
+		t.Errorf("a.c: got %v, expected %v", res.Metrics[1].Values, []float64{3, 4})
+	}
+}
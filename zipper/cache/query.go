@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/dgryski/go-expirecache"
 )
@@ -104,3 +105,13 @@ func (q *QueryCache) GetQueryItem(k string) *QueryItem {
 
 	return item
 }
+
+// ApproximateCleaner removes expired items every d, see expirecache.Cache.ApproximateCleaner
+func (q *QueryCache) ApproximateCleaner(d time.Duration) {
+	q.ec.ApproximateCleaner(d)
+}
+
+// Expire expires item for k, so the next GetQueryItem returns a new one. Those who already got the item still can use it
+func (q *QueryCache) Expire(k string) {
+	q.ec.Set(k, nil, 0, -1)
+}
@@ -52,14 +52,18 @@ type DummyClient struct {
 	backends             []string
 	maxMetricsPerRequest int
 
-	fetchResponses    map[string]FetchResponse
-	findResponses     map[string]FindResponse
-	infoResponses     map[string]InfoResponse
-	statsResponses    map[string]StatsResponse
-	tagNameResponse   []string
-	tagValuesResponse []string
-	probeResponses    ProbeResponse
-	alwaysTimeout     time.Duration
+	fetchResponses     map[string]FetchResponse
+	findResponses      map[string]FindResponse
+	infoResponses      map[string]InfoResponse
+	statsResponses     map[string]StatsResponse
+	listResponse       *ListResponse
+	detailsResponse    *StatsResponse
+	tagNameResponse    []string
+	tagValuesResponse  []string
+	findSeriesResponse []string
+	probeResponses     ProbeResponse
+	pushdownFunctions  map[string]struct{}
+	alwaysTimeout      time.Duration
 }
 
 func (d *DummyClient) Children() []types.BackendServer {
@@ -193,12 +197,26 @@ func (c *DummyClient) Info(ctx context.Context, request *protov3.MultiMetricsInf
 	return nil, nil, types.ErrNotImplementedYet
 }
 
+func (c *DummyClient) SetListResponse(response ListResponse) {
+	c.listResponse = &response
+}
+
 func (c *DummyClient) List(ctx context.Context) (*protov3.ListMetricsResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotImplementedYet
+	if c.listResponse == nil {
+		return nil, nil, types.ErrNotImplementedYet
+	}
+	return c.listResponse.Response, c.listResponse.Stats, c.listResponse.Errors
+}
+
+func (c *DummyClient) SetStatsResponse(response StatsResponse) {
+	c.detailsResponse = &response
 }
 
 func (c *DummyClient) Stats(ctx context.Context) (*protov3.MetricDetailsResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotImplementedYet
+	if c.detailsResponse == nil {
+		return nil, nil, types.ErrNotImplementedYet
+	}
+	return c.detailsResponse.Response, c.detailsResponse.Stats, c.detailsResponse.Errors
 }
 
 func (c *DummyClient) SetTLDResponse(response ProbeResponse) {
@@ -221,6 +239,25 @@ func (c *DummyClient) TagValues(ctx context.Context, query string, limit int64)
 	return c.tagNameResponse, nil
 }
 
+func (c *DummyClient) SetFindSeriesResponse(response []string) {
+	c.findSeriesResponse = response
+}
+
+func (c *DummyClient) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	return c.findSeriesResponse, nil
+}
+
+func (c *DummyClient) SetPushdownFunctions(functions []string) {
+	c.pushdownFunctions = make(map[string]struct{}, len(functions))
+	for _, f := range functions {
+		c.pushdownFunctions[f] = struct{}{}
+	}
+}
+
+func (c *DummyClient) PushdownFunctions(ctx context.Context, pathExpression string) map[string]struct{} {
+	return c.pushdownFunctions
+}
+
 func (c *DummyClient) ProbeTLDs(ctx context.Context) ([]string, merry.Error) {
 	return c.probeResponses.Response, c.probeResponses.Errors
 }
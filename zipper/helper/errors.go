@@ -123,20 +123,11 @@ func MergeHttpErrorMap(errorsMap map[string]merry.Error) (returnCode int, errMap
 	returnCode = http.StatusNotFound
 	errMap = make(map[string]string)
 	for key, err := range errorsMap {
-		c := merry.RootCause(err)
-		if c == nil {
-			c = err
-		}
-
-		code := merry.HTTPCode(err)
+		code, msg := HttpErrorCodeMessage(err)
 		if code == http.StatusNotFound {
 			continue
-		} else if code == http.StatusInternalServerError && merry.Is(c, parser.ErrInvalidArg) {
-			// check for invalid args, see applyByNode rewrite function
-			code = http.StatusBadRequest
 		}
 
-		msg := merryError(c)
 		errMap[key] = msg
 		returnCode = recalcCode(returnCode, code)
 	}
@@ -144,6 +135,22 @@ func MergeHttpErrorMap(errorsMap map[string]merry.Error) (returnCode int, errMap
 	return
 }
 
+// HttpErrorCodeMessage returns http code and message for the error of a single target, as MergeHttpErrorMap does
+func HttpErrorCodeMessage(err merry.Error) (int, string) {
+	c := merry.RootCause(err)
+	if c == nil {
+		c = err
+	}
+
+	code := merry.HTTPCode(err)
+	if code == http.StatusInternalServerError && merry.Is(c, parser.ErrInvalidArg) {
+		// check for invalid args, see applyByNode rewrite function
+		code = http.StatusBadRequest
+	}
+
+	return code, merryError(c)
+}
+
 func HttpErrorByCode(err merry.Error) merry.Error {
 	var returnErr merry.Error
 	if err == nil {
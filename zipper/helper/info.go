@@ -0,0 +1,201 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ansel1/merry"
+	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+var retentionUnits = map[string]int64{
+	"s": 1,
+	"m": 60,
+	"h": 60 * 60,
+	"d": 24 * 60 * 60,
+	"w": 7 * 24 * 60 * 60,
+	"y": 365 * 24 * 60 * 60,
+}
+
+// ParseRetention parses retention in prometheus duration format (e.g. "15d", "1y2w" or "720h") and returns it in seconds.
+// Number without unit is multiplied by defaultUnit seconds.
+func ParseRetention(s string, defaultUnit int64) (int64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty retention")
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n * defaultUnit, nil
+	}
+
+	var res int64
+	for s != "" {
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return 0, fmt.Errorf("invalid retention %q", s)
+		}
+		n, err := strconv.ParseInt(s[:i], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		s = s[i:]
+
+		j := 0
+		for j < len(s) && (s[j] < '0' || s[j] > '9') {
+			j++
+		}
+		unit, ok := retentionUnits[s[:j]]
+		if !ok {
+			return 0, fmt.Errorf("unknown retention unit %q", s[:j])
+		}
+		res += n * unit
+		s = s[j:]
+	}
+	return res, nil
+}
+
+// ParseInfoOptions parses backendOptions used to describe metrics for backends that doesn't support /info natively
+func ParseInfoOptions(logger *zap.Logger, backendOptions map[string]interface{}, retentionOption, defaultConsolidationFunc string) (retention int64, consolidationFunc string) {
+	if retentionI, ok := backendOptions[retentionOption]; ok {
+		retentionStr, ok := retentionI.(string)
+		if !ok {
+			logger.Fatal("failed to parse "+retentionOption,
+				zap.String("type_parsed", fmt.Sprintf("%T", retentionI)),
+				zap.String("type_expected", "string"),
+			)
+		}
+		var err error
+		retention, err = ParseRetention(retentionStr, 1)
+		if err != nil {
+			logger.Fatal("failed to parse option",
+				zap.String("option_name", retentionOption),
+				zap.String("option_value", retentionStr),
+				zap.Error(err),
+			)
+		}
+	}
+
+	consolidationFunc = defaultConsolidationFunc
+	if consolidationFuncI, ok := backendOptions["consolidation_func"]; ok {
+		consolidationFunc, ok = consolidationFuncI.(string)
+		if !ok {
+			logger.Fatal("failed to parse consolidation_func",
+				zap.String("type_parsed", fmt.Sprintf("%T", consolidationFuncI)),
+				zap.String("type_expected", "string"),
+			)
+		}
+	}
+
+	return retention, consolidationFunc
+}
+
+// SyntheticInfo describes metrics stored with a single fixed step. Zero retention means that it's unknown.
+func SyntheticInfo(names []string, step, retention int64, consolidationFunc string) protov3.MultiMetricsInfoResponse {
+	res := protov3.MultiMetricsInfoResponse{
+		Metrics: make([]protov3.MetricsInfoResponse, 0, len(names)),
+	}
+	var points int64
+	if step > 0 {
+		points = retention / step
+	}
+	for _, name := range names {
+		res.Metrics = append(res.Metrics, protov3.MetricsInfoResponse{
+			Name:              name,
+			ConsolidationFunc: consolidationFunc,
+			MaxRetention:      retention,
+			Retentions: []protov3.Retention{{
+				SecondsPerPoint: step,
+				NumberOfPoints:  points,
+			}},
+		})
+	}
+	return res
+}
+
+// FoundNames returns names that were found as metrics, so that info isn't made up for metrics that don't exist
+func FoundNames(names []string, found *protov3.MultiGlobResponse) []string {
+	leaves := make(map[string]struct{})
+	if found != nil {
+		for _, m := range found.Metrics {
+			for _, match := range m.Matches {
+				if match.IsLeaf {
+					leaves[match.Path] = struct{}{}
+				}
+			}
+		}
+	}
+	res := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, ok := leaves[name]; ok {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// RetentionProber caches retention reported by backend, so /info requests don't query backend's status endpoints every
+// time. Concurrent requests share a single probe.
+type RetentionProber struct {
+	Interval time.Duration
+	// FailureInterval is how long probe error is cached, so unavailable backend isn't probed on every request
+	FailureInterval time.Duration
+	Probe           func(ctx context.Context) (int64, merry.Error)
+
+	probes singleflight.Group
+
+	mu        sync.Mutex
+	retention int64
+	probedAt  time.Time
+	err       merry.Error
+	failedAt  time.Time
+}
+
+type retentionProbe struct {
+	retention int64
+	err       merry.Error
+}
+
+// Retention returns cached retention, probing backend if cache is empty or stale. Last known value is returned on errors.
+func (p *RetentionProber) Retention(ctx context.Context) (int64, merry.Error) {
+	if res, ok := p.cached(); ok {
+		return res.retention, res.err
+	}
+
+	v, _, _ := p.probes.Do("", func() (interface{}, error) {
+		retention, err := p.Probe(ctx)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if err != nil {
+			p.err = err
+			p.failedAt = time.Now()
+			return retentionProbe{retention: p.retention, err: err}, nil
+		}
+		p.retention = retention
+		p.probedAt = time.Now()
+		p.err = nil
+		return retentionProbe{retention: retention}, nil
+	})
+	res := v.(retentionProbe)
+	return res.retention, res.err
+}
+
+func (p *RetentionProber) cached() (retentionProbe, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil && time.Since(p.failedAt) < p.FailureInterval {
+		return retentionProbe{retention: p.retention, err: p.err}, true
+	}
+	if !p.probedAt.IsZero() && time.Since(p.probedAt) < p.Interval {
+		return retentionProbe{retention: p.retention}, true
+	}
+	return retentionProbe{}, false
+}
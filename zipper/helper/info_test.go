@@ -0,0 +1,127 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ansel1/merry"
+	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetention(t *testing.T) {
+	tests := []struct {
+		input       string
+		defaultUnit int64
+		want        int64
+		wantErr     bool
+	}{
+		{input: "15d", defaultUnit: 1, want: 15 * 86400},
+		{input: "1y2w", defaultUnit: 1, want: 365*86400 + 14*86400},
+		{input: "720h", defaultUnit: 1, want: 720 * 3600},
+		{input: "0s", defaultUnit: 1, want: 0},
+		{input: "3", defaultUnit: 31 * 86400, want: 93 * 86400},
+		{input: "", wantErr: true},
+		{input: "d", wantErr: true},
+		{input: "15", defaultUnit: 1, want: 15},
+		{input: "15x", wantErr: true},
+		{input: "1h30", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRetention(tt.input, tt.defaultUnit)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSyntheticInfo(t *testing.T) {
+	got := SyntheticInfo([]string{"a.b"}, 60, 86400, "average")
+	want := protov3.MultiMetricsInfoResponse{
+		Metrics: []protov3.MetricsInfoResponse{{
+			Name:              "a.b",
+			ConsolidationFunc: "average",
+			MaxRetention:      86400,
+			Retentions:        []protov3.Retention{{SecondsPerPoint: 60, NumberOfPoints: 1440}},
+		}},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestRetentionProber(t *testing.T) {
+	probes := 0
+	var probeErr merry.Error
+	p := &RetentionProber{
+		Interval: time.Hour,
+		Probe: func(ctx context.Context) (int64, merry.Error) {
+			probes++
+			return 86400, probeErr
+		},
+	}
+
+	probeErr = merry.New("connection refused")
+	_, err := p.Retention(context.Background())
+	assert.Error(t, err)
+
+	probeErr = nil
+	for i := 0; i < 3; i++ {
+		retention, err := p.Retention(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(86400), retention, fmt.Sprintf("attempt %d", i))
+	}
+	assert.Equal(t, 2, probes, "successful probe result should be cached")
+}
+
+func TestRetentionProberFailure(t *testing.T) {
+	var probes int32
+	release := make(chan struct{})
+	p := &RetentionProber{
+		Interval:        time.Hour,
+		FailureInterval: time.Hour,
+		Probe: func(ctx context.Context) (int64, merry.Error) {
+			atomic.AddInt32(&probes, 1)
+			<-release
+			return 0, merry.New("connection refused")
+		},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := p.Retention(context.Background())
+			assert.Error(t, err)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	_, err := p.Retention(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&probes), "concurrent probes should be shared and failure should be cached")
+}
+
+func TestFoundNames(t *testing.T) {
+	found := &protov3.MultiGlobResponse{
+		Metrics: []protov3.GlobResponse{{
+			Name: "a.b*",
+			Matches: []protov3.GlobMatch{
+				{Path: "a.b", IsLeaf: true},
+				{Path: "a.bc", IsLeaf: false},
+			},
+		}},
+	}
+	assert.Equal(t, []string{"a.b"}, FoundNames([]string{"a.b", "a.bc", "a.x"}, found))
+	assert.Equal(t, []string{}, FoundNames([]string{"a.b"}, nil))
+}
@@ -130,9 +130,31 @@ func (c *HttpQuery) doRequest(ctx context.Context, logger *zap.Logger, server, u
 		zap.String("function", "HttpQuery.doRequest"),
 	)
 
+	res := &ServerResponse{Server: server}
+	err := c.doRequestWith(ctx, logger, server, uri, r, c.encoding, func(body io.Reader) merry.Error {
+		var err error
+		res.Response, err = io.ReadAll(body)
+		if err != nil {
+			logger.Debug("error reading body",
+				zap.Error(err),
+			)
+			return merry.Here(err).WithValue("server", server)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// doRequestWith sends request to the server and passes body of successful response to handle while slot in limiter
+// is still held. Handler is not called if server responded with 404.
+func (c *HttpQuery) doRequestWith(ctx context.Context, logger *zap.Logger, server, uri string, r types.Request, accept string, handle func(body io.Reader) merry.Error) merry.Error {
 	u, err := url.Parse(server + uri)
 	if err != nil {
-		return nil, merry.Here(err).WithValue("server", server)
+		return merry.Here(err).WithValue("server", server)
 	}
 
 	var reader io.Reader
@@ -140,7 +162,7 @@ func (c *HttpQuery) doRequest(ctx context.Context, logger *zap.Logger, server, u
 	if r != nil {
 		body, err = r.Marshal()
 		if err != nil {
-			return nil, merry.Here(err).WithValue("server", server)
+			return merry.Here(err).WithValue("server", server)
 		}
 		if body != nil {
 			reader = bytes.NewReader(body)
@@ -155,10 +177,10 @@ func (c *HttpQuery) doRequest(ctx context.Context, logger *zap.Logger, server, u
 	// TODO: change to NewRequestWithContext
 	req, err := http.NewRequest("GET", u.String(), reader)
 	if err != nil {
-		return nil, merry.Here(err).WithValue("server", server)
+		return merry.Here(err).WithValue("server", server)
 	}
 
-	req.Header.Set("Accept", c.encoding)
+	req.Header.Set("Accept", accept)
 	req = util.MarshalPassHeaders(ctx, util.MarshalCtx(ctx, util.MarshalCtx(ctx, req, util.HeaderUUIDZipper), util.HeaderUUIDAPI))
 
 	logger.Debug("trying to get slot",
@@ -167,7 +189,7 @@ func (c *HttpQuery) doRequest(ctx context.Context, logger *zap.Logger, server, u
 	err = c.limiter.Enter(ctx, server)
 	if err != nil {
 		logger.Debug("timeout waiting for a slot")
-		return nil, merry.Here(err).WithValue("server", server)
+		return merry.Here(err).WithValue("server", server)
 	}
 
 	defer c.limiter.Leave(ctx, server)
@@ -185,7 +207,7 @@ func (c *HttpQuery) doRequest(ctx context.Context, logger *zap.Logger, server, u
 			zap.Error(err),
 		)
 
-		return nil, requestError(err, server)
+		return requestError(err, server)
 
 	}
 	defer func() {
@@ -194,22 +216,15 @@ func (c *HttpQuery) doRequest(ctx context.Context, logger *zap.Logger, server, u
 
 	// we don't need to process any further if the response is empty.
 	if resp.StatusCode == http.StatusNotFound {
-		return &ServerResponse{Server: server}, nil
-	}
-
-	body, err = io.ReadAll(resp.Body)
-	if err != nil {
-		logger.Debug("error reading body",
-			zap.Error(err),
-		)
-		return nil, merry.Here(err).WithValue("server", server)
+		return nil
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, types.ErrFailedToFetch.WithValue("server", server).WithMessage(string(body)).WithHTTPCode(resp.StatusCode)
+		body, _ = io.ReadAll(resp.Body)
+		return types.ErrFailedToFetch.WithValue("server", server).WithMessage(string(body)).WithHTTPCode(resp.StatusCode)
 	}
 
-	return &ServerResponse{Server: server, Response: body}, nil
+	return handle(resp.Body)
 }
 
 func (c *HttpQuery) DoQuery(ctx context.Context, logger *zap.Logger, uri string, r types.Request) (resp *ServerResponse, err merry.Error) {
@@ -242,6 +257,38 @@ func (c *HttpQuery) DoQuery(ctx context.Context, logger *zap.Logger, uri string,
 	return nil, types.ErrMaxTriesExceeded.WithCause(e).WithHTTPCode(code)
 }
 
+// DoQueryStream works like DoQuery, but passes response body to handle as it arrives instead of reading it to memory
+// first. As request could be retried on other server, handle must reset everything it got from the previous attempt.
+func (c *HttpQuery) DoQueryStream(ctx context.Context, logger *zap.Logger, uri string, r types.Request, accept string, handle func(server string, body io.Reader) merry.Error) merry.Error {
+	maxTries := c.maxTries
+	if len(c.servers) > maxTries {
+		maxTries = len(c.servers)
+	}
+
+	e := types.ErrFailedToFetch.WithValue("uri", uri)
+	code := http.StatusInternalServerError
+	for try := 0; try < maxTries; try++ {
+		server := c.pickServer(logger)
+		err := c.doRequestWith(ctx, logger, server, uri, r, accept, func(body io.Reader) merry.Error {
+			return handle(server, body)
+		})
+		if err != nil {
+			logger.Debug("have errors",
+				zap.String("error", err.Error()),
+				zap.String("server", server),
+			)
+
+			e = e.WithCause(err).WithHTTPCode(merry.HTTPCode(err))
+			code = merry.HTTPCode(err)
+			continue
+		}
+
+		return nil
+	}
+
+	return types.ErrMaxTriesExceeded.WithCause(e).WithHTTPCode(code)
+}
+
 func (c *HttpQuery) DoQueryToAll(ctx context.Context, logger *zap.Logger, uri string, r types.Request) (resp []*ServerResponse, err merry.Error) {
 	maxTries := c.maxTries
 	if len(c.servers) > maxTries {
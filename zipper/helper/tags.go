@@ -0,0 +1,37 @@
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/ansel1/merry"
+	"go.uber.org/zap"
+)
+
+// FindSeries queries graphite-compatible /tags/findSeries, result is truncated to limit if it's positive
+func (c *HttpQuery) FindSeries(ctx context.Context, logger *zap.Logger, query string, limit int64) ([]string, merry.Error) {
+	rewrite, _ := url.Parse("http://127.0.0.1/tags/findSeries")
+	rewrite.RawQuery = query
+
+	var r []string
+	res, e := c.DoQuery(ctx, logger, rewrite.RequestURI(), nil)
+	if e != nil {
+		return r, e
+	}
+
+	err := json.Unmarshal(res.Response, &r)
+	if err != nil {
+		return r, merry.Wrap(err)
+	}
+
+	if limit > 0 && int64(len(r)) > limit {
+		r = r[:limit]
+	}
+
+	logger.Debug("got client response",
+		zap.Strings("response", r),
+	)
+
+	return r, nil
+}
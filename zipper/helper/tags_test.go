@@ -0,0 +1,34 @@
+package helper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/go-graphite/carbonapi/limiter"
+)
+
+func TestHttpQueryFindSeries(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		_, _ = io.WriteString(w, `["a;env=prod","b;env=prod","c;env=prod"]`)
+	}))
+	defer srv.Close()
+
+	q := NewHttpQuery("test", []string{srv.URL}, 1, limiter.NewServerLimiter([]string{srv.URL}, 0), srv.Client(), "")
+
+	res, err := q.FindSeries(context.Background(), zap.NewNop(), "expr=env%3Dprod", -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a;env=prod", "b;env=prod", "c;env=prod"}, res)
+	assert.Equal(t, "expr=env%3Dprod", gotQuery)
+
+	res, err = q.FindSeries(context.Background(), zap.NewNop(), "expr=env%3Dprod", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a;env=prod", "b;env=prod"}, res)
+}
@@ -5,5 +5,7 @@ const (
 	ContentTypeProtobuf      = "application/x-protobuf"
 	ContentTypePickle        = "application/pickle"
 	ContentTypeCarbonAPIv3PB = "application/x-carbonapi-v3-pb"
-	ContentTypeCarbonAPIv2PB = "application/x-protobuf"
+	// ContentTypeCarbonAPIv3PBStream is a stream of length-delimited FetchResponse messages
+	ContentTypeCarbonAPIv3PBStream = "application/x-carbonapi-v3-pb-stream"
+	ContentTypeCarbonAPIv2PB       = "application/x-protobuf"
 )
@@ -19,5 +19,23 @@ type CarbonZipper interface {
 	Render(ctx context.Context, request pb.MultiFetchRequest) ([]*types.MetricData, *zipperTypes.Stats, merry.Error)
 	TagNames(ctx context.Context, query string, limit int64) ([]string, merry.Error)
 	TagValues(ctx context.Context, query string, limit int64) ([]string, merry.Error)
+	FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error)
 	ScaleToCommonStep() bool
 }
+
+// FunctionPushdown is an optional interface for CarbonZipper that can push functions down to the backends,
+// see zipperTypes.FunctionPushdown
+type FunctionPushdown = zipperTypes.FunctionPushdown
+
+// Admin is an optional interface for CarbonZipper that allows to inspect backends and their TLD routing at runtime
+type Admin interface {
+	Status() zipperTypes.BackendStatus
+	TLDs() map[string][]string
+	RefreshTLDs(ctx context.Context) (map[string][]string, merry.Error)
+}
+
+// Lister is an optional interface for CarbonZipper that can list all metrics of the backends and get their details
+type Lister interface {
+	List(ctx context.Context) (*pb.ListMetricsResponse, *zipperTypes.Stats, merry.Error)
+	Details(ctx context.Context) (*pb.MetricDetailsResponse, *zipperTypes.Stats, merry.Error)
+}
@@ -227,6 +227,10 @@ func (bg *AutoGroup) TagValues(ctx context.Context, tagName, prefix string, expr
 	return nil, merry.New("auto group doesn't support tag values")
 }
 
+func (bg *AutoGroup) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	return nil, merry.New("auto group doesn't support find series")
+}
+
 func (c *AutoGroup) ProbeTLDs(ctx context.Context) ([]string, merry.Error) {
 	return nil, merry.New("auto group doesn't support probing")
 }
@@ -332,11 +332,32 @@ func (c *GraphiteGroup) Info(ctx context.Context, request *protov3.MultiMetricsI
 	return &r, stats, nil
 }
 
+// List fetches graphite-web's /metrics/index.json
 func (c *GraphiteGroup) List(ctx context.Context) (*protov3.ListMetricsResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotImplementedYet
+	logger := c.logger.With(zap.String("type", "list"))
+	stats := &types.Stats{}
+	rewrite, _ := url.Parse("http://127.0.0.1/metrics/index.json")
+
+	res, err := c.httpQuery.DoQuery(ctx, logger, rewrite.RequestURI(), nil)
+	if err != nil {
+		if merry.Is(err, types.ErrTimeoutExceeded) {
+			stats.Timeouts++
+		}
+		return nil, stats, err
+	}
+
+	var r protov3.ListMetricsResponse
+	if err2 := json.Unmarshal(res.Response, &r.Metrics); err2 != nil {
+		stats.FailedServers = []string{res.Server}
+		return nil, stats, types.ErrUnmarshalFailed.WithCause(err2)
+	}
+	stats.Servers = []string{res.Server}
+
+	return &r, stats, nil
 }
+
 func (c *GraphiteGroup) Stats(ctx context.Context) (*protov3.MetricDetailsResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotImplementedYet
+	return nil, nil, types.ErrNotSupportedByBackend
 }
 
 func (c *GraphiteGroup) doTagQuery(ctx context.Context, isTagName bool, query string, limit int64) ([]string, merry.Error) {
@@ -379,6 +400,11 @@ func (c *GraphiteGroup) TagValues(ctx context.Context, query string, limit int64
 	return c.doTagQuery(ctx, false, query, limit)
 }
 
+func (c *GraphiteGroup) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	logger := c.logger.With(zap.String("type", "findSeries"))
+	return c.httpQuery.FindSeries(ctx, logger, query, limit)
+}
+
 func (c *GraphiteGroup) ProbeTLDs(ctx context.Context) ([]string, merry.Error) {
 	logger := c.logger.With(zap.String("function", "prober"))
 	req := &protov3.MultiGlobRequest{
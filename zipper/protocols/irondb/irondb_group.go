@@ -14,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/go-graphite/carbonapi/limiter"
+	"github.com/go-graphite/carbonapi/zipper/helper"
 	"github.com/go-graphite/carbonapi/zipper/metadata"
 	"github.com/go-graphite/carbonapi/zipper/types"
 )
@@ -48,6 +49,9 @@ type IronDBGroup struct {
 	accountID      int64
 	graphiteRollup int64
 	graphitePrefix string
+
+	retention         int64
+	consolidationFunc string
 }
 
 func NewWithLimiter(logger *zap.Logger, config types.BackendV2, tldCacheDisabled, requireSuccessAll bool, limiter limiter.ServerLimiter) (types.BackendServer, merry.Error) {
@@ -191,6 +195,8 @@ func NewWithLimiter(logger *zap.Logger, config types.BackendV2, tldCacheDisabled
 		graphitePrefix = tmpStr
 	}
 
+	retention, consolidationFunc := helper.ParseInfoOptions(logger, config.BackendOptions, "irondb_retention", "average")
+
 	snowthClient, err := gosnowth.NewClient(context.Background(), cfg)
 	if err != nil {
 		logger.Fatal("failed to create snowth client",
@@ -208,6 +214,8 @@ func NewWithLimiter(logger *zap.Logger, config types.BackendV2, tldCacheDisabled
 		accountID:            accountID,
 		graphiteRollup:       graphiteRollup,
 		graphitePrefix:       graphitePrefix,
+		retention:            retention,
+		consolidationFunc:    consolidationFunc,
 		limiter:              limiter,
 		logger:               logger,
 	}
@@ -540,8 +548,20 @@ func (c *IronDBGroup) Find(ctx context.Context, request *protov3.MultiGlobReques
 	return &r, stats, nil
 }
 
+// Info describes requested metrics that exist in backend using graphite rollup and retention configured in
+// backendOptions, as IRONdb doesn't expose its rollup configuration
 func (c *IronDBGroup) Info(ctx context.Context, request *protov3.MultiMetricsInfoRequest) (*protov3.ZipperInfoResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotSupportedByBackend
+	found, stats, err := c.Find(ctx, &protov3.MultiGlobRequest{Metrics: request.Names})
+	if err != nil {
+		return nil, stats, err
+	}
+
+	r := &protov3.ZipperInfoResponse{
+		Info: map[string]protov3.MultiMetricsInfoResponse{
+			c.groupName: helper.SyntheticInfo(helper.FoundNames(request.Names, found), c.graphiteRollup, c.retention, c.consolidationFunc),
+		},
+	}
+	return r, stats, nil
 }
 
 func (c *IronDBGroup) List(ctx context.Context) (*protov3.ListMetricsResponse, *types.Stats, merry.Error) {
@@ -672,6 +692,44 @@ func (c *IronDBGroup) TagValues(ctx context.Context, query string, limit int64)
 	return c.doTagQuery(ctx, false, query, limit)
 }
 
+func (c *IronDBGroup) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	logger := c.logger.With(zap.String("type", "findSeries"))
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return []string{}, merry.Wrap(err)
+	}
+	if len(params["expr"]) == 0 {
+		return []string{}, types.ErrNoTagSpecified
+	}
+	target := strings.Join(params["expr"], ",")
+
+	logger.Debug("sending GraphiteFindTags request to irondb",
+		zap.Int64("accountID", c.accountID),
+		zap.String("prefix", c.graphitePrefix),
+		zap.String("target", target),
+	)
+	tagResult, err := c.client.GraphiteFindTags(c.accountID, c.graphitePrefix, target, nil)
+	if err != nil {
+		return []string{}, merry.New("request returned an error").WithValue("error", err)
+	}
+
+	result := make([]string, 0, len(tagResult))
+	seen := make(map[string]struct{}, len(tagResult))
+	for _, metric := range tagResult {
+		if _, ok := seen[metric.Name]; ok {
+			continue
+		}
+		seen[metric.Name] = struct{}{}
+		result = append(result, metric.Name)
+	}
+
+	if limit > 0 && int64(len(result)) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
 func (c *IronDBGroup) ProbeTLDs(ctx context.Context) ([]string, merry.Error) {
 	// ProbeTLDs is not really needed for IronDB but returning nil causing error
 	// so, let's return empty list
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -25,6 +26,14 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// defaultRetention is used by prometheus when retention isn't configured
+	defaultRetention       = 15 * 24 * 60 * 60
+	retentionProbeInterval = 10 * time.Minute
+	// retentionProbeFailureInterval is how long backend isn't probed again after failure
+	retentionProbeFailureInterval = time.Minute
+)
+
 func init() {
 	aliases := []string{"prometheus"}
 	metadata.Metadata.Lock()
@@ -74,6 +83,10 @@ type PrometheusGroup struct {
 
 	startDelay StartDelay
 
+	retention         int64
+	consolidationFunc string
+	retentionProber   *helper.RetentionProber
+
 	httpQuery *helper.HttpQuery
 }
 
@@ -194,6 +207,12 @@ func NewWithEverythingInitialized(logger *zap.Logger, config types.BackendV2, tl
 
 		httpQuery: httpQuery,
 	}
+	c.retention, c.consolidationFunc = helper.ParseInfoOptions(logger, config.BackendOptions, "retention", "last")
+	c.retentionProber = &helper.RetentionProber{
+		Interval:        retentionProbeInterval,
+		FailureInterval: retentionProbeFailureInterval,
+		Probe:           c.probeRetention,
+	}
 	return c, nil
 }
 
@@ -485,12 +504,90 @@ func (c *PrometheusGroup) Find(ctx context.Context, request *protov3.MultiGlobRe
 	return &r, stats, nil
 }
 
+// Info describes requested metrics that exist in backend using configured step and retention. Retention is probed from
+// backend's flags if it isn't set in backendOptions, failed probes are retried after retentionProbeFailureInterval
 func (c *PrometheusGroup) Info(ctx context.Context, request *protov3.MultiMetricsInfoRequest) (*protov3.ZipperInfoResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotSupportedByBackend
+	logger := c.logger.With(zap.String("type", "info"), zap.Strings("request", request.Names))
+	found, stats, err := c.Find(ctx, &protov3.MultiGlobRequest{Metrics: request.Names})
+	if err != nil {
+		return nil, stats, err
+	}
+
+	retention := c.retention
+	if retention == 0 {
+		retention, err = c.retentionProber.Retention(ctx)
+		if err != nil {
+			logger.Warn("failed to get retention from backend",
+				zap.Error(err),
+			)
+		}
+	}
+
+	r := &protov3.ZipperInfoResponse{
+		Info: map[string]protov3.MultiMetricsInfoResponse{
+			c.groupName: helper.SyntheticInfo(helper.FoundNames(request.Names, found), c.step, retention, c.consolidationFunc),
+		},
+	}
+	return r, stats, nil
+}
+
+// probeRetention gets retention from prometheus command-line flags
+func (c *PrometheusGroup) probeRetention(ctx context.Context) (int64, merry.Error) {
+	logger := c.logger.With(zap.String("type", "probeRetention"))
+	rewrite, _ := url.Parse("http://127.0.0.1/api/v1/status/flags")
+
+	res, err := c.httpQuery.DoQuery(ctx, logger, rewrite.RequestURI(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var pr prometheusTypes.PrometheusFlagsResponse
+	if err := json.Unmarshal(res.Response, &pr); err != nil {
+		return 0, types.ErrUnmarshalFailed.WithCause(err)
+	}
+	if pr.Status != "success" {
+		return 0, types.ErrFailedToFetch.WithMessage(pr.Error).WithValue("error_type", pr.ErrorType)
+	}
+
+	return retentionFromFlags(pr.Data)
+}
+
+// retentionFromFlags mimics prometheus: retention.time supersedes deprecated retention flag and if neither of them nor
+// retention.size is set, data is kept for 15 days
+func retentionFromFlags(flags map[string]string) (int64, merry.Error) {
+	for _, flag := range []string{"storage.tsdb.retention.time", "storage.tsdb.retention"} {
+		v, ok := flags[flag]
+		if !ok || v == "" {
+			continue
+		}
+		retention, err := helper.ParseRetention(v, 1)
+		if err != nil {
+			return 0, types.ErrUnmarshalFailed.WithCause(err).WithValue("flag", flag)
+		}
+		if retention > 0 {
+			return retention, nil
+		}
+	}
+	if size := flags["storage.tsdb.retention.size"]; size == "" || size == "0B" {
+		return defaultRetention, nil
+	}
+	return 0, nil
 }
 
+// List returns values of __name__ label
 func (c *PrometheusGroup) List(ctx context.Context) (*protov3.ListMetricsResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotImplementedYet
+	logger := c.logger.With(zap.String("type", "list"))
+	stats := &types.Stats{}
+
+	names, err := c.doSimpleTagQuery(ctx, logger, false, map[string][]string{"tag": {"__name__"}}, -1)
+	if err != nil {
+		if merry.Is(err, types.ErrTimeoutExceeded) {
+			stats.Timeouts++
+		}
+		return nil, stats, err
+	}
+
+	return &protov3.ListMetricsResponse{Metrics: names}, stats, nil
 }
 func (c *PrometheusGroup) Stats(ctx context.Context) (*protov3.MetricDetailsResponse, *types.Stats, merry.Error) {
 	return nil, nil, types.ErrNotSupportedByBackend
@@ -504,7 +601,7 @@ func (c *PrometheusGroup) doSimpleTagQuery(ctx context.Context, logger *zap.Logg
 		rewrite, _ = url.Parse("http://127.0.0.1/api/v1/labels")
 	} else {
 		logger = logger.With(zap.String("type", "tagValues"))
-		if tag, ok := params["Tag"]; ok {
+		if tag, ok := params["tag"]; ok {
 			rewrite, _ = url.Parse(fmt.Sprintf("http://127.0.0.1/api/v1/label/%s/values", tag[0]))
 		} else {
 			return []string{}, types.ErrNoTagSpecified
@@ -568,7 +665,7 @@ func (c *PrometheusGroup) doComplexTagQuery(ctx context.Context, isTagName bool,
 		logger = logger.With(zap.String("type", "tagName"))
 	} else {
 		logger = logger.With(zap.String("type", "tagValues"))
-		if _, ok := params["Tag"]; !ok {
+		if _, ok := params["tag"]; !ok {
 			return []string{}, types.ErrNoTagSpecified
 		}
 	}
@@ -625,7 +722,7 @@ func (c *PrometheusGroup) doComplexTagQuery(ctx context.Context, isTagName bool,
 		}
 
 		uniqueTagValues := make(map[string]struct{})
-		tag := params["Tag"][0]
+		tag := params["tag"][0]
 		for _, d := range r.Data {
 			if v, ok := d[tag]; ok {
 				if strings.HasPrefix(v, prefix) {
@@ -689,6 +786,64 @@ func (c *PrometheusGroup) TagValues(ctx context.Context, query string, limit int
 	return c.doTagQuery(ctx, false, query, limit)
 }
 
+// FindSeries returns names of the series matching all tag expressions, as graphite's /tags/findSeries does
+func (c *PrometheusGroup) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	logger := c.logger.With(zap.String("type", "findSeries"))
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return []string{}, merry.Wrap(err)
+	}
+	if len(params["expr"]) == 0 {
+		return []string{}, types.ErrNoTagSpecified
+	}
+
+	matchers := make([]string, 0, len(params["expr"]))
+	for _, e := range params["expr"] {
+		name, t := helpers.PromethizeTagValue(e)
+		if name == "name" {
+			name = "__name__"
+		}
+		matchers = append(matchers, name+t.OP+"\""+t.TagValue+"\"")
+	}
+
+	rewrite, _ := url.Parse("http://127.0.0.1/api/v1/series")
+	v := url.Values{
+		"match[]": []string{"{" + strings.Join(matchers, ",") + "}"},
+	}
+	rewrite.RawQuery = v.Encode()
+
+	var r prometheusTypes.PrometheusFindResponse
+	res, e := c.httpQuery.DoQuery(ctx, logger, rewrite.RequestURI(), nil)
+	if e != nil {
+		return []string{}, e
+	}
+
+	err = json.Unmarshal(res.Response, &r)
+	if err != nil {
+		return []string{}, merry.Wrap(err)
+	}
+
+	if r.Status != "success" {
+		return []string{}, merry.New("request returned an error").WithValue("status", r.Status).WithValue("error_type", r.ErrorType).WithValue("error", r.Error)
+	}
+
+	result := make([]string, 0, len(r.Data))
+	for _, d := range r.Data {
+		result = append(result, helpers.PromMetricToGraphite(d))
+	}
+	sort.Strings(result)
+
+	if limit > 0 && len(result) > int(limit) {
+		result = result[:int(limit)]
+	}
+
+	logger.Debug("got client response",
+		zap.Strings("result", result),
+	)
+
+	return result, nil
+}
+
 func (c *PrometheusGroup) ProbeTLDs(ctx context.Context) ([]string, merry.Error) {
 	logger := c.logger.With(zap.String("function", "prober"))
 	req := &protov3.MultiGlobRequest{
@@ -0,0 +1,65 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-graphite/carbonapi/zipper/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestTagValues(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v1/label/host/values":
+			_, _ = w.Write([]byte(`{"status":"success","data":["db1","web1","web2"]}`))
+		case "/api/v1/label/__name__/values":
+			_, _ = w.Write([]byte(`{"status":"success","data":["a.b","a.c"]}`))
+		case "/api/v1/series":
+			_, _ = w.Write([]byte(`{"status":"success","data":[{"__name__":"cpu","dc":"east","host":"web1"},{"__name__":"cpu","dc":"east","host":"db1"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	concurrency := 0
+	tries := 1
+	keepAlive := time.Second
+	idleTimeout := time.Second
+	config := types.BackendV2{
+		GroupName:             "test",
+		Servers:               []string{srv.URL},
+		ConcurrencyLimit:      &concurrency,
+		MaxIdleConnsPerHost:   &concurrency,
+		MaxTries:              &tries,
+		MaxBatchSize:          &concurrency,
+		KeepAliveInterval:     &keepAlive,
+		IdleConnectionTimeout: &idleTimeout,
+	}
+	config.FillDefaults()
+	group, err := New(zap.NewNop(), config, true, false)
+	assert.NoError(t, err)
+
+	// tag is passed in lower case, as in graphite's /tags/autoComplete/values
+	values, err := group.TagValues(context.Background(), "tag=host&valuePrefix=web", -1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"web1", "web2"}, values)
+
+	values, err = group.TagValues(context.Background(), "tag=host&expr=dc%3Deast", -1)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"db1", "web1"}, values)
+
+	_, err = group.TagValues(context.Background(), "Tag=host", -1)
+	assert.Error(t, err)
+
+	list, _, err := group.List(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.b", "a.c"}, list.Metrics)
+}
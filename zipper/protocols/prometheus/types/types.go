@@ -89,6 +89,13 @@ type PrometheusFindResponse struct {
 	Data      []map[string]string `json:"data"`
 }
 
+type PrometheusFlagsResponse struct {
+	Status    string            `json:"status"`
+	ErrorType string            `json:"errorType"`
+	Error     string            `json:"error"`
+	Data      map[string]string `json:"data"`
+}
+
 // Tag handles prometheus-specific tags
 type Tag struct {
 	TagValue string
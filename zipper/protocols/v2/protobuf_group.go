@@ -388,11 +388,82 @@ func (c *ClientProtoV2Group) TagValues(ctx context.Context, query string, limit
 	return c.doTagQuery(ctx, false, query, limit)
 }
 
+func (c *ClientProtoV2Group) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	logger := c.logger.With(zap.String("type", "findSeries"), zap.String("carbonapi_uuid", utilctx.GetUUID(ctx)))
+	return c.httpQuery.FindSeries(ctx, logger, query, limit)
+}
+
 func (c *ClientProtoV2Group) List(ctx context.Context) (*protov3.ListMetricsResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotImplementedYet
+	logger := c.logger.With(zap.String("type", "list"), zap.String("carbonapi_uuid", utilctx.GetUUID(ctx)))
+	stats := &types.Stats{}
+	rewrite, _ := url.Parse("http://127.0.0.1/metrics/list/")
+
+	v := url.Values{
+		"format": []string{format},
+	}
+	rewrite.RawQuery = v.Encode()
+
+	res, err := c.httpQuery.DoQuery(ctx, logger, rewrite.RequestURI(), nil)
+	if err != nil {
+		if merry.Is(err, types.ErrTimeoutExceeded) {
+			stats.Timeouts = 1
+		}
+		return nil, stats, err
+	}
+
+	var list protov2.ListMetricsResponse
+	if err2 := list.Unmarshal(res.Response); err2 != nil {
+		stats.FailedServers = []string{res.Server}
+		return nil, stats, types.ErrUnmarshalFailed.WithCause(err2)
+	}
+	stats.Servers = []string{res.Server}
+
+	return &protov3.ListMetricsResponse{Metrics: list.Metrics}, stats, nil
 }
+
 func (c *ClientProtoV2Group) Stats(ctx context.Context) (*protov3.MetricDetailsResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotImplementedYet
+	logger := c.logger.With(zap.String("type", "details"), zap.String("carbonapi_uuid", utilctx.GetUUID(ctx)))
+	stats := &types.Stats{}
+	rewrite, _ := url.Parse("http://127.0.0.1/metrics/details/")
+
+	v := url.Values{
+		"format": []string{format},
+	}
+	rewrite.RawQuery = v.Encode()
+
+	res, err := c.httpQuery.DoQuery(ctx, logger, rewrite.RequestURI(), nil)
+	if err != nil {
+		if merry.Is(err, types.ErrTimeoutExceeded) {
+			stats.Timeouts = 1
+		}
+		return nil, stats, err
+	}
+
+	var details protov2.MetricDetailsResponse
+	if err2 := details.Unmarshal(res.Response); err2 != nil {
+		stats.FailedServers = []string{res.Server}
+		return nil, stats, types.ErrUnmarshalFailed.WithCause(err2)
+	}
+	stats.Servers = []string{res.Server}
+
+	r := &protov3.MetricDetailsResponse{
+		Metrics:    make(map[string]*protov3.MetricDetails, len(details.Metrics)),
+		FreeSpace:  details.FreeSpace,
+		TotalSpace: details.TotalSpace,
+	}
+	for name, d := range details.Metrics {
+		if d == nil {
+			continue
+		}
+		r.Metrics[name] = &protov3.MetricDetails{
+			Size_:   d.Size_,
+			ModTime: d.ModTime,
+			ATime:   d.ATime,
+			RdTime:  d.RdTime,
+		}
+	}
+
+	return r, stats, nil
 }
 
 func (c *ClientProtoV2Group) ProbeTLDs(ctx context.Context) ([]string, merry.Error) {
@@ -3,8 +3,11 @@ package v3
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
+	"sync/atomic"
+	"time"
 
 	"github.com/ansel1/merry"
 	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
@@ -46,8 +49,31 @@ type ClientProtoV3Group struct {
 	maxMetricsPerRequest int
 
 	httpQuery *helper.HttpQuery
+
+	pushdownFunctions map[string]struct{}
+	// capabilities is a combination of capability* flags, result of capabilities probe
+	capabilities int32
+	// capabilitiesFailedAt is time (in nanoseconds) of the last failed capabilities probe
+	capabilitiesFailedAt int64
+	// capabilitiesProbedAt is time (in nanoseconds) of the last successful capabilities probe or of the refresh start
+	capabilitiesProbedAt int64
 }
 
+const (
+	capabilitiesProbed int32 = 1 << iota
+	capabilityFilteringFunctions
+	capabilityStreaming
+	capabilityHighPrecisionTimestamps
+)
+
+const (
+	// capabilitiesRetryInterval limits how often capabilities are probed while some of the servers are unavailable
+	capabilitiesRetryInterval = time.Minute
+	// capabilitiesTTL is how long probed capabilities are used before they are probed again, servers could be upgraded
+	// or downgraded meanwhile
+	capabilitiesTTL = 10 * time.Minute
+)
+
 func (c *ClientProtoV3Group) Children() []types.BackendServer {
 	return []types.BackendServer{c}
 }
@@ -84,6 +110,12 @@ func NewWithLimiter(logger *zap.Logger, config types.BackendV2, tldCacheDisabled
 
 		httpQuery: httpQuery,
 	}
+	if len(config.PushdownFunctions) > 0 {
+		c.pushdownFunctions = make(map[string]struct{}, len(config.PushdownFunctions))
+		for _, f := range config.PushdownFunctions {
+			c.pushdownFunctions[f] = struct{}{}
+		}
+	}
 	return c, nil
 }
 
@@ -106,6 +138,18 @@ func (c *ClientProtoV3Group) Fetch(ctx context.Context, request *protov3.MultiFe
 	rewrite, _ := url.Parse("http://127.0.0.1/render/")
 	logger := c.logger.With(zap.String("type", "fetch"), zap.String("request", request.String()))
 
+	if c.getCapabilities(ctx)&capabilityStreaming != 0 {
+		r := &protov3.MultiFetchResponse{}
+		err := c.fetchStream(ctx, logger, request, stats, func(response *protov3.MultiFetchResponse) merry.Error {
+			r.Metrics = append(r.Metrics, response.Metrics...)
+			return nil
+		})
+		if err != nil {
+			return nil, stats, err
+		}
+		return r, stats, nil
+	}
+
 	v := url.Values{
 		"format": []string{format},
 	}
@@ -142,6 +186,69 @@ func (c *ClientProtoV3Group) Fetch(ctx context.Context, request *protov3.MultiFe
 	return &r, stats, nil
 }
 
+// FetchStream passes every FetchResponse to fn as soon as it's received if all servers support streaming, otherwise
+// whole response is passed at once
+func (c *ClientProtoV3Group) FetchStream(ctx context.Context, request *protov3.MultiFetchRequest, fn func(response *protov3.MultiFetchResponse) merry.Error) (*types.Stats, merry.Error) {
+	if c.getCapabilities(ctx)&capabilityStreaming == 0 {
+		r, stats, err := c.Fetch(ctx, request)
+		if err != nil {
+			return stats, err
+		}
+		if r != nil && len(r.Metrics) > 0 {
+			if err := fn(r); err != nil {
+				return stats, err
+			}
+		}
+		return stats, nil
+	}
+
+	stats := &types.Stats{
+		RenderRequests: 1,
+	}
+	logger := c.logger.With(zap.String("type", "fetch"), zap.String("request", request.String()))
+	return stats, c.fetchStream(ctx, logger, request, stats, fn)
+}
+
+// fetchStream requests streamed response, so every FetchResponse is decoded and passed to fn as soon as it's received
+// and response body is never kept in memory as a whole
+func (c *ClientProtoV3Group) fetchStream(ctx context.Context, logger *zap.Logger, request *protov3.MultiFetchRequest, stats *types.Stats, fn func(response *protov3.MultiFetchResponse) merry.Error) merry.Error {
+	rewrite, _ := url.Parse("http://127.0.0.1/render/")
+	v := url.Values{
+		"format": []string{types.FetchStreamFormat},
+	}
+	rewrite.RawQuery = v.Encode()
+
+	var server string
+	err := c.httpQuery.DoQueryStream(ctx, logger, rewrite.RequestURI(), types.MultiFetchRequestV3{MultiFetchRequest: *request}, httpHeaders.ContentTypeCarbonAPIv3PBStream,
+		func(srv string, body io.Reader) merry.Error {
+			server = srv
+			err := types.ReadFetchStream(body, func(m *protov3.FetchResponse) error {
+				return fn(&protov3.MultiFetchResponse{Metrics: []protov3.FetchResponse{*m}})
+			})
+			if err != nil && ctx.Err() != nil {
+				return types.ErrTimeoutExceeded.WithValue("server", srv).WithCause(err)
+			}
+			return err
+		},
+	)
+	if err != nil {
+		stats.RenderErrors = 1
+		if merry.Is(err, types.ErrTimeoutExceeded) {
+			stats.Timeouts = 1
+			stats.RenderTimeouts = 1
+		}
+		if merry.Is(err, types.ErrUnmarshalFailed) && server != "" {
+			stats.FailedServers = []string{server}
+		}
+		logger.Warn("errors occurred while getting results",
+			zap.Any("errors", err),
+		)
+		return err
+	}
+
+	return nil
+}
+
 func (c *ClientProtoV3Group) Find(ctx context.Context, request *protov3.MultiGlobRequest) (*protov3.MultiGlobResponse, *types.Stats, merry.Error) {
 	logger := c.logger.With(zap.String("type", "find"), zap.Strings("request", request.Metrics))
 	stats := &types.Stats{
@@ -223,10 +330,61 @@ func (c *ClientProtoV3Group) Info(ctx context.Context, request *protov3.MultiMet
 }
 
 func (c *ClientProtoV3Group) List(ctx context.Context) (*protov3.ListMetricsResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotImplementedYet
+	logger := c.logger.With(zap.String("type", "list"))
+	stats := &types.Stats{}
+	rewrite, _ := url.Parse("http://127.0.0.1/metrics/list/")
+
+	v := url.Values{
+		"format": []string{format},
+	}
+	rewrite.RawQuery = v.Encode()
+
+	res, err := c.httpQuery.DoQuery(ctx, logger, rewrite.RequestURI(), nil)
+	if err != nil {
+		if merry.Is(err, types.ErrTimeoutExceeded) {
+			stats.Timeouts = 1
+		}
+		return nil, stats, err
+	}
+
+	var r protov3.ListMetricsResponse
+	if err2 := r.Unmarshal(res.Response); err2 != nil {
+		stats.FailedServers = []string{res.Server}
+		return nil, stats, types.ErrUnmarshalFailed.WithCause(err2)
+	}
+	stats.Servers = []string{res.Server}
+	stats.MemoryUsage = int64(r.Size())
+
+	return &r, stats, nil
 }
+
 func (c *ClientProtoV3Group) Stats(ctx context.Context) (*protov3.MetricDetailsResponse, *types.Stats, merry.Error) {
-	return nil, nil, types.ErrNotImplementedYet
+	logger := c.logger.With(zap.String("type", "details"))
+	stats := &types.Stats{}
+	rewrite, _ := url.Parse("http://127.0.0.1/metrics/details/")
+
+	v := url.Values{
+		"format": []string{format},
+	}
+	rewrite.RawQuery = v.Encode()
+
+	res, err := c.httpQuery.DoQuery(ctx, logger, rewrite.RequestURI(), nil)
+	if err != nil {
+		if merry.Is(err, types.ErrTimeoutExceeded) {
+			stats.Timeouts = 1
+		}
+		return nil, stats, err
+	}
+
+	var r protov3.MetricDetailsResponse
+	if err2 := r.Unmarshal(res.Response); err2 != nil {
+		stats.FailedServers = []string{res.Server}
+		return nil, stats, types.ErrUnmarshalFailed.WithCause(err2)
+	}
+	stats.Servers = []string{res.Server}
+	stats.MemoryUsage = int64(r.Size())
+
+	return &r, stats, nil
 }
 
 func (c *ClientProtoV3Group) doTagQuery(ctx context.Context, isTagName bool, query string, limit int64) ([]string, merry.Error) {
@@ -268,6 +426,11 @@ func (c *ClientProtoV3Group) TagValues(ctx context.Context, query string, limit
 	return c.doTagQuery(ctx, false, query, limit)
 }
 
+func (c *ClientProtoV3Group) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	logger := c.logger.With(zap.String("sub_type", "findSeries"))
+	return c.httpQuery.FindSeries(ctx, logger, query, limit)
+}
+
 func (c *ClientProtoV3Group) ProbeTLDs(ctx context.Context) ([]string, merry.Error) {
 	logger := c.logger.With(zap.String("function", "prober"))
 	req := &protov3.MultiGlobRequest{
@@ -299,3 +462,93 @@ func (c *ClientProtoV3Group) ProbeTLDs(ctx context.Context) ([]string, merry.Err
 
 	return tlds, nil
 }
+
+// PushdownFunctions returns functions from pushdownFunctions config if all servers of the group announced support
+// of filtering functions in /_internal/capabilities/
+func (c *ClientProtoV3Group) PushdownFunctions(ctx context.Context, pathExpression string) map[string]struct{} {
+	if len(c.pushdownFunctions) == 0 {
+		return nil
+	}
+
+	if c.getCapabilities(ctx)&capabilityFilteringFunctions == 0 {
+		return nil
+	}
+	return c.pushdownFunctions
+}
+
+// SupportsHighPrecisionTimestamps reports if all servers of the group announced support of millisecond timestamps in
+// /_internal/capabilities/
+func (c *ClientProtoV3Group) SupportsHighPrecisionTimestamps(ctx context.Context) bool {
+	return c.getCapabilities(ctx)&capabilityHighPrecisionTimestamps != 0
+}
+
+// getCapabilities returns capabilities supported by all servers of the group, probing them if it's not done yet or
+// they are older than capabilitiesTTL. Zero is returned while capabilities are unknown.
+func (c *ClientProtoV3Group) getCapabilities(ctx context.Context) int32 {
+	capabilities := atomic.LoadInt32(&c.capabilities)
+	if capabilities&capabilitiesProbed != 0 {
+		probedAt := atomic.LoadInt64(&c.capabilitiesProbedAt)
+		// only one request refreshes them, the rest use the previous ones meanwhile
+		if time.Since(time.Unix(0, probedAt)) < capabilitiesTTL ||
+			!atomic.CompareAndSwapInt64(&c.capabilitiesProbedAt, probedAt, time.Now().UnixNano()) {
+			return capabilities
+		}
+		if refreshed := c.probeCapabilities(ctx); refreshed != 0 {
+			return refreshed
+		}
+		return capabilities
+	}
+	if failedAt := atomic.LoadInt64(&c.capabilitiesFailedAt); failedAt != 0 && time.Since(time.Unix(0, failedAt)) < capabilitiesRetryInterval {
+		return 0
+	}
+	return c.probeCapabilities(ctx)
+}
+
+// probeCapabilities asks all servers for their capabilities. Result is remembered only if all of them answered.
+// Servers that don't know about capabilities (e.g. older versions) are treated as ones that support nothing.
+func (c *ClientProtoV3Group) probeCapabilities(ctx context.Context) int32 {
+	logger := c.logger.With(zap.String("type", "capabilities"))
+	rewrite, _ := url.Parse("http://127.0.0.1/_internal/capabilities/")
+
+	v := url.Values{
+		"format": []string{format},
+	}
+	rewrite.RawQuery = v.Encode()
+
+	res, err := c.httpQuery.DoQueryToAll(ctx, logger, rewrite.RequestURI(), types.CapabilityRequestV3{})
+	if err != nil {
+		logger.Debug("failed to get capabilities",
+			zap.Error(err),
+		)
+		atomic.StoreInt64(&c.capabilitiesFailedAt, time.Now().UnixNano())
+		return 0
+	}
+
+	capabilities := capabilitiesProbed | capabilityFilteringFunctions | capabilityStreaming | capabilityHighPrecisionTimestamps
+	for _, r := range res {
+		var response protov3.CapabilityResponse
+		if r == nil || response.Unmarshal(r.Response) != nil {
+			response = protov3.CapabilityResponse{}
+		}
+		if !response.SupportFilteringFunctions {
+			capabilities &^= capabilityFilteringFunctions
+		}
+		if !response.SupportStreaming {
+			capabilities &^= capabilityStreaming
+		}
+		if !response.HighPrecisionTimestamps {
+			capabilities &^= capabilityHighPrecisionTimestamps
+		}
+	}
+	if len(c.pushdownFunctions) > 0 && capabilities&capabilityFilteringFunctions == 0 {
+		logger.Warn("pushdownFunctions are set, but backends don't support filtering functions")
+	}
+	logger.Debug("got capabilities",
+		zap.Bool("filtering_functions", capabilities&capabilityFilteringFunctions != 0),
+		zap.Bool("streaming", capabilities&capabilityStreaming != 0),
+		zap.Bool("high_precision_timestamps", capabilities&capabilityHighPrecisionTimestamps != 0),
+	)
+	atomic.StoreInt64(&c.capabilitiesProbedAt, time.Now().UnixNano())
+	atomic.StoreInt32(&c.capabilities, capabilities)
+	return capabilities
+}
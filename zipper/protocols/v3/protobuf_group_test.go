@@ -0,0 +1,159 @@
+package v3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ansel1/merry"
+	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/go-graphite/carbonapi/zipper/types"
+)
+
+func newTestServer(t *testing.T, capabilities protov3.CapabilityResponse, formats *[]string) *httptest.Server {
+	metrics := []protov3.FetchResponse{
+		{Name: "a.b", PathExpression: "a.*", StartTime: 10, StopTime: 30, StepTime: 10, Values: []float64{1, 2}},
+		{Name: "a.c", PathExpression: "a.*", StartTime: 10, StopTime: 30, StepTime: 10, Values: []float64{3, 4}},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_internal/capabilities/":
+			b, _ := capabilities.Marshal()
+			_, _ = w.Write(b)
+		case "/render/":
+			body, _ := io.ReadAll(r.Body)
+			var request protov3.MultiFetchRequest
+			assert.NoError(t, request.Unmarshal(body))
+			format := r.FormValue("format")
+			*formats = append(*formats, format)
+			if format == types.FetchStreamFormat {
+				sw := types.NewFetchStreamWriter(w)
+				for i := range metrics {
+					_ = sw.Write(&metrics[i])
+				}
+				_ = sw.Close()
+				return
+			}
+			b, _ := (&protov3.MultiFetchResponse{Metrics: metrics}).Marshal()
+			_, _ = w.Write(b)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestFetchStreamNegotiation(t *testing.T) {
+	tests := []struct {
+		name         string
+		capabilities protov3.CapabilityResponse
+		wantFormat   string
+		// number of parts FetchStream passes the response in
+		wantParts int
+	}{
+		{
+			name:         "streaming",
+			capabilities: protov3.CapabilityResponse{SupportStreaming: true},
+			wantFormat:   types.FetchStreamFormat,
+			wantParts:    2,
+		},
+		{
+			name:         "fallback",
+			capabilities: protov3.CapabilityResponse{SupportFilteringFunctions: true},
+			wantFormat:   format,
+			wantParts:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var formats []string
+			srv := newTestServer(t, tt.capabilities, &formats)
+			defer srv.Close()
+
+			concurrency, tries := 10, 1
+			keepAlive, idleTimeout := 30*time.Second, time.Minute
+			config := types.BackendV2{
+				GroupName:             "test",
+				Servers:               []string{srv.URL},
+				ConcurrencyLimit:      &concurrency,
+				MaxIdleConnsPerHost:   &concurrency,
+				MaxTries:              &tries,
+				MaxBatchSize:          &concurrency,
+				KeepAliveInterval:     &keepAlive,
+				IdleConnectionTimeout: &idleTimeout,
+			}
+			config.FillDefaults()
+			group, err := New(zap.NewNop(), config, true, false)
+			assert.NoError(t, err)
+
+			request := &protov3.MultiFetchRequest{Metrics: []protov3.FetchRequest{{Name: "a.*", PathExpression: "a.*", StartTime: 10, StopTime: 30}}}
+			for i := 0; i < 2; i++ {
+				res, _, err := group.Fetch(context.Background(), request)
+				assert.NoError(t, err)
+				if assert.NotNil(t, res) && assert.Len(t, res.Metrics, 2) {
+					assert.Equal(t, "a.b", res.Metrics[0].Name)
+					assert.Equal(t, []float64{3, 4}, res.Metrics[1].Values)
+				}
+			}
+			assert.Equal(t, []string{tt.wantFormat, tt.wantFormat}, formats)
+
+			var parts [][]string
+			_, err = group.(types.StreamFetcher).FetchStream(context.Background(), request, func(response *protov3.MultiFetchResponse) merry.Error {
+				var names []string
+				for _, m := range response.Metrics {
+					names = append(names, m.Name)
+				}
+				parts = append(parts, names)
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Len(t, parts, tt.wantParts)
+			var names []string
+			for _, p := range parts {
+				names = append(names, p...)
+			}
+			assert.Equal(t, []string{"a.b", "a.c"}, names)
+		})
+	}
+}
+
+func TestCapabilitiesRefresh(t *testing.T) {
+	capabilities := protov3.CapabilityResponse{SupportFilteringFunctions: true}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := capabilities.Marshal()
+		_, _ = w.Write(b)
+	}))
+	defer srv.Close()
+
+	concurrency, tries := 10, 1
+	keepAlive, idleTimeout := 30*time.Second, time.Minute
+	config := types.BackendV2{
+		GroupName:             "test",
+		Servers:               []string{srv.URL},
+		ConcurrencyLimit:      &concurrency,
+		MaxIdleConnsPerHost:   &concurrency,
+		MaxTries:              &tries,
+		MaxBatchSize:          &concurrency,
+		KeepAliveInterval:     &keepAlive,
+		IdleConnectionTimeout: &idleTimeout,
+	}
+	config.FillDefaults()
+	group, err := New(zap.NewNop(), config, true, false)
+	assert.NoError(t, err)
+	c := group.(*ClientProtoV3Group)
+
+	assert.NotZero(t, c.getCapabilities(context.Background())&capabilityFilteringFunctions)
+
+	// backend was downgraded, but capabilities are refreshed only after they expire
+	capabilities = protov3.CapabilityResponse{}
+	assert.NotZero(t, c.getCapabilities(context.Background())&capabilityFilteringFunctions)
+
+	c.capabilitiesProbedAt = time.Now().Add(-capabilitiesTTL).UnixNano()
+	assert.Zero(t, c.getCapabilities(context.Background())&capabilityFilteringFunctions)
+	assert.NotZero(t, c.getCapabilities(context.Background())&capabilitiesProbed)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/url"
 	"strconv"
 	"strings"
@@ -18,10 +19,42 @@ import (
 )
 
 type fetchTarget struct {
-	name  string
-	start int64
-	stop  int64
-	step  string
+	name      string
+	start     int64
+	stop      int64
+	step      string
+	functions []*protov3.FilteringFunction
+	// start, stop and step are in milliseconds
+	highPrecision bool
+}
+
+// SupportsHighPrecisionTimestamps reports if VictoriaMetrics can be queried with millisecond timestamps. It's only
+// possible with graphite-optimized fetch, prometheus code-path is used for older versions and works in seconds.
+func (c *VictoriaMetricsGroup) SupportsHighPrecisionTimestamps(ctx context.Context) bool {
+	supportedFeatures, _ := c.featureSet.Load().(*vmSupportedFeatures)
+	return supportedFeatures != nil && supportedFeatures.SupportOptimizedGraphiteFetch
+}
+
+// adjustStepMs is helpers.AdjustStep for a time range in milliseconds. Step could be less than a second only if
+// configured step is, otherwise same steps as for requests in seconds are used.
+func (c *VictoriaMetricsGroup) adjustStepMs(start, stop, maxPointsPerQuery int64) int64 {
+	interval := stop - start
+	if c.forceMinStepInterval.Milliseconds() > interval {
+		return c.stepMs
+	}
+	safeStep := (interval + maxPointsPerQuery - 1) / maxPointsPerQuery
+	if safeStep <= c.stepMs {
+		return c.stepMs
+	}
+	return 1000 * helpers.AdjustStep(start/1000, stop/1000, maxPointsPerQuery, c.step, c.forceMinStepInterval)
+}
+
+// formatTimestamp formats timestamp for query_range: seconds, with a fraction for milliseconds
+func formatTimestamp(t int64, highPrecision bool) string {
+	if highPrecision {
+		return strconv.FormatFloat(float64(t)/1000, 'f', -1, 64)
+	}
+	return strconv.FormatInt(t, 10)
 }
 
 func (c *VictoriaMetricsGroup) Fetch(ctx context.Context, request *protov3.MultiFetchRequest) (*protov3.MultiFetchResponse, *types.Stats, merry.Error) {
@@ -49,15 +82,22 @@ func (c *VictoriaMetricsGroup) Fetch(ctx context.Context, request *protov3.Multi
 			maxPointsPerQuery = c.maxPointsPerQuery
 		}
 
-		step := helpers.AdjustStep(m.StartTime, m.StopTime, maxPointsPerQuery, c.step, c.forceMinStepInterval)
-		stepStr := strconv.FormatInt(step, 10)
+		var stepStr string
+		if m.HighPrecisionTimestamps {
+			stepStr = strconv.FormatInt(c.adjustStepMs(m.StartTime, m.StopTime, maxPointsPerQuery), 10) + "ms"
+		} else {
+			step := helpers.AdjustStep(m.StartTime, m.StopTime, maxPointsPerQuery, c.step, c.forceMinStepInterval)
+			stepStr = strconv.FormatInt(step, 10)
+		}
 
 		t := &fetchTarget{
-			name:  m.Name,
-			start: m.StartTime,
-			stop:  m.StopTime,
-			step:  stepStr,
+			name:          m.Name,
+			start:         m.StartTime,
+			stop:          m.StopTime,
+			step:          stepStr,
+			highPrecision: m.HighPrecisionTimestamps,
 		}
+		t.functions = m.FilterFunctions
 		targets := pathExprToTargets[m.PathExpression]
 		pathExprToTargets[m.PathExpression] = append(targets, t)
 	}
@@ -74,11 +114,22 @@ func (c *VictoriaMetricsGroup) Fetch(ctx context.Context, request *protov3.Multi
 			// rewrite metric for Tag
 			// Make local copy
 			stepLocalStr := target.step
+			var appliedFunctions []string
+			var pushdownName string
 			if strings.HasPrefix(target.name, "seriesByTag") {
 				target.name = strings.ReplaceAll(target.name, "'name=", "'__name__=")
 				stepLocalStr, target.name = helpers.SeriesByTagToPromQL(stepLocalStr, target.name)
 			} else {
-				target.name = fmt.Sprintf("{__graphite__=%q}", target.name)
+				query := fmt.Sprintf("{__graphite__=%q}", target.name)
+				if len(target.functions) > 0 {
+					if q, name, ok := c.pushdownQuery(query, target.name, target.functions); ok {
+						query, pushdownName = q, name
+						for _, f := range target.functions {
+							appliedFunctions = append(appliedFunctions, f.Name)
+						}
+					}
+				}
+				target.name = query
 			}
 			if stepLocalStr[len(stepLocalStr)-1] >= '0' && stepLocalStr[len(stepLocalStr)-1] <= '9' {
 				stepLocalStr += "s"
@@ -96,6 +147,9 @@ func (c *VictoriaMetricsGroup) Fetch(ctx context.Context, request *protov3.Multi
 				continue
 			}
 			stepLocal := int64(t.Seconds())
+			if target.highPrecision {
+				stepLocal = t.Milliseconds()
+			}
 
 			logger.Debug("will do query",
 				zap.String("query", target.name),
@@ -106,8 +160,8 @@ func (c *VictoriaMetricsGroup) Fetch(ctx context.Context, request *protov3.Multi
 			)
 			v := url.Values{
 				"query":        []string{target.name},
-				"start":        []string{strconv.Itoa(int(target.start))},
-				"end":          []string{strconv.Itoa(int(target.stop))},
+				"start":        []string{formatTimestamp(target.start, target.highPrecision)},
+				"end":          []string{formatTimestamp(target.stop, target.highPrecision)},
 				"step":         []string{stepLocalStr},
 				"max_lookback": []string{stepLocalStr},
 			}
@@ -157,6 +211,11 @@ func (c *VictoriaMetricsGroup) Fetch(ctx context.Context, request *protov3.Multi
 			for _, m := range response.Data.Result {
 				// We always should trust backend's response (to mimic behavior of graphite for grahpite native protoocols)
 				// See https://github.com/go-graphite/carbonapi/issues/504 and https://github.com/go-graphite/carbonapi/issues/514
+				if target.highPrecision {
+					for i := range m.Values {
+						m.Values[i].Timestamp = math.Round(m.Values[i].Timestamp * 1000)
+					}
+				}
 				realStart := target.start
 				realStop := target.stop
 				if len(m.Values) > 0 {
@@ -165,17 +224,23 @@ func (c *VictoriaMetricsGroup) Fetch(ctx context.Context, request *protov3.Multi
 				}
 				alignedValues := helpers.AlignValues(realStart, realStop, stepLocal, m.Values)
 
+				name := helpers.PromMetricToGraphite(m.Metric)
+				if appliedFunctions != nil && pushdownName != "" {
+					name = pushdownName
+				}
 				r.Metrics = append(r.Metrics, protov3.FetchResponse{
-					Name:              helpers.PromMetricToGraphite(m.Metric),
-					PathExpression:    pathExpr,
-					ConsolidationFunc: "Average",
-					StartTime:         realStart,
-					StopTime:          realStop,
-					StepTime:          stepLocal,
-					Values:            alignedValues,
-					XFilesFactor:      0.0,
-					RequestStartTime:  target.start,
-					RequestStopTime:   target.stop,
+					Name:                    name,
+					PathExpression:          pathExpr,
+					ConsolidationFunc:       "Average",
+					StartTime:               realStart,
+					StopTime:                realStop,
+					StepTime:                stepLocal,
+					Values:                  alignedValues,
+					XFilesFactor:            0.0,
+					RequestStartTime:        target.start,
+					RequestStopTime:         target.stop,
+					AppliedFunctions:        appliedFunctions,
+					HighPrecisionTimestamps: target.highPrecision,
 				})
 			}
 		}
@@ -0,0 +1,76 @@
+package victoriametrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdjustStepMs(t *testing.T) {
+	tests := []struct {
+		name                 string
+		step                 int64
+		stepMs               int64
+		forceMinStepInterval time.Duration
+		start, stop          int64
+		maxPointsPerQuery    int64
+		want                 int64
+	}{
+		{
+			name:              "sub-second step",
+			step:              1,
+			stepMs:            500,
+			start:             1000,
+			stop:              61000,
+			maxPointsPerQuery: 11000,
+			want:              500,
+		},
+		{
+			name:              "too many points",
+			step:              1,
+			stepMs:            500,
+			start:             0,
+			stop:              7200000,
+			maxPointsPerQuery: 11000,
+			want:              1000,
+		},
+		{
+			name:              "same steps as for seconds",
+			step:              15,
+			stepMs:            15000,
+			start:             0,
+			stop:              86400000,
+			maxPointsPerQuery: 1000,
+			want:              120000,
+		},
+		{
+			name:                 "forced min step",
+			step:                 1,
+			stepMs:               100,
+			forceMinStepInterval: time.Hour,
+			start:                0,
+			stop:                 3600000 - 1,
+			maxPointsPerQuery:    10,
+			want:                 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &VictoriaMetricsGroup{
+				step:                 tt.step,
+				stepMs:               tt.stepMs,
+				forceMinStepInterval: tt.forceMinStepInterval,
+			}
+			assert.Equal(t, tt.want, c.adjustStepMs(tt.start, tt.stop, tt.maxPointsPerQuery))
+		})
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	assert.Equal(t, "1500", formatTimestamp(1500, false))
+	assert.Equal(t, "1.5", formatTimestamp(1500, true))
+	assert.Equal(t, "2", formatTimestamp(2000, true))
+	assert.Equal(t, "0.001", formatTimestamp(1, true))
+}
@@ -0,0 +1,85 @@
+package victoriametrics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ansel1/merry"
+	"go.uber.org/zap"
+
+	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+
+	"github.com/go-graphite/carbonapi/zipper/helper"
+	"github.com/go-graphite/carbonapi/zipper/types"
+)
+
+const (
+	// retentionPeriod without unit is set in months, victoria-metrics treats month as 31 days
+	retentionPeriodUnit    = 31 * 24 * 60 * 60
+	retentionProbeInterval = 10 * time.Minute
+	// retentionProbeFailureInterval is how long backend isn't probed again after failure
+	retentionProbeFailureInterval = time.Minute
+)
+
+// Info describes requested metrics that exist in backend using configured step and retention. Retention is probed from
+// backend's flags if it isn't set in backendOptions, failed probes are retried after retentionProbeFailureInterval
+func (c *VictoriaMetricsGroup) Info(ctx context.Context, request *protov3.MultiMetricsInfoRequest) (*protov3.ZipperInfoResponse, *types.Stats, merry.Error) {
+	logger := c.logger.With(zap.String("type", "info"), zap.Strings("request", request.Names))
+	found, stats, err := c.Find(ctx, &protov3.MultiGlobRequest{Metrics: request.Names})
+	if err != nil {
+		return nil, stats, err
+	}
+
+	retention := c.retention
+	if retention == 0 {
+		retention, err = c.retentionProber.Retention(ctx)
+		if err != nil {
+			logger.Warn("failed to get retention from backend",
+				zap.Error(err),
+			)
+		}
+	}
+
+	r := &protov3.ZipperInfoResponse{
+		Info: map[string]protov3.MultiMetricsInfoResponse{
+			c.groupName: helper.SyntheticInfo(helper.FoundNames(request.Names, found), c.step, retention, c.consolidationFunc),
+		},
+	}
+	return r, stats, nil
+}
+
+// probeRetention gets -retentionPeriod from /flags. It's only available for single-node version, vmselect doesn't know
+// about retention, so 0 (unknown) is returned for cluster
+func (c *VictoriaMetricsGroup) probeRetention(ctx context.Context) (int64, merry.Error) {
+	logger := c.logger.With(zap.String("type", "probeRetention"))
+	rewrite, _ := url.Parse("http://127.0.0.1/flags")
+
+	res, err := c.httpQuery.DoQuery(ctx, logger, rewrite.RequestURI(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseRetentionPeriod(res.Response)
+}
+
+// parseRetentionPeriod parses flags in format `-retentionPeriod="1y"`, one per line
+func parseRetentionPeriod(flags []byte) (int64, merry.Error) {
+	scanner := bufio.NewScanner(bytes.NewReader(flags))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "-retentionPeriod=") {
+			continue
+		}
+		value := strings.Trim(strings.TrimPrefix(line, "-retentionPeriod="), `"`)
+		retention, err := helper.ParseRetention(value, retentionPeriodUnit)
+		if err != nil {
+			return 0, types.ErrUnmarshalFailed.WithCause(err).WithValue("flag", "retentionPeriod")
+		}
+		return retention, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,48 @@
+package victoriametrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetentionPeriod(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:  "months",
+			input: "-httpListenAddr=\":8428\"\n-retentionPeriod=\"1\"\n-search.maxUniqueTimeseries=\"300000\"\n",
+			want:  31 * 86400,
+		},
+		{
+			name:  "with unit",
+			input: "-retentionPeriod=\"1y\"\n",
+			want:  365 * 86400,
+		},
+		{
+			name:  "vmselect",
+			input: "-httpListenAddr=\":8481\"\n",
+			want:  0,
+		},
+		{
+			name:    "invalid",
+			input:   "-retentionPeriod=\"1x\"\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRetentionPeriod([]byte(tt.input))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
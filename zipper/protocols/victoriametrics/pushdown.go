@@ -0,0 +1,112 @@
+package victoriametrics
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+)
+
+// vmAggregateFunctions maps graphite functions to MetricsQL aggregate functions, so VictoriaMetrics can evaluate them
+var vmAggregateFunctions = map[string]string{
+	"sum":           "sum",
+	"sumSeries":     "sum",
+	"avg":           "avg",
+	"average":       "avg",
+	"averageSeries": "avg",
+	"min":           "min",
+	"minSeries":     "min",
+	"max":           "max",
+	"maxSeries":     "max",
+}
+
+// vmGroupByNodeCallbacks maps groupByNode callbacks to MetricsQL aggregate functions
+var vmGroupByNodeCallbacks = map[string]string{
+	"sum":     "sum",
+	"avg":     "avg",
+	"average": "avg",
+	"min":     "min",
+	"max":     "max",
+}
+
+// newPushdownFunctions returns functions that can be pushed down to VictoriaMetrics. If functions are set in config,
+// only these are allowed.
+func newPushdownFunctions(allowed []string) map[string]struct{} {
+	functions := make(map[string]struct{}, len(vmAggregateFunctions)+1)
+	supported := func(f string) bool {
+		_, ok := vmAggregateFunctions[f]
+		return ok || f == "groupByNode"
+	}
+	if len(allowed) == 0 {
+		for f := range vmAggregateFunctions {
+			functions[f] = struct{}{}
+		}
+		functions["groupByNode"] = struct{}{}
+		return functions
+	}
+	for _, f := range allowed {
+		if supported(f) {
+			functions[f] = struct{}{}
+		}
+	}
+	return functions
+}
+
+// PushdownFunctions returns aggregate functions that VictoriaMetrics can evaluate, it's only possible with graphite
+// fetch API (__graphite__ selector)
+func (c *VictoriaMetricsGroup) PushdownFunctions(ctx context.Context, pathExpression string) map[string]struct{} {
+	supportedFeatures, _ := c.featureSet.Load().(*vmSupportedFeatures)
+	if supportedFeatures == nil || !supportedFeatures.SupportOptimizedGraphiteFetch || len(c.pushdownFunctions) == 0 {
+		return nil
+	}
+	return c.pushdownFunctions
+}
+
+// groupByNodeArgs returns node and MetricsQL aggregate function for groupByNode arguments. Only non-negative node
+// numbers are supported, as label_graphite_group doesn't count nodes from the end.
+func groupByNodeArgs(args []string) (int, string, bool) {
+	if len(args) == 0 || len(args) > 2 {
+		return 0, "", false
+	}
+	node, err := strconv.Atoi(args[0])
+	if err != nil || node < 0 {
+		return 0, "", false
+	}
+	callback := "average"
+	if len(args) == 2 {
+		callback = strings.Trim(args[1], `'"`)
+	}
+	aggr, ok := vmGroupByNodeCallbacks[callback]
+	return node, aggr, ok
+}
+
+// pushdownQuery wraps query with MetricsQL equivalents of graphite functions. It returns name that graphite would give
+// to the resulting series (empty if series are named by VictoriaMetrics, as for groupByNode) and false if any of
+// functions can't be evaluated by VictoriaMetrics.
+func (c *VictoriaMetricsGroup) pushdownQuery(query, pathExpression string, functions []*protov3.FilteringFunction) (string, string, bool) {
+	expr, name := pathExpression, pathExpression
+	for _, f := range functions {
+		if _, ok := c.pushdownFunctions[f.Name]; !ok {
+			return "", "", false
+		}
+		if f.Name == "groupByNode" {
+			node, aggr, ok := groupByNodeArgs(f.Arguments)
+			if !ok {
+				return "", "", false
+			}
+			// label_graphite_group replaces name with the node, so series are aggregated by it
+			query = fmt.Sprintf("%s(label_graphite_group(%s, %d)) by (__name__)", aggr, query, node)
+			name = ""
+		} else {
+			if len(f.Arguments) > 0 {
+				return "", "", false
+			}
+			query = fmt.Sprintf("%s(%s)", vmAggregateFunctions[f.Name], query)
+			name = f.Name + "(" + expr + ")"
+		}
+		expr = f.Name + "(" + strings.Join(append([]string{expr}, f.Arguments...), ",") + ")"
+	}
+	return query, name, true
+}
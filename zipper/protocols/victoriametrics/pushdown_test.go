@@ -0,0 +1,69 @@
+package victoriametrics
+
+import (
+	"testing"
+
+	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+)
+
+func TestPushdownQuery(t *testing.T) {
+	c := &VictoriaMetricsGroup{pushdownFunctions: newPushdownFunctions([]string{"sumSeries", "maxSeries", "groupByNode", "unknownFunction"})}
+	if len(c.pushdownFunctions) != 3 {
+		t.Fatalf("unexpected pushdown functions %v", c.pushdownFunctions)
+	}
+
+	tests := []struct {
+		name      string
+		functions []*protov3.FilteringFunction
+		wantQuery string
+		wantName  string
+		wantOk    bool
+	}{
+		{
+			name:      "aggregates",
+			functions: []*protov3.FilteringFunction{{Name: "maxSeries"}, {Name: "sumSeries"}},
+			wantQuery: `sum(max({__graphite__="a.*.b"}))`,
+			wantName:  "sumSeries(maxSeries(a.*.b))",
+			wantOk:    true,
+		},
+		{
+			name:      "groupByNode",
+			functions: []*protov3.FilteringFunction{{Name: "groupByNode", Arguments: []string{"1", "'sum'"}}},
+			wantQuery: `sum(label_graphite_group({__graphite__="a.*.b"}, 1)) by (__name__)`,
+			wantOk:    true,
+		},
+		{
+			name:      "groupByNode default callback",
+			functions: []*protov3.FilteringFunction{{Name: "groupByNode", Arguments: []string{"1"}}, {Name: "maxSeries"}},
+			wantQuery: `max(avg(label_graphite_group({__graphite__="a.*.b"}, 1)) by (__name__))`,
+			wantName:  "maxSeries(groupByNode(a.*.b,1))",
+			wantOk:    true,
+		},
+		{
+			name:      "groupByNode negative node",
+			functions: []*protov3.FilteringFunction{{Name: "groupByNode", Arguments: []string{"-1", "'sum'"}}},
+		},
+		{
+			name:      "groupByNode unsupported callback",
+			functions: []*protov3.FilteringFunction{{Name: "groupByNode", Arguments: []string{"1", "'median'"}}},
+		},
+		{
+			name:      "not allowed in config",
+			functions: []*protov3.FilteringFunction{{Name: "averageSeries"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, name, ok := c.pushdownQuery(`{__graphite__="a.*.b"}`, "a.*.b", tt.functions)
+			if ok != tt.wantOk {
+				t.Fatalf("got ok=%v, expected %v", ok, tt.wantOk)
+			}
+			if query != tt.wantQuery {
+				t.Errorf("unexpected query %s", query)
+			}
+			if name != tt.wantName {
+				t.Errorf("unexpected name %s", name)
+			}
+		})
+	}
+}
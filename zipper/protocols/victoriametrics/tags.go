@@ -86,3 +86,43 @@ func (c *VictoriaMetricsGroup) TagValues(ctx context.Context, query string, limi
 	}
 	return c.doTagQuery(ctx, false, query, limit, supportedFeatures)
 }
+
+func (c *VictoriaMetricsGroup) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	supportedFeatures, _ := c.featureSet.Load().(*vmSupportedFeatures)
+	if !supportedFeatures.SupportGraphiteTagsAPI {
+		// VictoriaMetrics < 1.47.0 doesn't support graphite tags api, reverting back to prometheus code-path
+		return c.BackendServer.FindSeries(ctx, query, limit)
+	}
+
+	logger := c.logger.With(zap.String("type", "findSeries"))
+	var serverUrl string
+	if len(c.vmClusterTenantID) > 0 {
+		serverUrl = fmt.Sprintf("http://127.0.0.1/select/%s/graphite/tags/findSeries", c.vmClusterTenantID)
+	} else {
+		serverUrl = "http://127.0.0.1/tags/findSeries"
+	}
+	rewrite, _ := url.Parse(serverUrl)
+
+	var r []string
+
+	rewrite.RawQuery = query
+	res, e := c.httpQuery.DoQuery(ctx, logger, rewrite.RequestURI(), nil)
+	if e != nil {
+		return r, e
+	}
+
+	err := json.Unmarshal(res.Response, &r)
+	if err != nil {
+		return r, merry.Wrap(err)
+	}
+
+	if limit > 0 && len(r) > int(limit) {
+		r = r[:int(limit)]
+	}
+
+	logger.Debug("got client response",
+		zap.Strings("response", r),
+	)
+
+	return r, nil
+}
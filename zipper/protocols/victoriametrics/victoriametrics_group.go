@@ -50,6 +50,7 @@ type VictoriaMetricsGroup struct {
 	maxMetricsPerRequest int
 
 	step                 int64
+	stepMs               int64 // step with millisecond precision, used for requests with HighPrecisionTimestamps
 	maxPointsPerQuery    int64
 	forceMinStepInterval time.Duration
 	vmClusterTenantID    string
@@ -62,6 +63,12 @@ type VictoriaMetricsGroup struct {
 	parserPool fastjson.ParserPool
 
 	featureSet atomic.Value // *vmSupportedFeatures
+
+	retention         int64
+	consolidationFunc string
+	retentionProber   *helper.RetentionProber
+
+	pushdownFunctions map[string]struct{}
 }
 
 func NewWithLimiter(logger *zap.Logger, config types.BackendV2, tldCacheDisabled, requireSuccessAll bool, limiter limiter.ServerLimiter) (types.BackendServer, merry.Error) {
@@ -69,6 +76,7 @@ func NewWithLimiter(logger *zap.Logger, config types.BackendV2, tldCacheDisabled
 	httpClient := helper.GetHTTPClient(logger, config)
 
 	step := int64(15)
+	stepMs := step * 1000
 	var vmClusterTenantID string = ""
 	vmClusterTenantIDI, ok := config.BackendOptions["vmclustertenantid"]
 	if ok {
@@ -90,6 +98,11 @@ func NewWithLimiter(logger *zap.Logger, config types.BackendV2, tldCacheDisabled
 				)
 			}
 			step = int64(t.Seconds())
+			stepMs = t.Milliseconds()
+			if step < 1 {
+				// sub-second step is only used for requests with HighPrecisionTimestamps
+				step = 1
+			}
 		} else {
 			logger.Fatal("failed to parse step",
 				zap.String("type_parsed", fmt.Sprintf("%T", stepI)),
@@ -200,6 +213,8 @@ func NewWithLimiter(logger *zap.Logger, config types.BackendV2, tldCacheDisabled
 		}
 	}
 
+	retention, consolidationFunc := helper.ParseInfoOptions(logger, config.BackendOptions, "retention", "last")
+
 	httpQuery := helper.NewHttpQuery(config.GroupName, config.Servers, *config.MaxTries, limiter, httpClient, httpHeaders.ContentTypeCarbonAPIv2PB)
 
 	c := &VictoriaMetricsGroup{
@@ -211,17 +226,28 @@ func NewWithLimiter(logger *zap.Logger, config types.BackendV2, tldCacheDisabled
 		maxMetricsPerRequest: *config.MaxBatchSize,
 
 		step:                 step,
+		stepMs:               stepMs,
 		maxPointsPerQuery:    maxPointsPerQuery,
 		vmClusterTenantID:    vmClusterTenantID,
 		startDelay:           delay,
 		probeVersionInterval: probeVersionInterval,
 		fallbackVersion:      fallbackVersion,
+		retention:            retention,
+		consolidationFunc:    consolidationFunc,
 
 		client:  httpClient,
 		limiter: limiter,
 		logger:  logger,
 
 		httpQuery: httpQuery,
+
+		pushdownFunctions: newPushdownFunctions(config.PushdownFunctions),
+	}
+
+	c.retentionProber = &helper.RetentionProber{
+		Interval:        retentionProbeInterval,
+		FailureInterval: retentionProbeFailureInterval,
+		Probe:           c.probeRetention,
 	}
 
 	promLogger := logger.With(zap.String("subclass", "prometheus"))
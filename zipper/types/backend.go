@@ -32,6 +32,7 @@ type BackendV2 struct {
 	DoMultipleRequestsIfSplit bool                   `mapstructure:"doMultipleRequestsIfSplit"`
 	IdleConnectionTimeout     *time.Duration         `mapstructure:"idleConnectionTimeout"`
 	TLSClientConfig           *tlsconfig.TLSConfig   `mapstructure:"tlsClientConfig"`
+	PushdownFunctions         []string               `mapstructure:"pushdownFunctions"`
 }
 
 func (b *BackendV2) FillDefaults() {
@@ -30,6 +30,7 @@ var ErrNoTagSpecified = merry.New("no tag specified")
 var ErrNoServersSpecified = merry.New("no servers specified")
 var ErrConcurrencyLimitNotSet = merry.New("concurrency limit is not set")
 var ErrUnmarshalFailed = merry.New("unmarshal failed")
+var ErrFetchStreamFrameTooLarge = merry.New("fetch stream frame is too large")
 var ErrBackendError = merry.New("error fetching data from backend").WithHTTPCode(http.StatusServiceUnavailable)
 var ErrResponceError = merry.New("error while fetching Response")
 
@@ -28,6 +28,31 @@ type BackendServer interface {
 
 	TagNames(ctx context.Context, query string, limit int64) ([]string, merry.Error)
 	TagValues(ctx context.Context, query string, limit int64) ([]string, merry.Error)
+	FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error)
 
 	Children() []BackendServer
 }
+
+// FunctionPushdown is an optional interface for BackendServer that can apply functions passed in
+// FetchRequest.FilterFunctions on its side. Backend must set FetchResponse.AppliedFunctions for series it applied
+// functions to.
+type FunctionPushdown interface {
+	// PushdownFunctions returns functions backend can evaluate for all series matching pathExpression, nil if nothing
+	// can be pushed down (e.g. backend doesn't support it or series are spread across multiple backends)
+	PushdownFunctions(ctx context.Context, pathExpression string) map[string]struct{}
+}
+
+// StreamFetcher is an optional interface for BackendServer that passes fetched series to fn as soon as they are
+// received, so they could be merged without waiting for the whole response. fn could be called multiple times and
+// fetching stops on the first error returned by it.
+type StreamFetcher interface {
+	FetchStream(ctx context.Context, request *protov3.MultiFetchRequest, fn func(response *protov3.MultiFetchResponse) merry.Error) (*Stats, merry.Error)
+}
+
+// BackendInspector is an optional interface for BackendServer that exposes its runtime state
+type BackendInspector interface {
+	// Status returns state of the backend and its children
+	Status() BackendStatus
+	// TLDs returns TLDs found by the last ProbeTLDs and names of the children that have them
+	TLDs() map[string][]string
+}
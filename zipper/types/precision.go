@@ -0,0 +1,98 @@
+package types
+
+import (
+	"context"
+
+	"github.com/ansel1/merry"
+	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+)
+
+// HighPrecisionFetcher is implemented by backends which are able to serve fetch requests with millisecond timestamps
+// (FetchRequest.HighPrecisionTimestamps). Backends that don't implement it are treated as second-resolution ones.
+type HighPrecisionFetcher interface {
+	SupportsHighPrecisionTimestamps(ctx context.Context) bool
+}
+
+// SupportsHighPrecisionTimestamps reports if backend can be asked for millisecond timestamps directly
+func SupportsHighPrecisionTimestamps(ctx context.Context, backend BackendServer) bool {
+	hp, ok := backend.(HighPrecisionFetcher)
+	return ok && hp.SupportsHighPrecisionTimestamps(ctx)
+}
+
+// FetchWithPrecision calls backend.Fetch. If some of the metrics are requested with millisecond timestamps and backend
+// doesn't support them, request is converted to seconds and response is converted back to milliseconds.
+func FetchWithPrecision(ctx context.Context, backend BackendServer, request *protov3.MultiFetchRequest) (*protov3.MultiFetchResponse, *Stats, merry.Error) {
+	if !IsHighPrecisionRequest(request) || SupportsHighPrecisionTimestamps(ctx, backend) {
+		return backend.Fetch(ctx, request)
+	}
+
+	response, stats, err := backend.Fetch(ctx, FetchRequestToSeconds(request))
+	if response != nil {
+		for i := range response.Metrics {
+			FetchResponseToHighPrecision(&response.Metrics[i])
+		}
+	}
+	return response, stats, err
+}
+
+// FetchStreamWithPrecision is FetchWithPrecision for backends that implement StreamFetcher
+func FetchStreamWithPrecision(ctx context.Context, backend BackendServer, request *protov3.MultiFetchRequest, fn func(response *protov3.MultiFetchResponse) merry.Error) (*Stats, merry.Error) {
+	fetcher := backend.(StreamFetcher)
+	if !IsHighPrecisionRequest(request) || SupportsHighPrecisionTimestamps(ctx, backend) {
+		return fetcher.FetchStream(ctx, request, fn)
+	}
+
+	return fetcher.FetchStream(ctx, FetchRequestToSeconds(request), func(response *protov3.MultiFetchResponse) merry.Error {
+		for i := range response.Metrics {
+			FetchResponseToHighPrecision(&response.Metrics[i])
+		}
+		return fn(response)
+	})
+}
+
+// IsHighPrecisionRequest reports if any of the metrics is requested with millisecond timestamps
+func IsHighPrecisionRequest(request *protov3.MultiFetchRequest) bool {
+	for i := range request.Metrics {
+		if request.Metrics[i].HighPrecisionTimestamps {
+			return true
+		}
+	}
+	return false
+}
+
+// FetchRequestToSeconds returns a copy of request where millisecond time ranges are widened to whole seconds
+func FetchRequestToSeconds(request *protov3.MultiFetchRequest) *protov3.MultiFetchRequest {
+	r := &protov3.MultiFetchRequest{
+		Metrics: make([]protov3.FetchRequest, len(request.Metrics)),
+	}
+	for i, m := range request.Metrics {
+		if m.HighPrecisionTimestamps {
+			m.StartTime = floorDiv(m.StartTime, 1000)
+			m.StopTime = -floorDiv(-m.StopTime, 1000)
+			m.HighPrecisionTimestamps = false
+		}
+		r.Metrics[i] = m
+	}
+	return r
+}
+
+// FetchResponseToHighPrecision converts timestamps of response in seconds to milliseconds
+func FetchResponseToHighPrecision(r *protov3.FetchResponse) {
+	if r.HighPrecisionTimestamps {
+		return
+	}
+	r.StartTime *= 1000
+	r.StopTime *= 1000
+	r.StepTime *= 1000
+	r.RequestStartTime *= 1000
+	r.RequestStopTime *= 1000
+	r.HighPrecisionTimestamps = true
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ansel1/merry"
+	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	"github.com/stretchr/testify/assert"
+)
+
+type precisionBackend struct {
+	BackendServer
+	highPrecision bool
+	requests      []*protov3.MultiFetchRequest
+}
+
+func (b *precisionBackend) SupportsHighPrecisionTimestamps(ctx context.Context) bool {
+	return b.highPrecision
+}
+
+func (b *precisionBackend) Fetch(ctx context.Context, request *protov3.MultiFetchRequest) (*protov3.MultiFetchResponse, *Stats, merry.Error) {
+	b.requests = append(b.requests, request)
+	m := request.Metrics[0]
+	return &protov3.MultiFetchResponse{
+		Metrics: []protov3.FetchResponse{{
+			Name:                    m.Name,
+			StartTime:               m.StartTime,
+			StopTime:                m.StopTime,
+			StepTime:                1,
+			RequestStartTime:        m.StartTime,
+			RequestStopTime:         m.StopTime,
+			HighPrecisionTimestamps: m.HighPrecisionTimestamps,
+		}},
+	}, &Stats{}, nil
+}
+
+func TestFetchWithPrecision(t *testing.T) {
+	request := &protov3.MultiFetchRequest{
+		Metrics: []protov3.FetchRequest{
+			{Name: "a", StartTime: 100500, StopTime: 200500, HighPrecisionTimestamps: true},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		highPrecision bool
+		wantRequest   protov3.FetchRequest
+		wantResponse  protov3.FetchResponse
+	}{
+		{
+			name:          "supported",
+			highPrecision: true,
+			wantRequest:   protov3.FetchRequest{Name: "a", StartTime: 100500, StopTime: 200500, HighPrecisionTimestamps: true},
+			wantResponse:  protov3.FetchResponse{Name: "a", StartTime: 100500, StopTime: 200500, StepTime: 1, RequestStartTime: 100500, RequestStopTime: 200500, HighPrecisionTimestamps: true},
+		},
+		{
+			name:          "converted",
+			highPrecision: false,
+			wantRequest:   protov3.FetchRequest{Name: "a", StartTime: 100, StopTime: 201},
+			wantResponse:  protov3.FetchResponse{Name: "a", StartTime: 100000, StopTime: 201000, StepTime: 1000, RequestStartTime: 100000, RequestStopTime: 201000, HighPrecisionTimestamps: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := &precisionBackend{highPrecision: tt.highPrecision}
+			response, _, err := FetchWithPrecision(context.Background(), backend, request)
+			assert.NoError(t, err)
+			if assert.Len(t, backend.requests, 1) {
+				assert.Equal(t, tt.wantRequest, backend.requests[0].Metrics[0])
+			}
+			if assert.NotNil(t, response) && assert.Len(t, response.Metrics, 1) {
+				assert.Equal(t, tt.wantResponse, response.Metrics[0])
+			}
+		})
+	}
+
+	assert.True(t, request.Metrics[0].HighPrecisionTimestamps, "original request must not be modified")
+}
+
+func TestFetchRequestToSecondsNegative(t *testing.T) {
+	r := FetchRequestToSeconds(&protov3.MultiFetchRequest{
+		Metrics: []protov3.FetchRequest{
+			{StartTime: -1500, StopTime: -500, HighPrecisionTimestamps: true},
+			{StartTime: 10, StopTime: 20},
+		},
+	})
+	assert.Equal(t, []protov3.FetchRequest{
+		{StartTime: -2, StopTime: 0},
+		{StartTime: 10, StopTime: 20},
+	}, r.Metrics)
+}
@@ -34,6 +34,11 @@ func NoAnswerBackends(backends []BackendServer, answered map[string]struct{}) []
 	return noAnswer
 }
 
+// partialResponse is implemented by responses that could be sent by backend in parts
+type partialResponse interface {
+	IsPartial() bool
+}
+
 // Helper function
 func DoRequest(ctx context.Context, logger *zap.Logger, clients []BackendServer, result ServerFetcherResponse, request interface{}, fetcher Fetcher) (ServerFetcherResponse, int) {
 	resCh := make(chan ServerFetcherResponse, len(clients))
@@ -51,6 +56,13 @@ GATHER:
 	for responseCount < len(clients) {
 		select {
 		case res := <-resCh:
+			if p, ok := res.(partialResponse); ok && p.IsPartial() {
+				// part of the response that is streamed by backend, server will send the rest later
+				if err := result.MergeI(res); err != nil {
+					result.AddError(err)
+				}
+				continue
+			}
 			answeredServers[res.GetServer()] = struct{}{}
 			if err := result.MergeI(res); err == nil {
 				responseCount++
@@ -213,6 +225,171 @@ func (first *ServerInfoResponse) Merge(second *ServerInfoResponse) merry.Error {
 	return nil
 }
 
+type ServerListResponse struct {
+	Server   string
+	Response *protov3.ListMetricsResponse
+	Stats    *Stats
+	Err      []merry.Error
+
+	seen map[string]struct{}
+}
+
+func NewServerListResponse() *ServerListResponse {
+	return &ServerListResponse{
+		Response: &protov3.ListMetricsResponse{},
+		Stats:    new(Stats),
+	}
+}
+
+func (s *ServerListResponse) Self() interface{} {
+	return s
+}
+
+func (s ServerListResponse) GetServer() string {
+	return s.Server
+}
+
+func (first *ServerListResponse) MergeI(second ServerFetcherResponse) merry.Error {
+	secondSelf := second.Self()
+	s, ok := secondSelf.(*ServerListResponse)
+	if !ok {
+		return ErrResponseTypeMismatch.Here().WithMessagef("got '%T', expected '%T'", secondSelf, first)
+	}
+	return first.Merge(s)
+}
+
+func (s *ServerListResponse) AddError(err merry.Error) {
+	if err == nil {
+		return
+	}
+	if s.Err == nil {
+		s.Err = []merry.Error{err}
+	} else {
+		s.Err = append(s.Err, err)
+	}
+}
+
+func (first *ServerListResponse) Errors() []merry.Error {
+	return first.Err
+}
+
+// Merge adds metrics from the second response that are not in the first one yet, order is not preserved
+func (first *ServerListResponse) Merge(second *ServerListResponse) merry.Error {
+	if second.Stats != nil {
+		first.Stats.Merge(second.Stats)
+	}
+
+	if first.Err == nil {
+		if second.Err != nil {
+			first.Err = second.Err
+		}
+	} else {
+		if second.Err != nil {
+			first.Err = append(first.Err, second.Err...)
+		}
+	}
+
+	if second.Response == nil {
+		return nil
+	}
+
+	if first.seen == nil {
+		first.seen = make(map[string]struct{}, len(first.Response.Metrics)+len(second.Response.Metrics))
+		for _, m := range first.Response.Metrics {
+			first.seen[m] = struct{}{}
+		}
+	}
+	for _, m := range second.Response.Metrics {
+		if _, ok := first.seen[m]; !ok {
+			first.seen[m] = struct{}{}
+			first.Response.Metrics = append(first.Response.Metrics, m)
+		}
+	}
+
+	return nil
+}
+
+type ServerDetailsResponse struct {
+	Server   string
+	Response *protov3.MetricDetailsResponse
+	Stats    *Stats
+	Err      []merry.Error
+}
+
+func NewServerDetailsResponse() *ServerDetailsResponse {
+	return &ServerDetailsResponse{
+		Response: &protov3.MetricDetailsResponse{Metrics: make(map[string]*protov3.MetricDetails)},
+		Stats:    new(Stats),
+	}
+}
+
+func (s *ServerDetailsResponse) Self() interface{} {
+	return s
+}
+
+func (s ServerDetailsResponse) GetServer() string {
+	return s.Server
+}
+
+func (first *ServerDetailsResponse) MergeI(second ServerFetcherResponse) merry.Error {
+	secondSelf := second.Self()
+	s, ok := secondSelf.(*ServerDetailsResponse)
+	if !ok {
+		return ErrResponseTypeMismatch.Here().WithMessagef("got '%T', expected '%T'", secondSelf, first)
+	}
+	return first.Merge(s)
+}
+
+func (s *ServerDetailsResponse) AddError(err merry.Error) {
+	if err == nil {
+		return
+	}
+	if s.Err == nil {
+		s.Err = []merry.Error{err}
+	} else {
+		s.Err = append(s.Err, err)
+	}
+}
+
+func (first *ServerDetailsResponse) Errors() []merry.Error {
+	return first.Err
+}
+
+// Merge sums free and total space of the backends. If metric is stored on several backends, the most recently updated
+// copy is kept
+func (first *ServerDetailsResponse) Merge(second *ServerDetailsResponse) merry.Error {
+	if second.Stats != nil {
+		first.Stats.Merge(second.Stats)
+	}
+
+	if first.Err == nil {
+		if second.Err != nil {
+			first.Err = second.Err
+		}
+	} else {
+		if second.Err != nil {
+			first.Err = append(first.Err, second.Err...)
+		}
+	}
+
+	if second.Response == nil {
+		return nil
+	}
+
+	first.Response.FreeSpace += second.Response.FreeSpace
+	first.Response.TotalSpace += second.Response.TotalSpace
+	for name, d := range second.Response.Metrics {
+		if d == nil {
+			continue
+		}
+		if old, ok := first.Response.Metrics[name]; !ok || old.ModTime < d.ModTime {
+			first.Response.Metrics[name] = d
+		}
+	}
+
+	return nil
+}
+
 type ServerFindResponse struct {
 	Server   string
 	Response *protov3.MultiGlobResponse
@@ -323,6 +500,12 @@ type ServerFetchResponse struct {
 	Response *protov3.MultiFetchResponse
 	Stats    *Stats
 	Err      []merry.Error
+	// Partial is set for series that are sent as soon as backend streamed them, before the whole response is received
+	Partial bool
+
+	// index of Response.Metrics by coordinates, so merging series one by one doesn't have to rebuild it
+	index   map[fetchResponseCoordinates]int
+	indexed int
 }
 
 func NewServerFetchResponse() *ServerFetchResponse {
@@ -359,25 +542,36 @@ func (first *ServerFetchResponse) Merge(second *ServerFetchResponse) merry.Error
 		return nil
 	}
 
-	metrics := make(map[fetchResponseCoordinates]int)
-	for i := range first.Response.Metrics {
-		metrics[coordinates(&first.Response.Metrics[i])] = i
+	if first.index == nil || first.indexed != len(first.Response.Metrics) {
+		first.index = make(map[fetchResponseCoordinates]int, len(first.Response.Metrics))
+		for i := range first.Response.Metrics {
+			first.index[coordinates(&first.Response.Metrics[i])] = i
+		}
+		first.indexed = len(first.Response.Metrics)
 	}
 
 	for i := range second.Response.Metrics {
-		if j, ok := metrics[coordinates(&second.Response.Metrics[i])]; ok {
+		c := coordinates(&second.Response.Metrics[i])
+		if j, ok := first.index[c]; ok {
 			err := MergeFetchResponses(&first.Response.Metrics[j], &second.Response.Metrics[i])
 			if err != nil {
 				// TODO: Normal merry.Error handling
 				continue
 			}
 		} else {
+			first.index[c] = len(first.Response.Metrics)
 			first.Response.Metrics = append(first.Response.Metrics, second.Response.Metrics[i])
+			first.indexed++
 		}
 	}
 	return nil
 }
 
+// IsPartial returns true if response is a part of the response streamed by backend
+func (s *ServerFetchResponse) IsPartial() bool {
+	return s.Partial
+}
+
 func (first *ServerFetchResponse) MergeI(second ServerFetcherResponse) merry.Error {
 	secondSelf := second.Self()
 	s, ok := secondSelf.(*ServerFetchResponse)
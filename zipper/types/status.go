@@ -0,0 +1,23 @@
+package types
+
+import "time"
+
+const (
+	HealthUnknown = "unknown"
+	HealthOK      = "ok"
+	HealthFailed  = "failed"
+)
+
+// BackendStatus is a runtime state of the backend, see BackendInspector
+type BackendStatus struct {
+	Name    string   `json:"name"`
+	Servers []string `json:"servers"`
+	// Health is a result of the last TLD probe, it's unknown if TLD cache is disabled
+	Health           string          `json:"health"`
+	LastProbe        *time.Time      `json:"lastProbe,omitempty"`
+	LastProbeError   string          `json:"lastProbeError,omitempty"`
+	InFlight         int64           `json:"inFlight"`
+	WaitingForSlot   int64           `json:"waitingForSlot"`
+	ConcurrencyLimit int             `json:"concurrencyLimit"`
+	Children         []BackendStatus `json:"children,omitempty"`
+}
@@ -0,0 +1,112 @@
+package types
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/ansel1/merry"
+	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+)
+
+const (
+	// FetchStreamFormat is a format name of streamed fetch response: sequence of FetchResponse messages, each of them
+	// prefixed by its length + 1 encoded as uvarint, and 0 at the end, so stream that was cut could be told from the
+	// complete one
+	FetchStreamFormat = "carbonapi_v3_pb_stream"
+
+	fetchStreamBufferSize = 64 * 1024
+	// maxFetchStreamFrameSize protects from allocating huge buffers in case of garbage in the stream
+	maxFetchStreamFrameSize = 1 << 30
+)
+
+// FetchStreamWriter encodes FetchResponse messages one at a time, so whole MultiFetchResponse is never marshaled into
+// a single buffer
+type FetchStreamWriter struct {
+	w       *bufio.Writer
+	buf     []byte
+	written int64
+}
+
+func NewFetchStreamWriter(w io.Writer) *FetchStreamWriter {
+	return &FetchStreamWriter{
+		w: bufio.NewWriterSize(w, fetchStreamBufferSize),
+	}
+}
+
+// Write appends single FetchResponse to the stream
+func (s *FetchStreamWriter) Write(r *protov3.FetchResponse) error {
+	size := r.Size()
+	if cap(s.buf) < binary.MaxVarintLen64+size {
+		s.buf = make([]byte, binary.MaxVarintLen64+size)
+	}
+	buf := s.buf[:cap(s.buf)]
+	n := binary.PutUvarint(buf, uint64(size)+1)
+	m, err := r.MarshalTo(buf[n:])
+	if err != nil {
+		return err
+	}
+	_, err = s.w.Write(buf[:n+m])
+	if err != nil {
+		return err
+	}
+	s.written += int64(n + m)
+	return nil
+}
+
+// Flush writes buffered data to the underlying writer
+func (s *FetchStreamWriter) Flush() error {
+	return s.w.Flush()
+}
+
+// Close writes end of the stream and flushes it
+func (s *FetchStreamWriter) Close() error {
+	if err := s.w.WriteByte(0); err != nil {
+		return err
+	}
+	s.written++
+	return s.w.Flush()
+}
+
+// Written returns number of bytes written to the stream
+func (s *FetchStreamWriter) Written() int64 {
+	return s.written
+}
+
+// ReadFetchStream decodes FetchResponse messages from the stream and passes them to fn as soon as they are read.
+// Decoding stops on the first error returned by fn. Stream without end is reported as ErrUnmarshalFailed.
+func ReadFetchStream(r io.Reader, fn func(r *protov3.FetchResponse) error) merry.Error {
+	br := bufio.NewReaderSize(r, fetchStreamBufferSize)
+	var buf []byte
+	for {
+		size, err := binary.ReadUvarint(br)
+		if err == io.EOF {
+			return ErrUnmarshalFailed.WithMessage("fetch stream is incomplete")
+		}
+		if err != nil {
+			return ErrUnmarshalFailed.WithCause(err)
+		}
+		if size == 0 {
+			return nil
+		}
+		size--
+		if size > maxFetchStreamFrameSize {
+			return ErrFetchStreamFrameTooLarge.WithValue("size", size)
+		}
+		if uint64(cap(buf)) < size {
+			buf = make([]byte, size)
+		}
+		buf = buf[:size]
+		if _, err = io.ReadFull(br, buf); err != nil {
+			return ErrUnmarshalFailed.WithCause(err)
+		}
+
+		var m protov3.FetchResponse
+		if err = m.Unmarshal(buf); err != nil {
+			return ErrUnmarshalFailed.WithCause(err)
+		}
+		if err = fn(&m); err != nil {
+			return merry.Wrap(err)
+		}
+	}
+}
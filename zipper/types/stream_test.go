@@ -0,0 +1,63 @@
+package types
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/ansel1/merry"
+	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchStream(t *testing.T) {
+	responses := []protov3.FetchResponse{
+		{Name: "a.b", PathExpression: "a.*", StartTime: 10, StopTime: 40, StepTime: 10, Values: []float64{1, math.NaN(), 3}},
+		{},
+		{Name: "a.c", PathExpression: "a.*", StartTime: 10, StopTime: 20, StepTime: 10, Values: []float64{4}, AppliedFunctions: []string{"sumSeries"}},
+	}
+
+	var buf bytes.Buffer
+	w := NewFetchStreamWriter(&buf)
+	for i := range responses {
+		assert.NoError(t, w.Write(&responses[i]))
+	}
+	assert.NoError(t, w.Close())
+	assert.Equal(t, int64(buf.Len()), w.Written())
+
+	var got []protov3.FetchResponse
+	err := ReadFetchStream(bytes.NewReader(buf.Bytes()), func(r *protov3.FetchResponse) error {
+		got = append(got, *r)
+		return nil
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, got, len(responses)) {
+		assert.Equal(t, "a.b", got[0].Name)
+		assert.True(t, math.IsNaN(got[0].Values[1]))
+		assert.Equal(t, protov3.FetchResponse{}.Name, got[1].Name)
+		assert.Equal(t, responses[2], got[2])
+	}
+
+	// truncated stream
+	err = ReadFetchStream(bytes.NewReader(buf.Bytes()[:buf.Len()-2]), func(r *protov3.FetchResponse) error { return nil })
+	assert.True(t, merry.Is(err, ErrUnmarshalFailed))
+
+	// stream without end, e.g. server failed after some responses were sent
+	got = got[:0]
+	err = ReadFetchStream(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), func(r *protov3.FetchResponse) error {
+		got = append(got, *r)
+		return nil
+	})
+	assert.True(t, merry.Is(err, ErrUnmarshalFailed))
+	assert.Len(t, got, len(responses))
+
+	// garbage length
+	err = ReadFetchStream(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}), func(r *protov3.FetchResponse) error { return nil })
+	assert.True(t, merry.Is(err, ErrFetchStreamFrameTooLarge))
+
+	// empty stream
+	err = ReadFetchStream(bytes.NewReader([]byte{0}), func(r *protov3.FetchResponse) error { return nil })
+	assert.NoError(t, err)
+	err = ReadFetchStream(bytes.NewReader(nil), func(r *protov3.FetchResponse) error { return nil })
+	assert.True(t, merry.Is(err, ErrUnmarshalFailed))
+}
@@ -227,7 +227,20 @@ func (z *Zipper) probeTlds() {
 func (z Zipper) FetchProtoV3(ctx context.Context, request *protov3.MultiFetchRequest) (*protov3.MultiFetchResponse, *types.Stats, merry.Error) {
 	logger := z.logger.With(zap.String("function", "FetchProtoV3"), zap.String("carbonapi_uuid", utilctx.GetUUID(ctx)))
 
-	res, stats, e := z.backend.Fetch(ctx, request)
+	res, stats, e := types.FetchWithPrecision(ctx, z.backend, request)
+
+	// merged values are charged here and not by backend groups, as groups are nested and every one of them merges
+	// responses of its children
+	if memoryAccountant := utilctx.GetMemoryAccountant(ctx); memoryAccountant != nil && res != nil {
+		for i := range res.Metrics {
+			if err := memoryAccountant.ChargeValues(res.Metrics[i].Values); err != nil {
+				logger.Debug("memory limit exceeded while fetching data",
+					zap.Int64("memory_used", memoryAccountant.Peak()),
+				)
+				return nil, stats, merry.Wrap(err)
+			}
+		}
+	}
 
 	if e != nil {
 		logger.Debug("had errors while fetching result",
@@ -376,3 +389,50 @@ func (z Zipper) TagValues(ctx context.Context, query string, limit int64) ([]str
 
 	return data, nil
 }
+
+func (z Zipper) FindSeries(ctx context.Context, query string, limit int64) ([]string, merry.Error) {
+	logger := z.logger.With(zap.String("function", "FindSeries"), zap.String("carbonapi_uuid", utilctx.GetUUID(ctx)))
+	data, err := z.backend.FindSeries(ctx, query, limit)
+	if err != nil {
+		logger.Debug("had errors while fetching result",
+			zap.Any("errors", err),
+		)
+		return data, err
+	}
+
+	return data, nil
+}
+
+// PushdownFunctions returns functions that backends can evaluate for pathExpression, see types.FunctionPushdown
+func (z Zipper) PushdownFunctions(ctx context.Context, pathExpression string) map[string]struct{} {
+	if pd, ok := z.backend.(types.FunctionPushdown); ok {
+		return pd.PushdownFunctions(ctx, pathExpression)
+	}
+	return nil
+}
+
+// Status returns state of backend groups, see types.BackendInspector
+func (z Zipper) Status() types.BackendStatus {
+	if inspector, ok := z.backend.(types.BackendInspector); ok {
+		return inspector.Status()
+	}
+	return types.BackendStatus{
+		Name:    z.backend.Name(),
+		Servers: z.backend.Backends(),
+		Health:  types.HealthUnknown,
+	}
+}
+
+// TLDs returns TLD routing map: TLD -> backend groups that have it
+func (z Zipper) TLDs() map[string][]string {
+	if inspector, ok := z.backend.(types.BackendInspector); ok {
+		return inspector.TLDs()
+	}
+	return nil
+}
+
+// RefreshTLDs probes backends for TLDs right away and returns updated routing map
+func (z Zipper) RefreshTLDs(ctx context.Context) (map[string][]string, merry.Error) {
+	_, err := z.backend.ProbeTLDs(ctx)
+	return z.TLDs(), err
+}
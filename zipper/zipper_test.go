@@ -1,12 +1,18 @@
 package zipper
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/ansel1/merry"
+	"go.uber.org/zap"
 
+	utilctx "github.com/go-graphite/carbonapi/util/ctx"
+	"github.com/go-graphite/carbonapi/zipper/broadcast"
+	"github.com/go-graphite/carbonapi/zipper/dummy"
 	"github.com/go-graphite/carbonapi/zipper/types"
 	protov3 "github.com/go-graphite/protocol/carbonapi_v3_pb"
 )
@@ -209,3 +215,62 @@ func TestMergeValues(t *testing.T) {
 		})
 	}
 }
+
+func newTestGroup(t *testing.T, name string, servers []types.BackendServer) types.BackendServer {
+	timeouts := types.Timeouts{Find: time.Minute, Render: time.Minute, Connect: time.Minute}
+	b, err := broadcast.NewBroadcastGroup(zap.NewNop(), name, false, servers, 60, 0, 100, timeouts, true, false)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return b
+}
+
+func TestFetchChargesMemoryOnce(t *testing.T) {
+	request := &protov3.MultiFetchRequest{
+		Metrics: []protov3.FetchRequest{{Name: "foo.*", StartTime: 0, StopTime: 240, PathExpression: "foo.*"}},
+	}
+
+	var groups []types.BackendServer
+	for i, name := range []string{"foo.bar", "foo.baz"} {
+		client := dummy.NewDummyClient(fmt.Sprintf("client%d", i+1), []string{fmt.Sprintf("backend%d", i+1)}, 1)
+		client.AddFetchResponse(request, &protov3.MultiFetchResponse{
+			Metrics: []protov3.FetchResponse{{
+				Name:           name,
+				PathExpression: "foo.*",
+				StartTime:      0,
+				StopTime:       240,
+				StepTime:       60,
+				Values:         []float64{0, 1, 2, 3},
+			}},
+		}, &types.Stats{}, nil)
+		groups = append(groups, newTestGroup(t, fmt.Sprintf("group%d", i+1), []types.BackendServer{client}))
+	}
+
+	z := Zipper{
+		backend: newTestGroup(t, "root", groups),
+		logger:  zap.NewNop(),
+	}
+
+	accountant := utilctx.NewMemoryAccountant(1024)
+	res, _, err := z.FetchProtoV3(utilctx.SetMemoryAccountant(context.Background(), accountant), request)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(res.Metrics) != 2 {
+		t.Fatalf("got %d metrics, expected 2", len(res.Metrics))
+	}
+
+	var expected int64
+	for i := range res.Metrics {
+		expected += int64(cap(res.Metrics[i].Values)) * utilctx.Float64Size
+	}
+	if accountant.Peak() != expected {
+		t.Errorf("got %d bytes charged, expected %d", accountant.Peak(), expected)
+	}
+
+	accountant = utilctx.NewMemoryAccountant(expected - 1)
+	_, _, err = z.FetchProtoV3(utilctx.SetMemoryAccountant(context.Background(), accountant), request)
+	if !merry.Is(err, utilctx.ErrMemoryLimitExceeded) {
+		t.Errorf("got error %v, expected %v", err, utilctx.ErrMemoryLimitExceeded)
+	}
+}